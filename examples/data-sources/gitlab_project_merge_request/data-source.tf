@@ -0,0 +1,8 @@
+data "gitlab_project" "foo" {
+  id = "foo/bar/baz"
+}
+
+data "gitlab_project_merge_request" "feature" {
+  project = data.gitlab_project.foo.id
+  iid     = 1
+}
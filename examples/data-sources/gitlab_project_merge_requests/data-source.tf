@@ -0,0 +1,9 @@
+data "gitlab_project" "foo" {
+  id = "foo/bar/baz"
+}
+
+data "gitlab_project_merge_requests" "open_into_main" {
+  project       = data.gitlab_project.foo.id
+  state         = "opened"
+  target_branch = "main"
+}
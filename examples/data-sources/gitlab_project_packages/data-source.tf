@@ -0,0 +1,6 @@
+data "gitlab_project_packages" "example" {
+  project         = "foo/bar"
+  package_type    = "generic"
+  package_name    = "example"
+  package_version = "1.0.0"
+}
@@ -0,0 +1,4 @@
+data "gitlab_release" "v1" {
+  project  = "foo/bar"
+  tag_name = "v1.0.0"
+}
@@ -0,0 +1,6 @@
+data "gitlab_releases" "latest" {
+  project     = "foo/bar"
+  order_by    = "released_at"
+  sort        = "desc"
+  max_results = 5
+}
@@ -0,0 +1,5 @@
+# You can import this resource with an id made up of `{project-id}:{package-name}:{package-version}:{file-name}`, e.g.
+terraform import gitlab_project_generic_package_file.binary 42:example:1.0.0:example.tar.gz
+
+# NOTE: the `source` and `content` are not imported, the file is uploaded again
+#       if its checksum differs from the one of the configured file.
@@ -0,0 +1,21 @@
+resource "gitlab_project" "example" {
+  name        = "example"
+  description = "An example project"
+}
+
+resource "gitlab_project_generic_package_file" "binary" {
+  project         = gitlab_project.example.id
+  package_name    = "example"
+  package_version = "1.0.0"
+  file_name       = "example.tar.gz"
+  source          = "${path.module}/dist/example.tar.gz"
+}
+
+resource "gitlab_project_generic_package_file" "checksums" {
+  project         = gitlab_project.example.id
+  package_name    = gitlab_project_generic_package_file.binary.package_name
+  package_version = gitlab_project_generic_package_file.binary.package_version
+  file_name       = "sha256sums.txt"
+  content         = "${gitlab_project_generic_package_file.binary.sha256}  example.tar.gz\n"
+  status          = "hidden"
+}
@@ -0,0 +1,2 @@
+# You can import this resource with an id made up of `{project-id}:{merge-request-iid}`, e.g.
+terraform import gitlab_project_merge_request.feature 42:1
@@ -0,0 +1,25 @@
+resource "gitlab_project" "foo" {
+  name             = "example project"
+  description      = "Lorem Ipsum"
+  visibility_level = "public"
+}
+
+resource "gitlab_branch" "feature" {
+  project = gitlab_project.foo.id
+  name    = "feature"
+  ref     = "main"
+}
+
+resource "gitlab_project_merge_request" "feature" {
+  project              = gitlab_project.foo.id
+  source_branch        = gitlab_branch.feature.name
+  target_branch        = "main"
+  title                = "Add feature"
+  description          = "Please review!"
+  draft                = true
+  remove_source_branch = true
+}
+
+output "feature_merge_request_web_url" {
+  value = gitlab_project_merge_request.feature.web_url
+}
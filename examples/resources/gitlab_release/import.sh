@@ -0,0 +1,5 @@
+# You can import this resource with an id made up of `{project-id}:{tag-name}`, e.g.
+terraform import gitlab_release.v1 42:v1.0.0
+
+# NOTE: the asset links are not imported with the release, because the `asset_link` blocks only manage
+#       the links they define. Import existing links with the `gitlab_release_link` resource instead.
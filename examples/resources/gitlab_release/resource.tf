@@ -0,0 +1,34 @@
+resource "gitlab_project" "example" {
+  name                   = "example"
+  description            = "An example project"
+  initialize_with_readme = true
+}
+
+resource "gitlab_project_milestone" "v1" {
+  project = gitlab_project.example.id
+  title   = "v1.0"
+}
+
+resource "gitlab_release" "v1" {
+  project     = gitlab_project.example.id
+  tag_name    = "v1.0.0"
+  ref         = "main"
+  name        = "Version 1.0"
+  description = "The first stable release."
+  milestones  = [gitlab_project_milestone.v1.title]
+
+  asset_link {
+    name      = "binary"
+    url       = "https://example.com/downloads/example-v1.0.0"
+    filepath  = "/bin/example"
+    link_type = "package"
+  }
+}
+
+# Links can also be managed separately, e.g. in another module
+resource "gitlab_release_link" "docs" {
+  project  = gitlab_release.v1.project
+  tag_name = gitlab_release.v1.tag_name
+  name     = "documentation"
+  url      = "https://example.com/docs/v1.0.0"
+}
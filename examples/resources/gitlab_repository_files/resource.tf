@@ -0,0 +1,39 @@
+resource "gitlab_project" "this" {
+  name                   = "example"
+  initialize_with_readme = true
+}
+
+resource "gitlab_repository_files" "this" {
+  project        = gitlab_project.this.id
+  branch         = "main"
+  author_email   = "terraform@example.com"
+  author_name    = "Terraform"
+  commit_message = "feature: bootstrap the repository"
+
+  files = {
+    ".gitlab-ci.yml"   = file("${path.module}/files/.gitlab-ci.yml")
+    "scripts/build.sh" = file("${path.module}/files/build.sh")
+    // content will be used as is if it's already base64 encoded
+    "logo.png" = filebase64("${path.module}/files/logo.png")
+  }
+  execute_filemode = ["scripts/build.sh"]
+}
+
+// propose the changes to a protected branch with a merge request
+resource "gitlab_repository_files" "ci" {
+  project        = gitlab_project.this.id
+  branch         = "main"
+  commit_message = "ci: add the release job"
+
+  files = {
+    ".gitlab/ci/release.yml" = file("${path.module}/files/release.yml")
+  }
+
+  merge_request {
+    title        = "Add the release job"
+    description  = "Managed by Terraform"
+    labels       = ["terraform"]
+    assignee_ids = [gitlab_user.maintainer.id]
+    auto_merge   = true
+  }
+}
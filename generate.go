@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/generate"
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
+)
+
+// runGenerate runs the `generate` subcommand, which writes the configuration and the `import` blocks
+// for the existing resources of a group. The token is read from the `GITLAB_TOKEN` environment variable.
+func runGenerate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: terraform-provider-gitlab generate --group <id or full path> [options]\n\n")
+		fmt.Fprintf(flags.Output(), "Generates the configuration and the import blocks for the existing resources of a group.\n")
+		fmt.Fprintf(flags.Output(), "The token is read from the GITLAB_TOKEN environment variable.\n\n")
+		flags.PrintDefaults()
+	}
+	group := flags.String("group", "", "The ID or full path of the group to generate the configuration for.")
+	output := flags.String("output", "", "The file to write the configuration to, defaults to stdout.")
+	baseURL := flags.String("base-url", os.Getenv("GITLAB_BASE_URL"), "The GitLab base API endpoint, defaults to the GITLAB_BASE_URL environment variable or GitLab.com.")
+	includeSubgroups := flags.Bool("include-subgroups", true, "Generate the configuration for the descendant groups and their projects.")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *group == "" {
+		flags.Usage()
+		return errors.New("the --group flag is required")
+	}
+
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return errors.New("the GITLAB_TOKEN environment variable is required")
+	}
+
+	ctx := context.Background()
+	config := client.Config{
+		Token:         token,
+		BaseURL:       *baseURL,
+		EarlyAuthFail: true,
+	}
+	gitlabClient, err := config.NewGitLabClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return generate.Generate(ctx, gitlabClient, generate.Options{
+		Group:            *group,
+		IncludeSubgroups: *includeSubgroups,
+		Warnf:            log.Printf,
+	}, w)
+}
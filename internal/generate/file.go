@@ -0,0 +1,259 @@
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/xanzy/go-gitlab"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The access level names accepted by the membership and branch protection resources.
+var accessLevelValueToName = map[gitlab.AccessLevelValue]string{
+	gitlab.NoPermissions:            "no one",
+	gitlab.MinimalAccessPermissions: "minimal",
+	gitlab.GuestPermissions:         "guest",
+	gitlab.ReporterPermissions:      "reporter",
+	gitlab.DeveloperPermissions:     "developer",
+	gitlab.MaintainerPermissions:    "maintainer",
+	gitlab.OwnerPermissions:         "owner",
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// file is the generated configuration. The `variable` blocks for the secrets are written first,
+// then the resources, each followed by its `import` block.
+type file struct {
+	variables *hclwrite.File
+	resources *hclwrite.File
+	// names are the names already used, per resource type or `var` for the variables.
+	names map[string]map[string]bool
+}
+
+func newFile() *file {
+	return &file{
+		variables: hclwrite.NewEmptyFile(),
+		resources: hclwrite.NewEmptyFile(),
+		names:     map[string]map[string]bool{},
+	}
+}
+
+func (f *file) Bytes() []byte {
+	var b strings.Builder
+	b.WriteString("# Generated by `terraform-provider-gitlab generate`, review the configuration before applying it.\n")
+	b.WriteString("# The `import` blocks require Terraform 1.5 or later.\n\n")
+	if variables := f.variables.Bytes(); len(variables) > 0 {
+		b.Write(variables)
+	}
+	b.Write(f.resources.Bytes())
+	return hclwrite.Format([]byte(b.String()))
+}
+
+func (f *file) addGroup(group *gitlab.Group, parentName string) string {
+	name := f.uniqueName("gitlab_group", group.FullPath)
+	body := f.addResource("gitlab_group", name, strconv.Itoa(group.ID))
+	body.SetAttributeValue("name", cty.StringVal(group.Name))
+	body.SetAttributeValue("path", cty.StringVal(group.Path))
+	if parentName != "" {
+		body.SetAttributeTraversal("parent_id", reference("gitlab_group", parentName, "id"))
+	} else if group.ParentID != 0 {
+		body.SetAttributeValue("parent_id", cty.NumberIntVal(int64(group.ParentID)))
+	}
+	setNonEmptyString(body, "description", group.Description)
+	body.SetAttributeValue("visibility_level", cty.StringVal(string(group.Visibility)))
+	return name
+}
+
+func (f *file) addProject(project *gitlab.Project, groupName string) string {
+	name := f.uniqueName("gitlab_project", project.PathWithNamespace)
+	body := f.addResource("gitlab_project", name, strconv.Itoa(project.ID))
+	body.SetAttributeValue("name", cty.StringVal(project.Name))
+	body.SetAttributeValue("path", cty.StringVal(project.Path))
+	body.SetAttributeTraversal("namespace_id", reference("gitlab_group", groupName, "id"))
+	setNonEmptyString(body, "description", project.Description)
+	body.SetAttributeValue("visibility_level", cty.StringVal(string(project.Visibility)))
+	setNonEmptyString(body, "default_branch", project.DefaultBranch)
+	if project.Archived {
+		body.SetAttributeValue("archived", cty.True)
+	}
+	return name
+}
+
+// addVariable adds a CI/CD variable of a group or project. The value is never written,
+// it's referenced from a sensitive Terraform variable without default.
+func (f *file) addVariable(resourceType string, parentAttribute string, parentID string, parentName string, key string, environmentScope string, variableType gitlab.VariableTypeValue, protected bool, masked bool) {
+	value := parentName + "_" + key
+	if environmentScope != "" && environmentScope != "*" {
+		value += "_" + environmentScope
+	}
+	name := f.uniqueName(resourceType, value)
+	variableName := f.addSecretVariable(name, fmt.Sprintf("The value of the CI/CD variable %s of gitlab_%s.%s.", key, parentAttribute, parentName), false)
+
+	body := f.addResource(resourceType, name, parentID+":"+key+":"+environmentScope)
+	body.SetAttributeTraversal(parentAttribute, reference("gitlab_"+parentAttribute, parentName, "id"))
+	body.SetAttributeValue("key", cty.StringVal(key))
+	body.SetAttributeTraversal("value", hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: variableName}})
+	body.SetAttributeValue("variable_type", cty.StringVal(string(variableType)))
+	body.SetAttributeValue("protected", cty.BoolVal(protected))
+	body.SetAttributeValue("masked", cty.BoolVal(masked))
+	body.SetAttributeValue("environment_scope", cty.StringVal(environmentScope))
+}
+
+func (f *file) addLabel(resourceType string, parentAttribute string, parentID string, parentName string, labelName string, color string, description string) {
+	name := f.uniqueName(resourceType, parentName+"_"+labelName)
+	body := f.addResource(resourceType, name, parentID+":"+labelName)
+	body.SetAttributeTraversal(parentAttribute, reference("gitlab_"+parentAttribute, parentName, "id"))
+	body.SetAttributeValue("name", cty.StringVal(labelName))
+	body.SetAttributeValue("color", cty.StringVal(color))
+	setNonEmptyString(body, "description", description)
+}
+
+func (f *file) addMembership(resourceType string, parentAttribute string, parentID string, parentName string, userID int, username string, accessLevel gitlab.AccessLevelValue, expiresAt *gitlab.ISOTime) {
+	accessLevelName, ok := accessLevelValueToName[accessLevel]
+	if !ok {
+		return
+	}
+	name := f.uniqueName(resourceType, parentName+"_"+username)
+	body := f.addResource(resourceType, name, parentID+":"+strconv.Itoa(userID))
+	parentType := strings.TrimSuffix(parentAttribute, "_id")
+	body.SetAttributeTraversal(parentAttribute, reference("gitlab_"+parentType, parentName, "id"))
+	body.SetAttributeValue("user_id", cty.NumberIntVal(int64(userID)))
+	body.SetAttributeValue("access_level", cty.StringVal(accessLevelName))
+	if expiresAt != nil {
+		body.SetAttributeValue("expires_at", cty.StringVal(expiresAt.String()))
+	}
+}
+
+// addHook adds a webhook of a group or project. The token isn't returned by the API,
+// it's referenced from a sensitive Terraform variable which defaults to no token.
+func (f *file) addHook(resourceType string, parentAttribute string, parentID string, parentName string, hookID int, url string, attributes map[string]interface{}) {
+	name := f.uniqueName(resourceType, parentName+"_"+strconv.Itoa(hookID))
+	variableName := f.addSecretVariable(name+"_token", fmt.Sprintf("The secret token of the hook %s of gitlab_%s.%s.", url, parentAttribute, parentName), true)
+
+	body := f.addResource(resourceType, name, parentID+":"+strconv.Itoa(hookID))
+	body.SetAttributeTraversal(parentAttribute, reference("gitlab_"+parentAttribute, parentName, "id"))
+	body.SetAttributeValue("url", cty.StringVal(url))
+	body.SetAttributeTraversal("token", hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: variableName}})
+
+	var keys []string
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := attributes[key].(type) {
+		case bool:
+			body.SetAttributeValue(key, cty.BoolVal(value))
+		case string:
+			setNonEmptyString(body, key, value)
+		}
+	}
+}
+
+func (f *file) addBranchProtection(projectID string, projectName string, protectedBranch *gitlab.ProtectedBranch) {
+	name := f.uniqueName("gitlab_branch_protection", projectName+"_"+protectedBranch.Name)
+	body := f.addResource("gitlab_branch_protection", name, projectID+":"+protectedBranch.Name)
+	body.SetAttributeTraversal("project", reference("gitlab_project", projectName, "id"))
+	body.SetAttributeValue("branch", cty.StringVal(protectedBranch.Name))
+
+	levels := []struct {
+		attribute    string
+		block        string
+		descriptions []*gitlab.BranchAccessDescription
+	}{
+		{"push_access_level", "allowed_to_push", protectedBranch.PushAccessLevels},
+		{"merge_access_level", "allowed_to_merge", protectedBranch.MergeAccessLevels},
+		{"unprotect_access_level", "allowed_to_unprotect", protectedBranch.UnprotectAccessLevels},
+	}
+	for _, level := range levels {
+		for _, description := range level.descriptions {
+			if description.UserID != 0 || description.GroupID != 0 {
+				continue
+			}
+			if accessLevelName, ok := accessLevelValueToName[description.AccessLevel]; ok {
+				body.SetAttributeValue(level.attribute, cty.StringVal(accessLevelName))
+			}
+			break
+		}
+	}
+	body.SetAttributeValue("allow_force_push", cty.BoolVal(protectedBranch.AllowForcePush))
+	if protectedBranch.CodeOwnerApprovalRequired {
+		body.SetAttributeValue("code_owner_approval_required", cty.True)
+	}
+	for _, level := range levels {
+		for _, description := range level.descriptions {
+			switch {
+			case description.UserID != 0:
+				body.AppendNewline()
+				body.AppendNewBlock(level.block, nil).Body().SetAttributeValue("user_id", cty.NumberIntVal(int64(description.UserID)))
+			case description.GroupID != 0:
+				body.AppendNewline()
+				body.AppendNewBlock(level.block, nil).Body().SetAttributeValue("group_id", cty.NumberIntVal(int64(description.GroupID)))
+			}
+		}
+	}
+}
+
+// addResource adds a resource block and its import block, and returns the body of the resource block.
+func (f *file) addResource(resourceType string, name string, importID string) *hclwrite.Body {
+	root := f.resources.Body()
+	resource := root.AppendNewBlock("resource", []string{resourceType, name})
+	root.AppendNewline()
+
+	importBlock := root.AppendNewBlock("import", nil)
+	importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: name}})
+	importBlock.Body().SetAttributeValue("id", cty.StringVal(importID))
+	root.AppendNewline()
+
+	return resource.Body()
+}
+
+// addSecretVariable adds a sensitive string variable and returns its name.
+// Optional variables default to `null`, so that the attribute isn't set unless a value is given.
+func (f *file) addSecretVariable(name string, description string, optional bool) string {
+	name = f.uniqueName("var", name)
+	root := f.variables.Body()
+	body := root.AppendNewBlock("variable", []string{name}).Body()
+	body.SetAttributeValue("description", cty.StringVal(description))
+	body.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	body.SetAttributeValue("sensitive", cty.True)
+	if optional {
+		body.SetAttributeValue("default", cty.NullVal(cty.String))
+	}
+	root.AppendNewline()
+	return name
+}
+
+// uniqueName returns a valid Terraform identifier derived from the given value,
+// which isn't used yet for the given resource type.
+func (f *file) uniqueName(resourceType string, value string) string {
+	name := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(value), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	if f.names[resourceType] == nil {
+		f.names[resourceType] = map[string]bool{}
+	}
+	unique := name
+	for i := 2; f.names[resourceType][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	f.names[resourceType][unique] = true
+	return unique
+}
+
+func reference(resourceType string, name string, attribute string) hcl.Traversal {
+	return hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: name}, hcl.TraverseAttr{Name: attribute}}
+}
+
+func setNonEmptyString(body *hclwrite.Body, name string, value string) {
+	if value != "" {
+		body.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
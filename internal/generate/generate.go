@@ -0,0 +1,268 @@
+// Package generate generates the Terraform configuration for the existing resources of a GitLab group,
+// with the `import` blocks to import them into the Terraform state.
+package generate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/pager"
+)
+
+// Options configure which resources of a group are generated.
+type Options struct {
+	// Group is the ID or full path of the group to generate the configuration for.
+	Group string
+	// IncludeSubgroups generates the configuration for all descendant groups and their projects as well.
+	IncludeSubgroups bool
+	// Warnf is called for the resources which can't be read, e.g. because the token lacks the permissions.
+	// They are skipped and the generation continues.
+	Warnf func(format string, args ...interface{})
+}
+
+// Generate walks the group tree through the GitLab API and writes the configuration of its resources,
+// together with the `import` blocks using the import ID format of each resource, to w.
+// The values of CI/CD variables and the tokens of hooks are never written, they are referenced as
+// sensitive Terraform variables instead.
+func Generate(ctx context.Context, client *gitlab.Client, options Options, w io.Writer) error {
+	g := &generator{
+		ctx:     ctx,
+		client:  client,
+		options: options,
+		file:    newFile(),
+	}
+	if g.options.Warnf == nil {
+		g.options.Warnf = func(string, ...interface{}) {}
+	}
+
+	group, _, err := client.Groups.GetGroup(options.Group, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to read group %q: %w", options.Group, err)
+	}
+	if err := g.generateGroup(group, ""); err != nil {
+		return err
+	}
+
+	_, err = w.Write(g.file.Bytes())
+	return err
+}
+
+type generator struct {
+	ctx     context.Context
+	client  *gitlab.Client
+	options Options
+	file    *file
+}
+
+func (g *generator) generateGroup(group *gitlab.Group, parentName string) error {
+	name := g.file.addGroup(group, parentName)
+	groupID := strconv.Itoa(group.ID)
+
+	g.generateGroupVariables(groupID, name)
+	g.generateGroupLabels(groupID, name)
+	g.generateGroupMembers(groupID, name)
+	g.generateGroupHooks(groupID, name)
+
+	projects, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+		return g.client.Groups.ListGroupProjects(group.ID, &gitlab.ListGroupProjectsOptions{
+			ListOptions: listOptions,
+			WithShared:  gitlab.Bool(false),
+		}, requestOptions...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the projects of group %q: %w", group.FullPath, err)
+	}
+	for _, project := range projects {
+		g.generateProject(project, name)
+	}
+
+	if !g.options.IncludeSubgroups {
+		return nil
+	}
+	subgroups, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Group, *gitlab.Response, error) {
+		return g.client.Groups.ListSubGroups(group.ID, &gitlab.ListSubGroupsOptions{ListOptions: listOptions}, requestOptions...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the subgroups of group %q: %w", group.FullPath, err)
+	}
+	for _, subgroup := range subgroups {
+		if err := g.generateGroup(subgroup, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) generateProject(project *gitlab.Project, groupName string) {
+	name := g.file.addProject(project, groupName)
+	projectID := strconv.Itoa(project.ID)
+
+	g.generateProjectVariables(projectID, name)
+	g.generateProjectLabels(projectID, name)
+	g.generateProjectMembers(projectID, name)
+	g.generateProjectHooks(projectID, name)
+	g.generateBranchProtections(projectID, name)
+}
+
+func (g *generator) generateGroupVariables(groupID string, groupName string) {
+	variables, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.GroupVariable, *gitlab.Response, error) {
+		return g.client.GroupVariables.ListVariables(groupID, &gitlab.ListGroupVariablesOptions{Page: listOptions.Page, PerPage: listOptions.PerPage}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the variables of group %s: %v", groupID, err)
+		return
+	}
+	for _, variable := range variables {
+		g.file.addVariable("gitlab_group_variable", "group", groupID, groupName, variable.Key, variable.EnvironmentScope, variable.VariableType, variable.Protected, variable.Masked)
+	}
+}
+
+func (g *generator) generateProjectVariables(projectID string, projectName string) {
+	variables, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
+		return g.client.ProjectVariables.ListVariables(projectID, &gitlab.ListProjectVariablesOptions{Page: listOptions.Page, PerPage: listOptions.PerPage}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the variables of project %s: %v", projectID, err)
+		return
+	}
+	for _, variable := range variables {
+		g.file.addVariable("gitlab_project_variable", "project", projectID, projectName, variable.Key, variable.EnvironmentScope, variable.VariableType, variable.Protected, variable.Masked)
+	}
+}
+
+func (g *generator) generateGroupLabels(groupID string, groupName string) {
+	labels, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.GroupLabel, *gitlab.Response, error) {
+		return g.client.GroupLabels.ListGroupLabels(groupID, &gitlab.ListGroupLabelsOptions{
+			ListOptions:           listOptions,
+			IncludeAncestorGroups: gitlab.Bool(false),
+			OnlyGroupLabels:       gitlab.Bool(true),
+		}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the labels of group %s: %v", groupID, err)
+		return
+	}
+	for _, label := range labels {
+		g.file.addLabel("gitlab_group_label", "group", groupID, groupName, label.Name, label.Color, label.Description)
+	}
+}
+
+func (g *generator) generateProjectLabels(projectID string, projectName string) {
+	labels, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Label, *gitlab.Response, error) {
+		return g.client.Labels.ListLabels(projectID, &gitlab.ListLabelsOptions{
+			ListOptions:           listOptions,
+			IncludeAncestorGroups: gitlab.Bool(false),
+		}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the labels of project %s: %v", projectID, err)
+		return
+	}
+	for _, label := range labels {
+		// the labels of the groups are generated with the groups
+		if !label.IsProjectLabel {
+			continue
+		}
+		g.file.addLabel("gitlab_label", "project", projectID, projectName, label.Name, label.Color, label.Description)
+	}
+}
+
+func (g *generator) generateGroupMembers(groupID string, groupName string) {
+	members, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.GroupMember, *gitlab.Response, error) {
+		return g.client.Groups.ListGroupMembers(groupID, &gitlab.ListGroupMembersOptions{ListOptions: listOptions}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the members of group %s: %v", groupID, err)
+		return
+	}
+	for _, member := range members {
+		g.file.addMembership("gitlab_group_membership", "group_id", groupID, groupName, member.ID, member.Username, member.AccessLevel, member.ExpiresAt)
+	}
+}
+
+func (g *generator) generateProjectMembers(projectID string, projectName string) {
+	members, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectMember, *gitlab.Response, error) {
+		return g.client.ProjectMembers.ListProjectMembers(projectID, &gitlab.ListProjectMembersOptions{ListOptions: listOptions}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the members of project %s: %v", projectID, err)
+		return
+	}
+	for _, member := range members {
+		g.file.addMembership("gitlab_project_membership", "project_id", projectID, projectName, member.ID, member.Username, member.AccessLevel, member.ExpiresAt)
+	}
+}
+
+func (g *generator) generateGroupHooks(groupID string, groupName string) {
+	hooks, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.GroupHook, *gitlab.Response, error) {
+		return g.client.Groups.ListGroupHooks(groupID, &gitlab.ListGroupHooksOptions{Page: listOptions.Page, PerPage: listOptions.PerPage}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the hooks of group %s: %v", groupID, err)
+		return
+	}
+	for _, hook := range hooks {
+		g.file.addHook("gitlab_group_hook", "group", groupID, groupName, hook.ID, hook.URL, map[string]interface{}{
+			"push_events":                hook.PushEvents,
+			"push_events_branch_filter":  hook.PushEventsBranchFilter,
+			"issues_events":              hook.IssuesEvents,
+			"confidential_issues_events": hook.ConfidentialIssuesEvents,
+			"merge_requests_events":      hook.MergeRequestsEvents,
+			"tag_push_events":            hook.TagPushEvents,
+			"note_events":                hook.NoteEvents,
+			"confidential_note_events":   hook.ConfidentialNoteEvents,
+			"job_events":                 hook.JobEvents,
+			"pipeline_events":            hook.PipelineEvents,
+			"wiki_page_events":           hook.WikiPageEvents,
+			"deployment_events":          hook.DeploymentEvents,
+			"releases_events":            hook.ReleasesEvents,
+			"subgroup_events":            hook.SubGroupEvents,
+			"enable_ssl_verification":    hook.EnableSSLVerification,
+		})
+	}
+}
+
+func (g *generator) generateProjectHooks(projectID string, projectName string) {
+	hooks, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectHook, *gitlab.Response, error) {
+		return g.client.Projects.ListProjectHooks(projectID, &gitlab.ListProjectHooksOptions{Page: listOptions.Page, PerPage: listOptions.PerPage}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the hooks of project %s: %v", projectID, err)
+		return
+	}
+	for _, hook := range hooks {
+		g.file.addHook("gitlab_project_hook", "project", projectID, projectName, hook.ID, hook.URL, map[string]interface{}{
+			"push_events":                hook.PushEvents,
+			"push_events_branch_filter":  hook.PushEventsBranchFilter,
+			"issues_events":              hook.IssuesEvents,
+			"confidential_issues_events": hook.ConfidentialIssuesEvents,
+			"merge_requests_events":      hook.MergeRequestsEvents,
+			"tag_push_events":            hook.TagPushEvents,
+			"note_events":                hook.NoteEvents,
+			"confidential_note_events":   hook.ConfidentialNoteEvents,
+			"job_events":                 hook.JobEvents,
+			"pipeline_events":            hook.PipelineEvents,
+			"wiki_page_events":           hook.WikiPageEvents,
+			"deployment_events":          hook.DeploymentEvents,
+			"releases_events":            hook.ReleasesEvents,
+			"enable_ssl_verification":    hook.EnableSSLVerification,
+		})
+	}
+}
+
+func (g *generator) generateBranchProtections(projectID string, projectName string) {
+	protectedBranches, err := pager.Paginate(g.ctx, pager.Options{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProtectedBranch, *gitlab.Response, error) {
+		return g.client.ProtectedBranches.ListProtectedBranches(projectID, &gitlab.ListProtectedBranchesOptions{Page: listOptions.Page, PerPage: listOptions.PerPage}, requestOptions...)
+	})
+	if err != nil {
+		g.options.Warnf("skipping the protected branches of project %s: %v", projectID, err)
+		return
+	}
+	for _, protectedBranch := range protectedBranches {
+		g.file.addBranchProtection(projectID, projectName, protectedBranch)
+	}
+}
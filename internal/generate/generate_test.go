@@ -0,0 +1,138 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestGenerate(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	client := fake.Client
+
+	group, _, err := client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("Acme"), Path: gitlab.String("acme")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	subgroup, _, err := client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("Platform"), Path: gitlab.String("platform"), ParentID: gitlab.Int(group.ID)})
+	if err != nil {
+		t.Fatalf("failed to create subgroup: %v", err)
+	}
+	project, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+		Name:                 gitlab.String("API"),
+		Path:                 gitlab.String("api"),
+		NamespaceID:          gitlab.Int(subgroup.ID),
+		InitializeWithReadme: gitlab.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if _, _, err := client.ProjectVariables.CreateVariable(project.ID, &gitlab.CreateProjectVariableOptions{
+		Key:              gitlab.String("DEPLOY_TOKEN"),
+		Value:            gitlab.String("super-secret-value"),
+		Masked:           gitlab.Bool(true),
+		EnvironmentScope: gitlab.String("production"),
+	}); err != nil {
+		t.Fatalf("failed to create variable: %v", err)
+	}
+	if _, _, err := client.GroupLabels.CreateGroupLabel(group.ID, &gitlab.CreateGroupLabelOptions{Name: gitlab.String("bug"), Color: gitlab.String("#FF0000")}); err != nil {
+		t.Fatalf("failed to create group label: %v", err)
+	}
+	if _, _, err := client.Projects.AddProjectHook(project.ID, &gitlab.AddProjectHookOptions{URL: gitlab.String("https://example.com/hook"), PushEvents: gitlab.Bool(true)}); err != nil {
+		t.Fatalf("failed to create hook: %v", err)
+	}
+
+	var warnings []string
+	var out bytes.Buffer
+	err = Generate(context.Background(), client, Options{
+		Group:            "acme",
+		IncludeSubgroups: true,
+		Warnf: func(format string, args ...interface{}) {
+			warnings = append(warnings, format)
+		},
+	}, &out)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if len(warnings) > 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	generated := out.String()
+
+	if _, diags := hclsyntax.ParseConfig(out.Bytes(), "generated.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Fatalf("generated configuration is invalid: %v\n%s", diags, generated)
+	}
+	if strings.Contains(generated, "super-secret-value") {
+		t.Fatalf("generated configuration contains the value of a variable:\n%s", generated)
+	}
+
+	for _, expected := range []string{
+		`resource "gitlab_group" "acme" {`,
+		`resource "gitlab_group" "acme_platform" {`,
+		`parent_id        = gitlab_group.acme.id`,
+		`resource "gitlab_project" "acme_platform_api" {`,
+		`namespace_id     = gitlab_group.acme_platform.id`,
+		`resource "gitlab_project_variable" "acme_platform_api_deploy_token_production" {`,
+		`value             = var.acme_platform_api_deploy_token_production`,
+		`variable "acme_platform_api_deploy_token_production" {`,
+		`id = "` + strconv.Itoa(project.ID) + `:DEPLOY_TOKEN:production"`,
+		`resource "gitlab_group_label" "acme_bug" {`,
+		`id = "` + strconv.Itoa(group.ID) + `:bug"`,
+		`resource "gitlab_branch_protection" "acme_platform_api_main" {`,
+		`id = "` + strconv.Itoa(project.ID) + `:main"`,
+		`resource "gitlab_project_membership" "acme_platform_api_root" {`,
+		`resource "gitlab_group_membership" "acme_root" {`,
+		`to = gitlab_project.acme_platform_api`,
+		`token                      = var.acme_platform_api_`,
+	} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("expected the generated configuration to contain %q", expected)
+		}
+	}
+	if t.Failed() {
+		t.Logf("generated configuration:\n%s", generated)
+	}
+}
+
+func TestGenerateWithoutSubgroups(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	client := fake.Client
+
+	group, _, err := client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("Acme"), Path: gitlab.String("acme")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	if _, _, err := client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("Platform"), Path: gitlab.String("platform"), ParentID: gitlab.Int(group.ID)}); err != nil {
+		t.Fatalf("failed to create subgroup: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := Generate(context.Background(), client, Options{Group: strconv.Itoa(group.ID)}, &out); err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if strings.Contains(out.String(), "platform") {
+		t.Fatalf("expected the subgroup to be skipped:\n%s", out.String())
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	f := newFile()
+	for _, tc := range []struct{ value, expected string }{
+		{"my-group/Sub Group", "my_group_sub_group"},
+		{"my_group/sub-group", "my_group_sub_group_2"},
+		{"42", "_42"},
+		{"", "_"},
+	} {
+		if name := f.uniqueName("gitlab_group", tc.value); name != tc.expected {
+			t.Errorf("expected %q for %q, got %q", tc.expected, tc.value, name)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Package pager lists all pages of the GitLab list endpoints, for the provider and the generator alike.
+package pager
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/xanzy/go-gitlab"
+)
+
+// DefaultPerPage is the page size used by the pager, which is the maximum GitLab supports.
+const DefaultPerPage = 100
+
+// Options configure how `Paginate` lists all pages of a GitLab list endpoint.
+type Options struct {
+	// Page is the first page to list in offset pagination, defaults to 1.
+	Page int
+	// PerPage is the number of results per page, defaults to `DefaultPerPage`.
+	PerPage int
+	// MaxResults is the maximum number of results to return, 0 returns all results.
+	MaxResults int
+	// MaxPages is the maximum number of pages to request, 0 requests all pages.
+	MaxPages int
+	// Keyset uses keyset pagination, which is faster than offset pagination for large result sets
+	// and isn't restricted by the offset limits of GitLab. Only some endpoints support it, usually
+	// only for some `order_by` values, see https://docs.gitlab.com/ee/api/#keyset-based-pagination.
+	// If GitLab responds with offset pagination headers instead, they are followed as well.
+	Keyset bool
+	// Concurrency is the number of pages requested at once in offset pagination.
+	// It requires GitLab to return the total number of pages, which it doesn't for very large result sets,
+	// then the pages are requested one after the other.
+	Concurrency int
+	// ContextOption returns the request option which passes the context to each request,
+	// defaults to `gitlab.WithContext`.
+	ContextOption func(ctx context.Context) gitlab.RequestOptionFunc
+}
+
+// ListPageFunc lists a single page of a GitLab list endpoint with the given list and request options.
+// It must not modify shared state, as pages may be requested concurrently.
+type ListPageFunc[T any] func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]T, *gitlab.Response, error)
+
+// Paginate lists all pages of a GitLab list endpoint, up to the limits of the given options.
+func Paginate[T any](ctx context.Context, options Options, listPage ListPageFunc[T]) ([]T, error) {
+	if options.Page < 1 {
+		options.Page = 1
+	}
+	if options.PerPage < 1 {
+		options.PerPage = DefaultPerPage
+	}
+	if options.ContextOption == nil {
+		options.ContextOption = gitlab.WithContext
+	}
+	if options.MaxResults > 0 && options.MaxResults < options.PerPage {
+		options.PerPage = options.MaxResults
+	}
+
+	var results []T
+	var err error
+	if options.Keyset && options.Page == 1 {
+		results, err = paginateKeyset(ctx, options, listPage)
+	} else {
+		results, err = paginateOffset(ctx, options, listPage)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if options.MaxResults > 0 && len(results) > options.MaxResults {
+		results = results[:options.MaxResults]
+	}
+	return results, nil
+}
+
+// done reports whether the pager has reached the limits of the options.
+func (o Options) done(pages, results int) bool {
+	return (o.MaxPages > 0 && pages >= o.MaxPages) || (o.MaxResults > 0 && results >= o.MaxResults)
+}
+
+func paginateOffset[T any](ctx context.Context, options Options, listPage ListPageFunc[T]) ([]T, error) {
+	listOptions := gitlab.ListOptions{Page: options.Page, PerPage: options.PerPage}
+	results, resp, err := listPage(listOptions, options.ContextOption(ctx))
+	if err != nil {
+		return nil, err
+	}
+	pages := 1
+
+	if options.Concurrency > 1 && resp.TotalPages > 0 {
+		lastPage := resp.TotalPages
+		if options.MaxPages > 0 && options.Page+options.MaxPages-1 < lastPage {
+			lastPage = options.Page + options.MaxPages - 1
+		}
+		if options.MaxResults > 0 {
+			// the pages required for the maximum number of results, the first one has already been listed
+			if required := options.Page + (options.MaxResults-1)/options.PerPage; required < lastPage {
+				lastPage = required
+			}
+		}
+		if lastPage <= options.Page {
+			return results, nil
+		}
+
+		tflog.Debug(ctx, "Listing pages concurrently", map[string]interface{}{
+			"first_page": options.Page + 1, "last_page": lastPage, "concurrency": options.Concurrency,
+		})
+		remaining, err := listPagesConcurrently(ctx, options, listPage, options.Page+1, lastPage)
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range remaining {
+			results = append(results, page...)
+		}
+		return results, nil
+	}
+
+	for resp.NextPage != 0 && !options.done(pages, len(results)) {
+		listOptions.Page = resp.NextPage
+		var page []T
+		page, resp, err = listPage(listOptions, options.ContextOption(ctx))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+		pages++
+	}
+	return results, nil
+}
+
+// listPagesConcurrently lists the pages from first to last with at most `options.Concurrency` requests at once
+// and returns them in order.
+func listPagesConcurrently[T any](ctx context.Context, options Options, listPage ListPageFunc[T], first, last int) ([][]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]T, last-first+1)
+	semaphore := make(chan struct{}, options.Concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+
+	for page := first; page <= last; page++ {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(page int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			results, _, err := listPage(gitlab.ListOptions{Page: page, PerPage: options.PerPage}, options.ContextOption(ctx))
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			pages[page-first] = results
+		}(page)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// the parent context has been canceled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+func paginateKeyset[T any](ctx context.Context, options Options, listPage ListPageFunc[T]) ([]T, error) {
+	listOptions := gitlab.ListOptions{PerPage: options.PerPage}
+	results, resp, err := listPage(listOptions, options.ContextOption(ctx), withKeysetPagination(""))
+	if err != nil {
+		return nil, err
+	}
+	pages := 1
+
+	for !options.done(pages, len(results)) {
+		var page []T
+		if next := nextLink(resp.Response); next != "" {
+			page, resp, err = listPage(listOptions, options.ContextOption(ctx), withKeysetPagination(next))
+		} else if resp.NextPage != 0 {
+			// the endpoint doesn't support keyset pagination for the given options
+			listOptions.Page = resp.NextPage
+			page, resp, err = listPage(listOptions, options.ContextOption(ctx))
+		} else {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+		pages++
+	}
+	return results, nil
+}
+
+// withKeysetPagination requests keyset pagination for the first page of a list endpoint,
+// or requests the page of the given `next` link returned by GitLab for the previous page.
+func withKeysetPagination(next string) gitlab.RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		if next == "" {
+			query := req.URL.Query()
+			query.Set("pagination", "keyset")
+			req.URL.RawQuery = query.Encode()
+			return nil
+		}
+
+		nextURL, err := url.Parse(next)
+		if err != nil {
+			return err
+		}
+		// the link contains all the query parameters of the previous request and the cursor of the next page,
+		// but the request is still sent to the configured base URL.
+		req.URL.RawQuery = nextURL.RawQuery
+		return nil
+	}
+}
+
+// nextLink returns the URL of the next page from the `Link` header of a keyset paginated response.
+func nextLink(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			if len(parts) < 2 {
+				continue
+			}
+			for _, param := range parts[1:] {
+				if strings.TrimSpace(param) == `rel="next"` {
+					return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package pager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// newPagedProjectsServer returns a GitLab client for a server which lists the given number of projects,
+// with offset or keyset pagination, and records the query of every request.
+func newPagedProjectsServer(t *testing.T, total int) (*gitlab.Client, func() []string) {
+	var lock sync.Mutex
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/projects" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		lock.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		lock.Unlock()
+
+		query := r.URL.Query()
+		perPage, _ := strconv.Atoi(query.Get("per_page"))
+		first := 1
+		if query.Get("pagination") == "keyset" {
+			if idAfter := query.Get("id_after"); idAfter != "" {
+				first, _ = strconv.Atoi(idAfter)
+				first++
+			}
+		} else {
+			page, _ := strconv.Atoi(query.Get("page"))
+			if page < 1 {
+				page = 1
+			}
+			first = (page-1)*perPage + 1
+			totalPages := (total + perPage - 1) / perPage
+			w.Header().Set("X-Page", strconv.Itoa(page))
+			w.Header().Set("X-Total-Pages", strconv.Itoa(totalPages))
+			if page < totalPages {
+				w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+			}
+		}
+
+		last := first + perPage - 1
+		if last > total {
+			last = total
+		}
+		if query.Get("pagination") == "keyset" && last < total {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v4/projects?id_after=%d&pagination=keyset&per_page=%d>; rel="next", <http://%s/api/v4/projects?pagination=keyset&per_page=%d>; rel="first"`, r.Host, last, perPage, r.Host, perPage))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		body := "["
+		for id := first; id <= last; id++ {
+			if id > first {
+				body += ","
+			}
+			body += fmt.Sprintf(`{"id":%d}`, id)
+		}
+		_, _ = w.Write([]byte(body + "]"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	cases := []struct {
+		name     string
+		total    int
+		options  Options
+		results  int
+		requests int
+	}{
+		{name: "offset", total: 250, options: Options{}, results: 250, requests: 3},
+		{name: "offset empty", total: 0, options: Options{}, results: 0, requests: 1},
+		{name: "offset max results", total: 250, options: Options{MaxResults: 120}, results: 120, requests: 2},
+		{name: "offset small max results", total: 250, options: Options{MaxResults: 5}, results: 5, requests: 1},
+		{name: "offset max pages", total: 250, options: Options{PerPage: 20, MaxPages: 3}, results: 60, requests: 3},
+		{name: "offset start page", total: 250, options: Options{Page: 2, PerPage: 100}, results: 150, requests: 2},
+		{name: "concurrent", total: 950, options: Options{Concurrency: 4}, results: 950, requests: 10},
+		{name: "concurrent max results", total: 950, options: Options{Concurrency: 4, MaxResults: 301}, results: 301, requests: 4},
+		{name: "keyset", total: 250, options: Options{Keyset: true}, results: 250, requests: 3},
+		{name: "keyset max results", total: 250, options: Options{Keyset: true, MaxResults: 150}, results: 150, requests: 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, queries := newPagedProjectsServer(t, tc.total)
+
+			projects, err := Paginate(context.Background(), tc.options, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+				return client.Projects.ListProjects(&gitlab.ListProjectsOptions{ListOptions: listOptions}, requestOptions...)
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(projects) != tc.results {
+				t.Fatalf("expected %d projects, got %d", tc.results, len(projects))
+			}
+			first := 1
+			if tc.options.Page > 1 {
+				first = (tc.options.Page-1)*tc.options.PerPage + 1
+			}
+			for i, project := range projects {
+				if project.ID != first+i {
+					t.Fatalf("expected project %d at index %d, got %d", first+i, i, project.ID)
+				}
+			}
+			if requests := len(queries()); requests != tc.requests {
+				t.Fatalf("expected %d requests, got %d: %v", tc.requests, requests, queries())
+			}
+		})
+	}
+}
+
+func TestPaginate_keysetFallsBackToOffset(t *testing.T) {
+	// the server ignores the keyset pagination and responds with offset pagination headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"id":1}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":2}]`))
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	projects, err := Paginate(context.Background(), Options{Keyset: true}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+		return client.Projects.ListProjects(&gitlab.ListProjectsOptions{ListOptions: listOptions}, requestOptions...)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(projects) != 2 || projects[0].ID != 1 || projects[1].ID != 2 {
+		t.Fatalf("expected projects 1 and 2, got %v", projects)
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	cases := []struct {
+		header string
+		next   string
+	}{
+		{header: "", next: ""},
+		{header: `<https://gitlab.example.com/api/v4/projects?pagination=keyset&id_after=42>; rel="first"`, next: ""},
+		{
+			header: `<https://gitlab.example.com/api/v4/projects?id_after=42&pagination=keyset>; rel="next", <https://gitlab.example.com/api/v4/projects?pagination=keyset>; rel="first"`,
+			next:   "https://gitlab.example.com/api/v4/projects?id_after=42&pagination=keyset",
+		},
+	}
+
+	for _, tc := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		if tc.header != "" {
+			resp.Header.Set("Link", tc.header)
+		}
+		if next := nextLink(resp); next != tc.next {
+			t.Errorf("expected next link %q for %q, got %q", tc.next, tc.header, next)
+		}
+	}
+}
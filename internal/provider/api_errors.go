@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
+)
+
+// attributeSchema is the part of a schema required to look up its attributes,
+// like the `Schema` of a `tfsdk.Plan` or `tfsdk.State`.
+type attributeSchema interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// addAPIErrorDiagnostics adds the diagnostics for an error returned by the GitLab API.
+// The validation errors of a `400 Bad Request` are added per field, for the attribute of the same name
+// if the schema has one, and a `403 Forbidden` is explained with the token scopes and the role of the user.
+// Any other error is added with the given summary.
+// It's the counterpart of the `apiErrorDiagnostics` of the SDK resources.
+func addAPIErrorDiagnostics(ctx context.Context, diags *diag.Diagnostics, gitlabClient *gitlab.Client, s attributeSchema, summary string, err error) {
+	if fieldErrors := client.ValidationErrors(err); len(fieldErrors) > 0 {
+		for _, fieldError := range fieldErrors {
+			attribute := path.Root(strings.SplitN(fieldError.Field, ".", 2)[0])
+			if fieldError.Field == "base" {
+				diags.AddError(fmt.Sprintf("Invalid request: %s", strings.Join(fieldError.Messages, ", ")), err.Error())
+				continue
+			}
+
+			fieldSummary := fmt.Sprintf("Invalid %s: %s", fieldError.Field, strings.Join(fieldError.Messages, ", "))
+			if s != nil {
+				if _, typeDiags := s.TypeAtPath(ctx, attribute); !typeDiags.HasError() {
+					diags.AddAttributeError(attribute, fieldSummary, err.Error())
+					continue
+				}
+			}
+			diags.AddError(fieldSummary, err.Error())
+		}
+		return
+	}
+
+	if gitlabClient != nil {
+		if detail := client.ForbiddenDetail(ctx, gitlabClient, err); detail != "" {
+			diags.AddError(summary, fmt.Sprintf("%s\n\n%s", err.Error(), detail))
+			return
+		}
+	}
+
+	diags.AddError(summary, err.Error())
+}
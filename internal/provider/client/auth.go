@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+const (
+	// AuthTypeOAuth sends the token as `Authorization: Bearer` header.
+	AuthTypeOAuth = "oauth"
+	// AuthTypePrivateToken sends the token as `PRIVATE-TOKEN` header.
+	AuthTypePrivateToken = "private_token"
+	// AuthTypeJobToken sends the token as `JOB-TOKEN` header.
+	AuthTypeJobToken = "job_token"
+)
+
+// AuthTypes are all the supported values for the `auth_type` provider attribute.
+var AuthTypes = []string{AuthTypeOAuth, AuthTypePrivateToken, AuthTypeJobToken}
+
+// resolveToken returns the token from the first configured token source.
+// The token file and the token command are evaluated on every call,
+// so that a fresh token is used every time the provider is configured.
+func (c *Config) resolveToken(ctx context.Context) (string, error) {
+	switch {
+	case c.Token != "":
+		return c.Token, nil
+	case c.TokenFile != "":
+		content, err := os.ReadFile(c.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read GitLab token from file %q: %w", c.TokenFile, err)
+		}
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			return "", fmt.Errorf("the GitLab token file %q is empty", c.TokenFile)
+		}
+		return token, nil
+	case len(c.TokenCommand) > 0:
+		return runTokenCommand(ctx, c.TokenCommand)
+	}
+
+	return "", errors.New("No GitLab token configured, either use the `token`, `token_file` or `token_command` provider argument or set it as `GITLAB_TOKEN` environment variable")
+}
+
+// runTokenCommand runs the given credential helper command and returns the token it prints to stdout.
+func runTokenCommand(ctx context.Context, command []string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run GitLab token command %q: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("the GitLab token command %q did not print a token", command[0])
+	}
+	return token, nil
+}
+
+// newClientForAuthType creates a new GitLab client which sends the token in the header for the given auth type.
+func newClientForAuthType(authType, token string, opts ...gitlab.ClientOptionFunc) (*gitlab.Client, error) {
+	switch authType {
+	case "", AuthTypeOAuth:
+		// The OAuth method is also compatible with project/group/personal access and job tokens because they are all usable as Bearer tokens.
+		// Although the job token API access is very limited.
+		// see https://docs.gitlab.com/ee/api#authentication
+		return gitlab.NewOAuthClient(token, opts...)
+	case AuthTypePrivateToken:
+		return gitlab.NewClient(token, opts...)
+	case AuthTypeJobToken:
+		return gitlab.NewJobClient(token, opts...)
+	}
+
+	return nil, fmt.Errorf("unsupported auth type %q, must be one of %s", authType, strings.Join(AuthTypes, ", "))
+}
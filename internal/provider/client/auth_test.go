@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestAuth_resolveToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	cases := []struct {
+		Name     string
+		Config   Config
+		Expected string
+	}{
+		{Name: "token", Config: Config{Token: "static-token"}, Expected: "static-token"},
+		{Name: "token file", Config: Config{TokenFile: tokenFile}, Expected: "file-token"},
+		{Name: "token command", Config: Config{TokenCommand: []string{"echo", "command-token"}}, Expected: "command-token"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			token, err := tc.Config.resolveToken(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != tc.Expected {
+				t.Fatalf("expected token %q, got %q", tc.Expected, token)
+			}
+		})
+	}
+}
+
+func TestAuth_resolveToken_errors(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config Config
+	}{
+		{Name: "no token", Config: Config{}},
+		{Name: "missing token file", Config: Config{TokenFile: filepath.Join(t.TempDir(), "missing")}},
+		{Name: "failing token command", Config: Config{TokenCommand: []string{"false"}}},
+		{Name: "empty token command output", Config: Config{TokenCommand: []string{"true"}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := tc.Config.resolveToken(context.Background()); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestAuth_newClientForAuthType(t *testing.T) {
+	cases := []struct {
+		AuthType      string
+		Header        string
+		ExpectedValue string
+	}{
+		{AuthType: "", Header: "Authorization", ExpectedValue: "Bearer secret"},
+		{AuthType: AuthTypeOAuth, Header: "Authorization", ExpectedValue: "Bearer secret"},
+		{AuthType: AuthTypePrivateToken, Header: "Private-Token", ExpectedValue: "secret"},
+		{AuthType: AuthTypeJobToken, Header: "Job-Token", ExpectedValue: "secret"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.AuthType, func(t *testing.T) {
+			var actualValue string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actualValue = r.Header.Get(tc.Header)
+				_, _ = w.Write([]byte(`{"version": "15.7.0"}`))
+			}))
+			defer server.Close()
+
+			client, err := newClientForAuthType(tc.AuthType, "secret", gitlab.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, _, err := client.Version.GetVersion(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actualValue != tc.ExpectedValue {
+				t.Fatalf("expected header %s to be %q, got %q", tc.Header, tc.ExpectedValue, actualValue)
+			}
+		})
+	}
+
+	if _, err := newClientForAuthType("basic", "secret"); err == nil {
+		t.Fatalf("expected an error for an unsupported auth type")
+	}
+}
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sharedReadCache is the read cache used by all clients in this process which have the read cache enabled.
+// It lives as long as the provider process, which is a single Terraform operation, like a plan or an apply.
+var sharedReadCache = newReadCache()
+
+// authHeaders are the headers which identify the user a request is made for.
+var authHeaders = []string{"Authorization", "Private-Token", "Job-Token", "Sudo"}
+
+type readCacheContextKey struct{}
+
+// WithoutReadCache returns a context which makes requests bypass the read cache.
+// Use it for requests which wait for a state change on the GitLab side, like polling for a deletion.
+func WithoutReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readCacheContextKey{}, true)
+}
+
+func isReadCacheDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(readCacheContextKey{}).(bool)
+	return disabled
+}
+
+// readCache is an in-memory cache for successful GET responses.
+type readCache struct {
+	lock    sync.RWMutex
+	entries map[string]readCacheEntry
+}
+
+type readCacheEntry struct {
+	// path is the escaped path of the cached request, used for the invalidation.
+	path     string
+	response []byte
+}
+
+func newReadCache() *readCache {
+	return &readCache{entries: make(map[string]readCacheEntry)}
+}
+
+// readCacheKey identifies a cached response by the requested URL and the authentication of the request,
+// so that responses are never shared between users.
+func readCacheKey(req *http.Request) string {
+	h := sha256.New()
+	for _, header := range authHeaders {
+		fmt.Fprintf(h, "%s=%s\n", header, req.Header.Get(header))
+	}
+	return fmt.Sprintf("%s|%x", req.URL.String(), h.Sum(nil))
+}
+
+func (c *readCache) get(req *http.Request) (*http.Response, bool) {
+	c.lock.RLock()
+	entry, ok := c.entries[readCacheKey(req)]
+	c.lock.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(entry.response)), req)
+	if err != nil {
+		return nil, false
+	}
+	return resp, true
+}
+
+func (c *readCache) set(req *http.Request, resp *http.Response) error {
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[readCacheKey(req)] = readCacheEntry{path: req.URL.EscapedPath(), response: dump}
+	return nil
+}
+
+// invalidate removes all cached responses which overlap with the given path.
+func (c *readCache) invalidate(path string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	invalidated := 0
+	for key, entry := range c.entries {
+		if pathsOverlap(entry.path, path) {
+			delete(c.entries, key)
+			invalidated++
+		}
+	}
+	return invalidated
+}
+
+// pathsOverlap checks if a change to one of the API paths may affect the other one.
+// That's the case if one path is a prefix of the other, e.g. `projects/42` and `projects/42/variables/FOO`.
+// Because resources can be addressed by their ID and their full path alike, the identifiers of
+// the top-level resource are considered the same if one of them is not numeric,
+// e.g. `projects/42` and `projects/my-group%2Fmy-project`.
+func pathsOverlap(a, b string) bool {
+	aSegments := apiRelativePathSegments(a)
+	bSegments := apiRelativePathSegments(b)
+
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		if aSegments[i] == bSegments[i] {
+			continue
+		}
+		if i == 1 && (!isNumeric(aSegments[i]) || !isNumeric(bSegments[i])) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// apiRelativePathSegments returns the segments of the given path after the `api/v4` prefix.
+func apiRelativePathSegments(path string) []string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "api" && segments[i+1] == "v4" {
+			return segments[i+2:]
+		}
+	}
+	return segments
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// cachingTransport is an http.RoundTripper which serves GET requests from the read cache
+// and invalidates the cache for all other requests.
+type cachingTransport struct {
+	cache     *readCache
+	transport http.RoundTripper
+}
+
+func newCachingTransport(cache *readCache, transport http.RoundTripper) http.RoundTripper {
+	if cache == nil {
+		return transport
+	}
+	return &cachingTransport{cache: cache, transport: transport}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if req.Method != http.MethodGet {
+		// Invalidate before and after the change, so that concurrent reads can't cache the old state.
+		t.invalidate(ctx, req)
+		resp, err := t.transport.RoundTrip(req)
+		t.invalidate(ctx, req)
+		return resp, err
+	}
+
+	if isReadCacheDisabled(ctx) {
+		return t.transport.RoundTrip(req)
+	}
+
+	if resp, ok := t.cache.get(req); ok {
+		tflog.SubsystemDebug(ctx, loggingSubsystem, "Serving GitLab API response from the read cache", map[string]interface{}{
+			"url": req.URL.String(),
+		})
+		return resp, nil
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	// DumpResponse consumes the body and replaces it with an in-memory copy.
+	if err := t.cache.set(req, resp); err != nil {
+		tflog.SubsystemWarn(ctx, loggingSubsystem, "Failed to cache GitLab API response", map[string]interface{}{
+			"url":   req.URL.String(),
+			"error": err.Error(),
+		})
+	}
+	return resp, nil
+}
+
+func (t *cachingTransport) invalidate(ctx context.Context, req *http.Request) {
+	if invalidated := t.cache.invalidate(req.URL.EscapedPath()); invalidated > 0 {
+		tflog.SubsystemDebug(ctx, loggingSubsystem, "Invalidated cached GitLab API responses", map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.EscapedPath(),
+			"invalidated": invalidated,
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCache_pathsOverlap(t *testing.T) {
+	cases := []struct {
+		A        string
+		B        string
+		Expected bool
+	}{
+		{A: "/api/v4/projects/42", B: "/api/v4/projects/42", Expected: true},
+		{A: "/api/v4/projects/42", B: "/api/v4/projects/42/variables/FOO", Expected: true},
+		{A: "/api/v4/projects", B: "/api/v4/projects/42", Expected: true},
+		{A: "/api/v4/projects/42/variables", B: "/api/v4/projects/42/variables/FOO", Expected: true},
+		{A: "/api/v4/projects/my-group%2Fmy-project", B: "/api/v4/projects/42/variables/FOO", Expected: true},
+		{A: "/gitlab/api/v4/projects/42", B: "/gitlab/api/v4/projects/42/hooks/1", Expected: true},
+		{A: "/api/v4/projects/42", B: "/api/v4/projects/43", Expected: false},
+		{A: "/api/v4/projects/42/hooks", B: "/api/v4/projects/42/variables/FOO", Expected: false},
+		{A: "/api/v4/groups/42", B: "/api/v4/projects/42", Expected: false},
+	}
+
+	for _, tc := range cases {
+		if actual := pathsOverlap(tc.A, tc.B); actual != tc.Expected {
+			t.Errorf("expected paths %q and %q to overlap: %t, got %t", tc.A, tc.B, tc.Expected, actual)
+		}
+	}
+}
+
+func TestCache_cachingTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/api/v4/projects/404" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(`{"id": 42}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: newCachingTransport(newReadCache(), http.DefaultTransport)}
+
+	do := func(ctx context.Context, method, path, token string) string {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(ctx, method, server.URL+path, nil)
+		req.Header.Set("Private-Token", token)
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	expectRequests := func(expected int32) {
+		t.Helper()
+		if actual := atomic.LoadInt32(&requests); actual != expected {
+			t.Fatalf("expected %d requests to the server, got %d", expected, actual)
+		}
+	}
+
+	ctx := context.Background()
+
+	if body := do(ctx, http.MethodGet, "/api/v4/projects/42", "a"); body != `{"id": 42}` {
+		t.Fatalf("unexpected body %q", body)
+	}
+	expectRequests(1)
+
+	if body := do(ctx, http.MethodGet, "/api/v4/projects/42", "a"); body != `{"id": 42}` {
+		t.Fatalf("unexpected body from cache %q", body)
+	}
+	expectRequests(1)
+
+	// a different user must not get the cached response
+	do(ctx, http.MethodGet, "/api/v4/projects/42", "b")
+	expectRequests(2)
+
+	// polling requests bypass the cache
+	do(WithoutReadCache(ctx), http.MethodGet, "/api/v4/projects/42", "a")
+	expectRequests(3)
+
+	// unsuccessful responses are not cached
+	do(ctx, http.MethodGet, "/api/v4/projects/404", "a")
+	do(ctx, http.MethodGet, "/api/v4/projects/404", "a")
+	expectRequests(5)
+
+	// a change invalidates the cached response
+	do(ctx, http.MethodPut, "/api/v4/projects/42/variables/FOO", "a")
+	do(ctx, http.MethodGet, "/api/v4/projects/42", "a")
+	expectRequests(7)
+}
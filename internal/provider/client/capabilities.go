@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/xanzy/go-gitlab"
+)
+
+// Tier is the subscription tier of GitLab, which determines the features available.
+type Tier int
+
+const (
+	TierFree Tier = iota
+	TierPremium
+	TierUltimate
+)
+
+func (t Tier) String() string {
+	switch t {
+	case TierPremium:
+		return "Premium"
+	case TierUltimate:
+		return "Ultimate"
+	default:
+		return "Free"
+	}
+}
+
+// Capability describes the GitLab instance required by an attribute of a resource.
+type Capability struct {
+	// MinVersion is the minimum version of GitLab, like `14.1`. It only consists of the major and minor version.
+	MinVersion string
+	// Tier is the minimum tier of GitLab, every tier above the free tier requires the Enterprise Edition.
+	Tier Tier
+}
+
+// capabilities are the capabilities required by the attributes of the resources, by resource type and attribute name.
+// Only attributes which GitLab rejects with an API error for an unsupported instance must be registered here,
+// not attributes which are silently ignored.
+var capabilities = map[string]map[string]Capability{
+	"gitlab_branch_protection": {
+		"code_owner_approval_required": {Tier: TierPremium},
+	},
+	"gitlab_group": {
+		"extra_shared_runners_minutes_limit": {Tier: TierPremium},
+		"membership_lock":                    {Tier: TierPremium},
+		"prevent_forking_outside_group":      {Tier: TierPremium},
+		"shared_runners_minutes_limit":       {Tier: TierPremium},
+	},
+	"gitlab_project": {
+		"group_with_project_templates_id": {Tier: TierPremium},
+		"merge_pipelines_enabled":         {Tier: TierPremium},
+		"merge_trains_enabled":            {Tier: TierPremium},
+		"push_rules":                      {Tier: TierPremium},
+		"requirements_access_level":       {Tier: TierUltimate},
+		"merge_commit_template":           {MinVersion: "14.5"},
+		"squash_commit_template":          {MinVersion: "14.6"},
+		"template_project_id":             {Tier: TierPremium},
+		"use_custom_template":             {Tier: TierPremium},
+	},
+	"gitlab_topic": {
+		"description": {MinVersion: "15.0"},
+	},
+}
+
+// AttributeCapabilities returns the capabilities required by the attributes of the given resource type, by attribute name.
+func AttributeCapabilities(resourceType string) map[string]Capability {
+	return capabilities[resourceType]
+}
+
+// InstanceInfo describes the version and edition of a GitLab instance.
+type InstanceInfo struct {
+	// Version is the version of GitLab, like `15.11.0-ee`.
+	Version string
+	// Enterprise is true if the instance runs the Enterprise Edition.
+	Enterprise bool
+	// Tier is the tier of the license of the instance. It is only known to administrators,
+	// otherwise `TierKnown` is false and the tier isn't checked beyond requiring the Enterprise Edition.
+	Tier      Tier
+	TierKnown bool
+}
+
+// instanceInfoEntry caches the instance info of a client. The lookup is retried if it fails.
+type instanceInfoEntry struct {
+	lock sync.Mutex
+	info *InstanceInfo
+}
+
+// instanceInfos caches the instance info by client, so it is only looked up once per provider.
+var instanceInfos sync.Map
+
+// GetInstanceInfo returns the version and edition of the GitLab instance of the client.
+// It is looked up once per client and cached for all subsequent calls.
+func GetInstanceInfo(ctx context.Context, client *gitlab.Client) (*InstanceInfo, error) {
+	value, _ := instanceInfos.LoadOrStore(client, &instanceInfoEntry{})
+	entry := value.(*instanceInfoEntry)
+
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+	if entry.info != nil {
+		return entry.info, nil
+	}
+
+	info, err := lookupInstanceInfo(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	entry.info = info
+	return info, nil
+}
+
+func lookupInstanceInfo(ctx context.Context, client *gitlab.Client) (*InstanceInfo, error) {
+	version, _, err := client.Version.GetVersion(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	info := &InstanceInfo{Version: version.Version, Enterprise: strings.HasSuffix(version.Version, "-ee")}
+	// the following requests may fail depending on the version of GitLab and the permissions of the user,
+	// which must not be reported as failed requests of the operation
+	ctx = withoutFailedRequests(ctx)
+
+	// the metadata tells the edition of the instance since GitLab 15.6, also if the version has no `-ee` suffix, like on GitLab.com
+	var metadata struct {
+		Enterprise *bool `json:"enterprise"`
+	}
+	if req, err := client.NewRequest(http.MethodGet, "metadata", nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)}); err == nil {
+		if _, err := client.Do(req, &metadata); err == nil && metadata.Enterprise != nil {
+			info.Enterprise = *metadata.Enterprise
+		}
+	}
+
+	if !info.Enterprise {
+		info.TierKnown = true
+		return info, nil
+	}
+
+	// the license is only visible to administrators
+	license, _, err := client.License.GetLicense(gitlab.WithContext(ctx))
+	if err != nil || license == nil || license.Plan == "" {
+		tflog.Debug(ctx, "Unable to read the license of the GitLab instance, only the edition is checked for tiered features", map[string]interface{}{
+			"error": err,
+		})
+		return info, nil
+	}
+	switch strings.ToLower(license.Plan) {
+	case "ultimate", "gold":
+		info.Tier = TierUltimate
+	case "premium", "silver", "starter", "bronze":
+		info.Tier = TierPremium
+	}
+	info.TierKnown = true
+	return info, nil
+}
+
+// Check returns an error if the GitLab instance doesn't support the capability.
+func (c Capability) Check(info *InstanceInfo) error {
+	if c.MinVersion != "" {
+		isAtLeast, err := isVersionAtLeast(info.Version, c.MinVersion)
+		if err != nil {
+			return err
+		}
+		if !isAtLeast {
+			return fmt.Errorf("requires GitLab %s or later, but the GitLab instance runs version %s", c.MinVersion, info.Version)
+		}
+	}
+
+	if c.Tier > TierFree {
+		if !info.Enterprise {
+			return fmt.Errorf("requires GitLab %s, but the GitLab instance runs the Community Edition", c.Tier)
+		}
+		if info.TierKnown && info.Tier < c.Tier {
+			return fmt.Errorf("requires GitLab %s, but the GitLab instance is licensed for GitLab %s", c.Tier, info.Tier)
+		}
+	}
+	return nil
+}
+
+// CheckAttributeCapability returns an error if the GitLab instance of the client doesn't support the attribute of the resource type.
+// It doesn't return an error if the attribute isn't registered or the instance info can't be looked up,
+// then GitLab will return an error for the API request if it doesn't support the attribute.
+func CheckAttributeCapability(ctx context.Context, client *gitlab.Client, resourceType string, attribute string) error {
+	capability, ok := capabilities[resourceType][attribute]
+	if !ok || client == nil {
+		return nil
+	}
+
+	info, err := GetInstanceInfo(ctx, client)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to look up the version of the GitLab instance, the attributes are not checked against it", map[string]interface{}{
+			"error": err,
+		})
+		return nil
+	}
+	return capability.Check(info)
+}
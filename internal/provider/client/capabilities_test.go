@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// newInstanceServer returns a client for a GitLab instance of the given version and license plan,
+// the license is forbidden if the plan is empty. It counts the requests for the version.
+func newInstanceServer(t *testing.T, version string, metadataEnterprise string, plan string) (*gitlab.Client, *int32) {
+	var versionRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/version":
+			atomic.AddInt32(&versionRequests, 1)
+			fmt.Fprintf(w, `{"version": %q, "revision": "abc"}`, version)
+		case "/api/v4/metadata":
+			if metadataEnterprise == "" {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error": "404 Not Found"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"version": %q, "enterprise": %s}`, version, metadataEnterprise)
+		case "/api/v4/license":
+			if plan == "" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"message": "403 Forbidden"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"id": 1, "plan": %q}`, plan)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client, &versionRequests
+}
+
+func TestGetInstanceInfo(t *testing.T) {
+	cases := []struct {
+		name               string
+		version            string
+		metadataEnterprise string
+		plan               string
+		expected           InstanceInfo
+	}{
+		{
+			name:     "community edition",
+			version:  "15.0.1",
+			expected: InstanceInfo{Version: "15.0.1", Tier: TierFree, TierKnown: true},
+		},
+		{
+			name:     "enterprise edition without access to the license",
+			version:  "15.0.1-ee",
+			expected: InstanceInfo{Version: "15.0.1-ee", Enterprise: true},
+		},
+		{
+			name:     "enterprise edition with license",
+			version:  "15.0.1-ee",
+			plan:     "ultimate",
+			expected: InstanceInfo{Version: "15.0.1-ee", Enterprise: true, Tier: TierUltimate, TierKnown: true},
+		},
+		{
+			name:               "enterprise edition from metadata",
+			version:            "16.5.0-pre",
+			metadataEnterprise: "true",
+			plan:               "premium",
+			expected:           InstanceInfo{Version: "16.5.0-pre", Enterprise: true, Tier: TierPremium, TierKnown: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, versionRequests := newInstanceServer(t, tc.version, tc.metadataEnterprise, tc.plan)
+
+			for i := 0; i < 3; i++ {
+				info, err := GetInstanceInfo(context.Background(), client)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if *info != tc.expected {
+					t.Fatalf("expected %+v, got %+v", tc.expected, *info)
+				}
+			}
+			if requests := atomic.LoadInt32(versionRequests); requests != 1 {
+				t.Fatalf("expected the version to be requested once, got %d requests", requests)
+			}
+		})
+	}
+}
+
+func TestCapabilityCheck(t *testing.T) {
+	cases := []struct {
+		name       string
+		capability Capability
+		info       InstanceInfo
+		err        string
+	}{
+		{
+			name:       "version supported",
+			capability: Capability{MinVersion: "14.6"},
+			info:       InstanceInfo{Version: "15.0.0", TierKnown: true},
+		},
+		{
+			name:       "version too old",
+			capability: Capability{MinVersion: "14.6"},
+			info:       InstanceInfo{Version: "14.5.2", TierKnown: true},
+			err:        "requires GitLab 14.6 or later, but the GitLab instance runs version 14.5.2",
+		},
+		{
+			name:       "community edition",
+			capability: Capability{Tier: TierPremium},
+			info:       InstanceInfo{Version: "15.0.0", TierKnown: true},
+			err:        "requires GitLab Premium, but the GitLab instance runs the Community Edition",
+		},
+		{
+			name:       "unknown tier",
+			capability: Capability{Tier: TierUltimate},
+			info:       InstanceInfo{Version: "15.0.0-ee", Enterprise: true},
+		},
+		{
+			name:       "tier too low",
+			capability: Capability{Tier: TierUltimate},
+			info:       InstanceInfo{Version: "15.0.0-ee", Enterprise: true, Tier: TierPremium, TierKnown: true},
+			err:        "requires GitLab Ultimate, but the GitLab instance is licensed for GitLab Premium",
+		},
+		{
+			name:       "tier supported",
+			capability: Capability{MinVersion: "15.0", Tier: TierPremium},
+			info:       InstanceInfo{Version: "15.0.0-ee", Enterprise: true, Tier: TierUltimate, TierKnown: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.capability.Check(&tc.info)
+			if tc.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckAttributeCapability(t *testing.T) {
+	client, _ := newInstanceServer(t, "14.9.0", "", "")
+
+	if err := CheckAttributeCapability(context.Background(), client, "gitlab_topic", "description"); err == nil {
+		t.Fatal("expected an error for the topic description on GitLab 14.9")
+	}
+	if err := CheckAttributeCapability(context.Background(), client, "gitlab_topic", "name"); err != nil {
+		t.Fatalf("expected no error for an unregistered attribute, got %v", err)
+	}
+}
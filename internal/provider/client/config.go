@@ -4,43 +4,79 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/xanzy/go-gitlab"
 )
 
 // Config is per-provider, specifies where to connect to gitlab
 type Config struct {
 	Token         string
+	TokenFile     string
+	TokenCommand  []string
+	AuthType      string
+	OAuth         *OAuthConfig
 	BaseURL       string
 	Insecure      bool
 	CACertFile    string
 	ClientCert    string
 	ClientKey     string
+	CACert        string
+	ClientCertPEM string
+	ClientKeyPEM  string
+	ProxyURL      string
+	Headers       map[string]string
 	EarlyAuthFail bool
+	MaxRetries    int
+	RetryWaitMin  time.Duration
+	RetryWaitMax  time.Duration
+
+	RequestsPerSecond     float64
+	MaxConcurrentRequests int
+	// RequestBudget is shared by the clients of a provider instance to limit their requests together.
+	// Without it, the requests of each client are limited on their own.
+	RequestBudget *RequestBudget
+
+	ReadCache bool
+
+	// Sudo is the username or ID of the user to impersonate for all requests,
+	// unless a request impersonates a user itself.
+	Sudo string
 }
 
-// Client returns a *gitlab.Client to interact with the configured gitlab instance
-func (c *Config) NewGitLabClient(ctx context.Context) (*gitlab.Client, error) {
-	if c.Token == "" {
-		return nil, errors.New("No GitLab token configured, either use the `token` provider argument or set it as `GITLAB_TOKEN` environment variable")
+// readCache returns the read cache shared by all clients in this process or nil if it's disabled.
+func (c *Config) readCache() *readCache {
+	if !c.ReadCache {
+		return nil
 	}
+	return sharedReadCache
+}
 
+// newTransport returns the HTTP transport which connects to the configured GitLab instance.
+func (c *Config) newTransport() (*http.Transport, error) {
 	// Configure TLS/SSL
 	tlsConfig := &tls.Config{}
 
-	// If a CACertFile has been specified, use that for cert validation
-	if c.CACertFile != "" {
-		caCert, err := os.ReadFile(c.CACertFile)
-		if err != nil {
-			return nil, err
+	// If a CACertFile or CACert has been specified, use that for cert validation
+	if c.CACertFile != "" || c.CACert != "" {
+		caCertPool := x509.NewCertPool()
+
+		if c.CACertFile != "" {
+			caCert, err := os.ReadFile(c.CACertFile)
+			if err != nil {
+				return nil, err
+			}
+			caCertPool.AppendCertsFromPEM(caCert)
+		}
+
+		if c.CACert != "" && !caCertPool.AppendCertsFromPEM([]byte(c.CACert)) {
+			return nil, fmt.Errorf("the CA certificate does not contain any PEM encoded certificate")
 		}
 
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
 		tlsConfig.RootCAs = caCertPool
 	}
 
@@ -49,9 +85,22 @@ func (c *Config) NewGitLabClient(ctx context.Context) (*gitlab.Client, error) {
 		tlsConfig.InsecureSkipVerify = true
 	}
 
-	// add client cert and key to connection
-	if c.ClientCert != "" && c.ClientKey != "" {
-		clientPair, err := tls.LoadX509KeyPair(c.ClientCert, c.ClientKey)
+	// add client cert and key to connection, both may either be given as file or as PEM encoded data
+	clientCert, clientKey := []byte(c.ClientCertPEM), []byte(c.ClientKeyPEM)
+	if c.ClientCert != "" {
+		var err error
+		if clientCert, err = os.ReadFile(c.ClientCert); err != nil {
+			return nil, err
+		}
+	}
+	if c.ClientKey != "" {
+		var err error
+		if clientKey, err = os.ReadFile(c.ClientKey); err != nil {
+			return nil, err
+		}
+	}
+	if len(clientCert) > 0 && len(clientKey) > 0 {
+		clientPair, err := tls.X509KeyPair(clientCert, clientKey)
 		if err != nil {
 			return nil, err
 		}
@@ -62,22 +111,73 @@ func (c *Config) NewGitLabClient(ctx context.Context) (*gitlab.Client, error) {
 	t.TLSClientConfig = tlsConfig
 	t.MaxIdleConnsPerHost = 100
 
+	// Without a proxy URL the proxy is taken from the environment, like for the default transport.
+	if c.ProxyURL != "" {
+		if err := validateProxyURL(c.ProxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		// Basic auth credentials in the URL are sent by the transport
+		proxyURL, _ := url.Parse(c.ProxyURL)
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return t, nil
+}
+
+// Client returns a *gitlab.Client to interact with the configured gitlab instance
+func (c *Config) NewGitLabClient(ctx context.Context) (*gitlab.Client, error) {
+	t, err := c.newTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	// The custom headers are sent with all requests, including the ones to obtain an OAuth2 token.
+	base := newHeadersTransport(c.Headers, t)
+
+	var token string
+	var oauthSource *oauthTokenSource
+	if c.OAuth != nil {
+		if c.AuthType != "" && c.AuthType != AuthTypeOAuth {
+			return nil, fmt.Errorf("the auth type must be %q when using an OAuth2 application, got %q", AuthTypeOAuth, c.AuthType)
+		}
+
+		oauthSource = newOAuthTokenSource(c.OAuth, c.BaseURL, base)
+		if token, err = oauthSource.accessToken(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		if token, err = c.resolveToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Wrap the transport from the inside out: cached responses don't count towards
+	// the throttle and only requests which are actually sent are logged and recorded in the usage summary.
+	var transport http.RoundTripper = newRedactingLoggingTransport(base)
+	transport = newUsageTransport(sharedUsage, transport)
+	transport = newOAuthTransport(oauthSource, transport)
+	transport = newThrottledTransport(c.throttle(), transport)
+	transport = newCachingTransport(c.readCache(), transport)
+	// The impersonated user must be known to the read cache.
+	transport = newSudoTransport(c.Sudo, transport)
+
 	opts := []gitlab.ClientOptionFunc{
 		gitlab.WithHTTPClient(
 			&http.Client{
-				Transport: logging.NewSubsystemLoggingHTTPTransport("GitLab", t),
+				Transport: transport,
 			},
 		),
+		gitlab.WithCustomRetry(checkRetry),
+		gitlab.WithCustomBackoff(backoff),
+		gitlab.WithCustomRetryMax(c.MaxRetries),
+		gitlab.WithCustomRetryWaitMinMax(c.RetryWaitMin, c.RetryWaitMax),
 	}
 
 	if c.BaseURL != "" {
 		opts = append(opts, gitlab.WithBaseURL(c.BaseURL))
 	}
 
-	// The OAuth method is also compatible with project/group/personal access and job tokens because they are all usable as Bearer tokens.
-	// Although the job token API access is very limited.
-	// see https://docs.gitlab.com/ee/api#authentication
-	client, err := gitlab.NewOAuthClient(c.Token, opts...)
+	client, err := newClientForAuthType(c.AuthType, token, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConfig_proxyAndHeaders(t *testing.T) {
+	var requests []*http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		_, _ = w.Write([]byte(`{"id": 1, "username": "root"}`))
+	}))
+	defer proxy.Close()
+
+	config := &Config{
+		Token:    "secret",
+		AuthType: AuthTypePrivateToken,
+		BaseURL:  "http://gitlab.example.com/api/v4/",
+		ProxyURL: strings.Replace(proxy.URL, "http://", "http://proxy-user:proxy-password@", 1),
+		Headers: map[string]string{
+			"X-Identity-Aware-Proxy": "iap-token",
+			"Private-Token":          "must-not-override",
+		},
+		EarlyAuthFail: true,
+	}
+	if _, err := config.NewGitLabClient(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(requests) == 0 {
+		t.Fatalf("expected the requests to be sent through the proxy")
+	}
+	for _, r := range requests {
+		if r.Host != "gitlab.example.com" {
+			t.Errorf("expected request for gitlab.example.com, got %q", r.Host)
+		}
+		expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("proxy-user:proxy-password"))
+		if actual := r.Header.Get("Proxy-Authorization"); actual != expectedAuth {
+			t.Errorf("expected proxy authorization %q, got %q", expectedAuth, actual)
+		}
+		if actual := r.Header.Get("X-Identity-Aware-Proxy"); actual != "iap-token" {
+			t.Errorf("expected custom header to be sent, got %q", actual)
+		}
+		if actual := r.Header.Get("Private-Token"); r.URL.Path == "/api/v4/user" && actual != "secret" {
+			t.Errorf("expected the token not to be overridden by the custom headers, got %q", actual)
+		}
+	}
+}
+
+func TestConfig_proxyURLErrors(t *testing.T) {
+	for _, proxyURL := range []string{"ftp://proxy.example.com", "http://[::1"} {
+		config := &Config{Token: "secret", ProxyURL: proxyURL}
+		if _, err := config.NewGitLabClient(context.Background()); err == nil {
+			t.Errorf("expected an error for proxy URL %q", proxyURL)
+		}
+	}
+}
+
+func TestConfig_inlineCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1, "username": "root"}`))
+	}))
+	defer server.Close()
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	config := &Config{
+		Token:         "secret",
+		BaseURL:       server.URL + "/api/v4/",
+		CACert:        string(caCert),
+		EarlyAuthFail: true,
+	}
+	if _, err := config.NewGitLabClient(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config.CACert = "not a certificate"
+	if _, err := config.NewGitLabClient(context.Background()); err == nil {
+		t.Fatalf("expected an error for an invalid CA certificate")
+	}
+}
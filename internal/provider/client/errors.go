@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// FieldError is the validation error of a single field of a GitLab API request.
+type FieldError struct {
+	// Field is the name of the field, like `name`. The names of nested fields are joined with a dot.
+	Field    string
+	Messages []string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, strings.Join(e.Messages, ", "))
+}
+
+// grapeFieldErrorPattern matches the errors of the API parameter validation, like `name is missing`.
+var grapeFieldErrorPattern = regexp.MustCompile(`^([a-z_][a-z0-9_]*(?:\[[a-z0-9_]*\])*) ((?:is|are|does|must|has|have) .+)$`)
+
+// ValidationErrors returns the validation errors per field of a `400 Bad Request` GitLab API error.
+// GitLab returns them either as map of the model validation errors, like
+// `{"message": {"name": ["has already been taken"]}}`, or as list of the API parameter validation errors,
+// like `{"error": "name is missing, path is invalid"}`.
+// It returns nil for any other error, e.g. if the error doesn't belong to specific fields.
+func ValidationErrors(err error) []FieldError {
+	var errorResponse *gitlab.ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.Response == nil || errorResponse.Response.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+
+	var body struct {
+		Message interface{} `json:"message"`
+		Error   string      `json:"error"`
+	}
+	if err := json.Unmarshal(errorResponse.Body, &body); err != nil {
+		return nil
+	}
+
+	var fieldErrors []FieldError
+	if message, ok := body.Message.(map[string]interface{}); ok {
+		fieldErrors = appendModelFieldErrors(fieldErrors, "", message)
+	} else if body.Error != "" {
+		fieldErrors = parameterFieldErrors(body.Error)
+	}
+
+	sort.Slice(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+	return fieldErrors
+}
+
+func appendModelFieldErrors(fieldErrors []FieldError, prefix string, message map[string]interface{}) []FieldError {
+	for field, value := range message {
+		// errors of the model as a whole are returned as `base`
+		if field != "base" {
+			field = prefix + field
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			fieldErrors = appendModelFieldErrors(fieldErrors, field+".", v)
+		case []interface{}:
+			messages := make([]string, 0, len(v))
+			for _, m := range v {
+				messages = append(messages, fmt.Sprint(m))
+			}
+			fieldErrors = append(fieldErrors, FieldError{Field: field, Messages: messages})
+		default:
+			fieldErrors = append(fieldErrors, FieldError{Field: field, Messages: []string{fmt.Sprint(v)}})
+		}
+	}
+	return fieldErrors
+}
+
+func parameterFieldErrors(message string) []FieldError {
+	var fieldErrors []FieldError
+	for _, part := range strings.Split(message, ", ") {
+		match := grapeFieldErrorPattern.FindStringSubmatch(part)
+		if match == nil {
+			// only errors which all belong to fields are split up
+			return nil
+		}
+		// nested parameters are returned like `rules[0][value]`
+		field := strings.NewReplacer("][", ".", "[", ".", "]", "").Replace(match[1])
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Messages: []string{match[2]}})
+	}
+	return fieldErrors
+}
+
+var accessLevelNames = map[gitlab.AccessLevelValue]string{
+	gitlab.NoPermissions:            "No access",
+	gitlab.MinimalAccessPermissions: "Minimal Access",
+	gitlab.GuestPermissions:         "Guest",
+	gitlab.ReporterPermissions:      "Reporter",
+	gitlab.DeveloperPermissions:     "Developer",
+	gitlab.MaintainerPermissions:    "Maintainer",
+	gitlab.OwnerPermissions:         "Owner",
+}
+
+// ForbiddenDetail explains a `403 Forbidden` GitLab API error with the scopes of the token
+// and the role of the current user in the project or group of the failed request.
+// The information is gathered on a best effort basis, as the token may not be allowed to read it either.
+// It returns an empty string for any other error.
+func ForbiddenDetail(ctx context.Context, gitlabClient *gitlab.Client, err error) string {
+	var errorResponse *gitlab.ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.Response == nil || errorResponse.Response.StatusCode != http.StatusForbidden {
+		return ""
+	}
+	// the requests to explain the error must not be reported as failed requests of the operation themselves
+	ctx = withoutFailedRequests(ctx)
+
+	details := []string{"GitLab denied the request, because the token or the user it belongs to is missing a permission."}
+
+	var token struct {
+		Name   string   `json:"name"`
+		Scopes []string `json:"scopes"`
+	}
+	if req, err := gitlabClient.NewRequest(http.MethodGet, "personal_access_tokens/self", nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)}); err == nil {
+		if _, err := gitlabClient.Do(req, &token); err == nil {
+			details = append(details, fmt.Sprintf("The token %q has the scopes: %s.", token.Name, strings.Join(token.Scopes, ", ")))
+		}
+	}
+
+	user, _, err := gitlabClient.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return strings.Join(details, " ")
+	}
+	if user.IsAdmin {
+		details = append(details, fmt.Sprintf("The current user %q is an administrator.", user.Username))
+	} else {
+		details = append(details, fmt.Sprintf("The current user %q is not an administrator.", user.Username))
+	}
+
+	if kind, id := requestNamespace(errorResponse.Response.Request); id != "" {
+		var member gitlab.ProjectMember
+		req, err := gitlabClient.NewRequest(http.MethodGet, fmt.Sprintf("%s/%s/members/all/%d", kind, url.PathEscape(id), user.ID), nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+		if err == nil {
+			noun := strings.TrimSuffix(kind, "s")
+			if resp, err := gitlabClient.Do(req, &member); err == nil {
+				details = append(details, fmt.Sprintf("The user has the %s role in the %s %s.", accessLevelName(member.AccessLevel), noun, id))
+			} else if resp != nil && resp.StatusCode == http.StatusNotFound {
+				details = append(details, fmt.Sprintf("The user is not a member of the %s %s.", noun, id))
+			}
+		}
+	}
+
+	return strings.Join(details, " ")
+}
+
+func accessLevelName(accessLevel gitlab.AccessLevelValue) string {
+	if name, ok := accessLevelNames[accessLevel]; ok {
+		return name
+	}
+	return fmt.Sprintf("access level %d", accessLevel)
+}
+
+// requestNamespace returns whether the request belongs to `projects` or `groups` and the ID or path of it.
+func requestNamespace(req *http.Request) (string, string) {
+	if req == nil {
+		return "", ""
+	}
+	segments := apiRelativePathSegments(req.URL.EscapedPath())
+	if len(segments) < 2 || (segments[0] != "projects" && segments[0] != "groups") {
+		return "", ""
+	}
+	id, err := url.PathUnescape(segments[1])
+	if err != nil {
+		return "", ""
+	}
+	return segments[0], id
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func newErrorResponse(t *testing.T, statusCode int, path string, body string) *gitlab.ErrorResponse {
+	req, err := http.NewRequest(http.MethodPost, "https://gitlab.example.com/api/v4/"+path, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = gitlab.CheckResponse(&http.Response{StatusCode: statusCode, Body: http.NoBody, Request: req})
+	errorResponse := err.(*gitlab.ErrorResponse)
+	errorResponse.Body = []byte(body)
+	return errorResponse
+}
+
+func TestValidationErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       string
+		expected   []FieldError
+	}{
+		{
+			name:       "model errors",
+			statusCode: http.StatusBadRequest,
+			body:       `{"message": {"path": ["has already been taken", "is reserved"], "name": ["has already been taken"]}}`,
+			expected: []FieldError{
+				{Field: "name", Messages: []string{"has already been taken"}},
+				{Field: "path", Messages: []string{"has already been taken", "is reserved"}},
+			},
+		},
+		{
+			name:       "nested model errors",
+			statusCode: http.StatusBadRequest,
+			body:       `{"message": {"namespace": {"path": ["is invalid"]}, "base": ["Project could not be created"]}}`,
+			expected: []FieldError{
+				{Field: "base", Messages: []string{"Project could not be created"}},
+				{Field: "namespace.path", Messages: []string{"is invalid"}},
+			},
+		},
+		{
+			name:       "parameter errors",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error": "name is missing, rules[0][value] does not have a valid value"}`,
+			expected: []FieldError{
+				{Field: "name", Messages: []string{"is missing"}},
+				{Field: "rules.0.value", Messages: []string{"does not have a valid value"}},
+			},
+		},
+		{
+			name:       "other parameter errors",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error": "name, path are missing, at least one parameter must be provided"}`,
+		},
+		{
+			name:       "message",
+			statusCode: http.StatusBadRequest,
+			body:       `{"message": "400 Bad request - Invalid branch"}`,
+		},
+		{
+			name:       "not a bad request",
+			statusCode: http.StatusConflict,
+			body:       `{"message": {"name": ["has already been taken"]}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := fmt.Errorf("failed: %w", newErrorResponse(t, tc.statusCode, "projects", tc.body))
+			if actual := ValidationErrors(err); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+
+	if actual := ValidationErrors(fmt.Errorf("not an API error")); actual != nil {
+		t.Errorf("expected no validation errors for other errors, got %v", actual)
+	}
+}
+
+func TestForbiddenDetail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v4/personal_access_tokens/self":
+			_, _ = w.Write([]byte(`{"name": "terraform", "scopes": ["read_api"]}`))
+		case "/api/v4/user":
+			_, _ = w.Write([]byte(`{"id": 42, "username": "jane", "is_admin": false}`))
+		case "/api/v4/projects/my-group/my-project/members/all/42":
+			_, _ = w.Write([]byte(`{"id": 42, "access_level": 30}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitlabClient, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	forbidden := newErrorResponse(t, http.StatusForbidden, "projects/my-group%2Fmy-project/variables", `{"message": "403 Forbidden"}`)
+	detail := ForbiddenDetail(context.Background(), gitlabClient, forbidden)
+	for _, expected := range []string{
+		`The token "terraform" has the scopes: read_api.`,
+		`The current user "jane" is not an administrator.`,
+		"The user has the Developer role in the project my-group/my-project.",
+	} {
+		if !strings.Contains(detail, expected) {
+			t.Errorf("expected the detail to contain %q, got %q", expected, detail)
+		}
+	}
+
+	if detail := ForbiddenDetail(context.Background(), gitlabClient, newErrorResponse(t, http.StatusNotFound, "projects/1", "{}")); detail != "" {
+		t.Errorf("expected no detail for other errors, got %q", detail)
+	}
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// GraphQLRequest is a GraphQL query or mutation.
+// User input must always be passed as variables and never be spliced into the query.
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLPageInfo is the `pageInfo { endCursor hasNextPage }` of a GraphQL connection.
+type GraphQLPageInfo struct {
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
+}
+
+// GraphQLError is a single error returned by the GraphQL API.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	path := make([]string, len(e.Path))
+	for i, segment := range e.Path {
+		path[i] = fmt.Sprint(segment)
+	}
+	return fmt.Sprintf("%s (at %s)", e.Message, strings.Join(path, "."))
+}
+
+// GraphQLErrors are the errors returned by the GraphQL API for a request.
+// GraphQL returns them with a `200 OK` status, possibly alongside partial data.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("GraphQL request failed: %s", strings.Join(messages, "; "))
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// GraphQLEndpoint returns the path of the GraphQL endpoint of the GitLab instance the client is configured for.
+// It's derived from the REST API base URL, so that instances hosted on a relative URL work as well,
+// e.g. `/gitlab/api/graphql` for `https://example.com/gitlab/api/v4/`.
+func GraphQLEndpoint(client *gitlab.Client) string {
+	path := strings.TrimSuffix(client.BaseURL().Path, "/")
+	path = strings.TrimSuffix(path, "/api/v4")
+	return path + "/api/graphql"
+}
+
+// SendGraphQLRequest sends the GraphQL request and decodes the `data` of the response into the given value.
+// GraphQL errors are returned as GraphQLErrors.
+func SendGraphQLRequest(ctx context.Context, client *gitlab.Client, request GraphQLRequest, data interface{}) error {
+	req, err := client.NewRequest(http.MethodPost, "", request, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return err
+	}
+	// Overwrite the path of the request, as otherwise the go-gitlab client uses the REST API base URL.
+	req.URL.Path = GraphQLEndpoint(client)
+	req.URL.RawPath = ""
+
+	var response graphQLResponse
+	if _, err := client.Do(req, &response); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+	if data == nil || len(response.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response.Data, data)
+}
+
+// SendPaginatedGraphQLRequest sends the GraphQL request for all pages of a connection.
+// The query must declare an `$after: String` variable, which is set to the `endCursor` of the previous page.
+// The handlePage function is called with the `data` of each page and must return its `pageInfo`.
+func SendPaginatedGraphQLRequest(ctx context.Context, client *gitlab.Client, request GraphQLRequest, handlePage func(data json.RawMessage) (GraphQLPageInfo, error)) error {
+	variables := make(map[string]interface{}, len(request.Variables)+1)
+	for name, value := range request.Variables {
+		variables[name] = value
+	}
+	request.Variables = variables
+
+	for {
+		var data json.RawMessage
+		if err := SendGraphQLRequest(ctx, client, request, &data); err != nil {
+			return err
+		}
+
+		pageInfo, err := handlePage(data)
+		if err != nil {
+			return err
+		}
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			return nil
+		}
+		variables["after"] = pageInfo.EndCursor
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestGraphQL_endpoint(t *testing.T) {
+	cases := map[string]string{
+		"https://gitlab.example.com/api/v4/":          "/api/graphql",
+		"https://example.com/gitlab/api/v4/":          "/gitlab/api/graphql",
+		"https://example.com/some/deep/gitlab/api/v4": "/some/deep/gitlab/api/graphql",
+	}
+
+	for baseURL, expected := range cases {
+		client, err := gitlab.NewClient("", gitlab.WithBaseURL(baseURL))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual := GraphQLEndpoint(client); actual != expected {
+			t.Errorf("expected GraphQL endpoint %q for base URL %q, got %q", expected, baseURL, actual)
+		}
+	}
+}
+
+func TestGraphQL_paginationAndVariables(t *testing.T) {
+	var requests []GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gitlab/api/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var request GraphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		requests = append(requests, request)
+
+		w.Header().Set("Content-Type", "application/json")
+		if request.Variables["after"] == nil {
+			_, _ = w.Write([]byte(`{"data": {"group": {"projects": {"nodes": [{"name": "a"}], "pageInfo": {"endCursor": "c1", "hasNextPage": true}}}}}`))
+		} else {
+			_, _ = w.Write([]byte(`{"data": {"group": {"projects": {"nodes": [{"name": "b"}], "pageInfo": {"endCursor": "c2", "hasNextPage": false}}}}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("secret", gitlab.WithBaseURL(server.URL+"/gitlab/api/v4/"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request := GraphQLRequest{
+		OperationName: "groupProjects",
+		Query:         `query groupProjects($fullPath: ID!, $after: String) {group(fullPath: $fullPath) {projects(after: $after) {nodes {name} pageInfo {endCursor hasNextPage}}}}`,
+		Variables:     map[string]interface{}{"fullPath": "my-group"},
+	}
+
+	var names []string
+	err = SendPaginatedGraphQLRequest(context.Background(), client, request, func(data json.RawMessage) (GraphQLPageInfo, error) {
+		var page struct {
+			Group struct {
+				Projects struct {
+					Nodes []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+					PageInfo GraphQLPageInfo `json:"pageInfo"`
+				} `json:"projects"`
+			} `json:"group"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return GraphQLPageInfo{}, err
+		}
+		for _, node := range page.Group.Projects.Nodes {
+			names = append(names, node.Name)
+		}
+		return page.Group.Projects.PageInfo, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Fatalf("expected the nodes of both pages, got %v", names)
+	}
+	if len(requests) != 2 || requests[1].Variables["after"] != "c1" || requests[1].Variables["fullPath"] != "my-group" || requests[1].OperationName != "groupProjects" {
+		t.Fatalf("expected the second page to be requested with the end cursor of the first page, got %+v", requests)
+	}
+	if _, ok := request.Variables["after"]; ok {
+		t.Fatalf("expected the variables of the original request not to be modified")
+	}
+}
+
+func TestGraphQL_errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"currentUser": null}, "errors": [{"message": "Field 'foo' doesn't exist", "path": ["query", "currentUser", "foo"]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := gitlab.NewClient("secret", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = SendGraphQLRequest(context.Background(), client, GraphQLRequest{Query: `query {currentUser {foo}}`}, nil)
+	var graphQLErrors GraphQLErrors
+	if !errors.As(err, &graphQLErrors) || len(graphQLErrors) != 1 {
+		t.Fatalf("expected GraphQL errors, got %v", err)
+	}
+	if expected := "Field 'foo' doesn't exist (at query.currentUser.foo)"; graphQLErrors[0].Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, graphQLErrors[0].Error())
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import "net/http"
+
+// headersTransport is an http.RoundTripper which adds the configured headers to all requests.
+// Headers which are already set on a request, like the authentication headers, are not overridden.
+type headersTransport struct {
+	headers   map[string]string
+	transport http.RoundTripper
+}
+
+func newHeadersTransport(headers map[string]string, transport http.RoundTripper) http.RoundTripper {
+	if len(headers) == 0 {
+		return transport
+	}
+	return &headersTransport{headers: headers, transport: transport}
+}
+
+func (t *headersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the original request.
+	r := req.Clone(req.Context())
+	for name, value := range t.headers {
+		if r.Header.Get(name) == "" {
+			r.Header.Set(name, value)
+		}
+	}
+	return t.transport.RoundTrip(r)
+}
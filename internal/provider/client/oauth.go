@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const defaultInstanceURL = "https://gitlab.com/"
+
+// OAuthConfig configures the OAuth2 application used to obtain a token instead of a static token.
+// If a username and password is set the resource owner password credentials grant is used,
+// otherwise the client credentials grant.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Username     string
+	Password     string
+}
+
+// instanceURL returns the URL of the GitLab instance the given API base URL belongs to,
+// e.g. `https://gitlab.example.com/gitlab/` for `https://gitlab.example.com/gitlab/api/v4/`.
+func instanceURL(baseURL string) string {
+	if baseURL == "" {
+		return defaultInstanceURL
+	}
+
+	u := strings.TrimSuffix(baseURL, "/")
+	u = strings.TrimSuffix(u, "/api/v4")
+	return u + "/"
+}
+
+// oauthTokenSource obtains and refreshes the OAuth2 access token from the GitLab OAuth2 token endpoint.
+type oauthTokenSource struct {
+	config    *OAuthConfig
+	tokenURL  string
+	transport http.RoundTripper
+
+	lock  sync.Mutex
+	token *oauth2.Token
+}
+
+func newOAuthTokenSource(config *OAuthConfig, baseURL string, transport http.RoundTripper) *oauthTokenSource {
+	return &oauthTokenSource{
+		config:    config,
+		tokenURL:  instanceURL(baseURL) + "oauth/token",
+		transport: transport,
+	}
+}
+
+// accessToken returns the current access token. A new one is obtained if there is none yet or it has expired.
+func (s *oauthTokenSource) accessToken(ctx context.Context) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.token.Valid() {
+		return s.token.AccessToken, nil
+	}
+	return s.refreshLocked(ctx)
+}
+
+// refresh obtains a new access token unless the rejected token has already been replaced in the meantime.
+func (s *oauthTokenSource) refresh(ctx context.Context, rejectedToken string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.token != nil && s.token.AccessToken != rejectedToken {
+		return s.token.AccessToken, nil
+	}
+	return s.refreshLocked(ctx)
+}
+
+func (s *oauthTokenSource) refreshLocked(ctx context.Context) (string, error) {
+	// The token requests must not go through the logging transport, because they contain the secrets.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: s.transport})
+
+	var token *oauth2.Token
+	var err error
+	switch {
+	case s.token != nil && s.token.RefreshToken != "":
+		token, err = s.passwordConfig().TokenSource(ctx, &oauth2.Token{RefreshToken: s.token.RefreshToken}).Token()
+		if err == nil {
+			break
+		}
+		// The refresh token may have been revoked, fall back to a new grant.
+		tflog.SubsystemWarn(ctx, loggingSubsystem, "Failed to refresh the GitLab OAuth2 token, requesting a new one", map[string]interface{}{
+			"error": err.Error(),
+		})
+		fallthrough
+	default:
+		token, err = s.grant(ctx)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain GitLab OAuth2 token from %s: %w", s.tokenURL, err)
+	}
+
+	s.token = token
+	return token.AccessToken, nil
+}
+
+func (s *oauthTokenSource) grant(ctx context.Context) (*oauth2.Token, error) {
+	if s.config.Username != "" {
+		return s.passwordConfig().PasswordCredentialsToken(ctx, s.config.Username, s.config.Password)
+	}
+
+	clientCredentialsConfig := &clientcredentials.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		TokenURL:     s.tokenURL,
+		Scopes:       s.config.Scopes,
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	return clientCredentialsConfig.Token(ctx)
+}
+
+func (s *oauthTokenSource) passwordConfig() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		Scopes:       s.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  s.tokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+// oauthTransport is an http.RoundTripper which authenticates requests with the current OAuth2 access token.
+// If GitLab rejects a token with `401 Unauthorized` a new token is obtained and the request is sent again.
+type oauthTransport struct {
+	source    *oauthTokenSource
+	transport http.RoundTripper
+}
+
+func newOAuthTransport(source *oauthTokenSource, transport http.RoundTripper) http.RoundTripper {
+	if source == nil {
+		return transport
+	}
+	return &oauthTransport{source: source, transport: transport}
+}
+
+func (t *oauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	token, err := t.source.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The request can only be sent again if its body can be read again.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	token, err = t.source.refresh(ctx, token)
+	if err != nil {
+		// return the original response, the error is more helpful to the user.
+		tflog.SubsystemError(ctx, loggingSubsystem, "Failed to refresh the GitLab OAuth2 token", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	retryReq := req
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq = req.Clone(ctx)
+		retryReq.Body = body
+	}
+
+	tflog.SubsystemDebug(ctx, loggingSubsystem, "Retrying GitLab API request with refreshed OAuth2 token", map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	})
+	return t.transport.RoundTrip(withBearerToken(retryReq, token))
+}
+
+// withBearerToken returns a copy of the request which is authenticated with the given token.
+// A RoundTripper must not modify the original request.
+func withBearerToken(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
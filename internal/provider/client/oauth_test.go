@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// fakeOAuthServer is a stand-in for the GitLab OAuth2 token endpoint and the REST API.
+type fakeOAuthServer struct {
+	lock        sync.Mutex
+	grants      []string
+	validTokens map[string]bool
+	issued      int
+}
+
+func (s *fakeOAuthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch r.URL.Path {
+	case "/oauth/token":
+		_ = r.ParseForm()
+		if r.Form.Get("client_id") != "app-id" || r.Form.Get("client_secret") != "app-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		grantType := r.Form.Get("grant_type")
+		if grantType == "password" && (r.Form.Get("username") != "root" || r.Form.Get("password") != "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.grants = append(s.grants, grantType)
+		s.issued++
+		token := "token-" + strconv.Itoa(s.issued)
+		s.validTokens[token] = true
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  token,
+			"refresh_token": "refresh-" + token,
+			"token_type":    "Bearer",
+			"expires_in":    7200,
+		})
+	default:
+		if auth := r.Header.Get("Authorization"); len(auth) < 7 || !s.validTokens[auth[7:]] {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "401 Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "username": "root"}`))
+	}
+}
+
+func (s *fakeOAuthServer) revokeAll() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.validTokens = make(map[string]bool)
+}
+
+func TestOAuth_passwordGrantWithRefresh(t *testing.T) {
+	fake := &fakeOAuthServer{validTokens: make(map[string]bool)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config := &Config{
+		BaseURL: server.URL + "/api/v4/",
+		OAuth: &OAuthConfig{
+			ClientID:     "app-id",
+			ClientSecret: "app-secret",
+			Username:     "root",
+			Password:     "secret",
+		},
+		EarlyAuthFail: true,
+	}
+	client, err := config.NewGitLabClient(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the token expires during a long running apply
+	fake.revokeAll()
+
+	user, _, err := client.Users.CurrentUser(gitlab.WithContext(context.Background()))
+	if err != nil {
+		t.Fatalf("expected request to succeed with refreshed token, got: %v", err)
+	}
+	if user.Username != "root" {
+		t.Fatalf("unexpected user %q", user.Username)
+	}
+
+	if len(fake.grants) != 2 || fake.grants[0] != "password" || fake.grants[1] != "refresh_token" {
+		t.Fatalf("expected a password grant followed by a refresh token grant, got %v", fake.grants)
+	}
+}
+
+func TestOAuth_clientCredentialsGrant(t *testing.T) {
+	fake := &fakeOAuthServer{validTokens: make(map[string]bool)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config := &Config{
+		BaseURL: server.URL + "/api/v4/",
+		OAuth: &OAuthConfig{
+			ClientID:     "app-id",
+			ClientSecret: "app-secret",
+			Scopes:       []string{"api"},
+		},
+		EarlyAuthFail: true,
+	}
+	if _, err := config.NewGitLabClient(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(fake.grants) != 1 || fake.grants[0] != "client_credentials" {
+		t.Fatalf("expected a client credentials grant, got %v", fake.grants)
+	}
+}
+
+func TestOAuth_invalidCredentials(t *testing.T) {
+	fake := &fakeOAuthServer{validTokens: make(map[string]bool)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config := &Config{
+		BaseURL: server.URL + "/api/v4/",
+		OAuth: &OAuthConfig{
+			ClientID:     "app-id",
+			ClientSecret: "wrong",
+		},
+	}
+	if _, err := config.NewGitLabClient(context.Background()); err == nil {
+		t.Fatalf("expected an error for invalid application credentials")
+	}
+}
+
+func TestOAuth_instanceURL(t *testing.T) {
+	cases := map[string]string{
+		"":                                        "https://gitlab.com/",
+		"https://gitlab.example.com/api/v4/":      "https://gitlab.example.com/",
+		"https://gitlab.example.com/api/v4":       "https://gitlab.example.com/",
+		"https://example.com/gitlab/api/v4/":      "https://example.com/gitlab/",
+		"https://gitlab.example.com/":             "https://gitlab.example.com/",
+		"https://gitlab.example.com/custom/path/": "https://gitlab.example.com/custom/path/",
+	}
+
+	for baseURL, expected := range cases {
+		if actual := instanceURL(baseURL); actual != expected {
+			t.Errorf("expected instance URL %q for base URL %q, got %q", expected, baseURL, actual)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const redacted = "***"
+
+// redactedHeaders are the headers which contain credentials.
+var redactedHeaders = append([]string{"Proxy-Authorization", "Cookie", "Set-Cookie"}, authHeaders...)
+
+// redactedFields are the JSON fields which contain secrets, like the tokens returned by GitLab
+// for personal, project, group and deploy tokens, cluster agent tokens and runners.
+var redactedFields = map[string]bool{
+	"token":                      true,
+	"authentication_token":       true,
+	"runners_token":              true,
+	"registration_token":         true,
+	"runners_registration_token": true,
+	"access_token":               true,
+	"refresh_token":              true,
+	"client_secret":              true,
+	"secret":                     true,
+	"password":                   true,
+	"private_token":              true,
+}
+
+// redactingLoggingTransport is an http.RoundTripper which logs the requests and responses
+// like the transport of `logging.NewSubsystemLoggingHTTPTransport`, but redacts the credentials
+// in the headers and the secrets in JSON bodies, like tokens and variable values.
+// The secrets in a response are not known before it has been received, therefore they can't
+// be masked using the logger options of the request context.
+type redactingLoggingTransport struct {
+	transport http.RoundTripper
+}
+
+func newRedactingLoggingTransport(transport http.RoundTripper) http.RoundTripper {
+	return &redactingLoggingTransport{transport: transport}
+}
+
+func (t *redactingLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	transactionID, err := uuid.GenerateUUID()
+	if err != nil {
+		transactionID = "Unable to assign Transaction ID: " + err.Error()
+	}
+	ctx = tflog.SubsystemSetField(ctx, loggingSubsystem, logging.FieldHttpTransactionId, transactionID)
+
+	if fields, err := redactedRequestFields(req); err != nil {
+		tflog.SubsystemError(ctx, loggingSubsystem, "Failed to parse request bytes for logging", map[string]interface{}{
+			"error": err,
+		})
+	} else {
+		tflog.SubsystemDebug(ctx, loggingSubsystem, "Sending HTTP Request", fields)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if fields, err := redactedResponseFields(resp); err != nil {
+		tflog.SubsystemError(ctx, loggingSubsystem, "Failed to parse response bytes for logging", map[string]interface{}{
+			"error": err,
+		})
+	} else {
+		tflog.SubsystemDebug(ctx, loggingSubsystem, "Received HTTP Response", fields)
+	}
+
+	return resp, nil
+}
+
+func redactedRequestFields(req *http.Request) (map[string]interface{}, error) {
+	// The dump contains the headers added by the http.Transport, like the `User-Agent`,
+	// and restores the request body.
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		return nil, err
+	}
+	dumpedReq, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(dump)))
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(dumpedReq.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := redactedHeaderFields(dumpedReq.Header)
+	fields[logging.FieldHttpOperationType] = logging.OperationHttpRequest
+	fields[logging.FieldHttpRequestMethod] = req.Method
+	fields[logging.FieldHttpRequestUri] = req.URL.RequestURI()
+	fields[logging.FieldHttpRequestProtoVersion] = req.Proto
+	fields[logging.FieldHttpRequestBody] = redactBody(body, req.URL.Path)
+	return fields, nil
+}
+
+func redactedResponseFields(resp *http.Response) (map[string]interface{}, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fields := redactedHeaderFields(resp.Header)
+	fields[logging.FieldHttpOperationType] = logging.OperationHttpResponse
+	fields[logging.FieldHttpResponseProtoVersion] = resp.Proto
+	fields[logging.FieldHttpResponseStatusCode] = resp.StatusCode
+	fields[logging.FieldHttpResponseStatusReason] = resp.Status
+	path := ""
+	if resp.Request != nil {
+		path = resp.Request.URL.Path
+	}
+	fields[logging.FieldHttpResponseBody] = redactBody(body, path)
+	return fields, nil
+}
+
+func redactedHeaderFields(header http.Header) map[string]interface{} {
+	fields := make(map[string]interface{}, len(header)+6)
+	for name, values := range header {
+		if len(values) == 1 {
+			fields[name] = values[0]
+		} else {
+			fields[name] = values
+		}
+	}
+	for _, name := range redactedHeaders {
+		if _, ok := fields[http.CanonicalHeaderKey(name)]; ok {
+			fields[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+	return fields
+}
+
+// redactBody returns the body of a request to the given path with the secrets redacted, if it's a JSON document.
+// Other bodies are returned as is, GitLab only returns secrets in JSON documents.
+func redactBody(body []byte, path string) string {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return string(body)
+	}
+
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	// keep numbers as is, like large IDs
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return string(body)
+	}
+	if !redactValue(document, isVariablesPath(path)) {
+		return string(body)
+	}
+
+	var redactedBody strings.Builder
+	encoder := json.NewEncoder(&redactedBody)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return redacted
+	}
+	return strings.TrimSuffix(redactedBody.String(), "\n")
+}
+
+// isVariablesPath reports whether the path is a CI/CD variables endpoint, like the project, group, instance,
+// pipeline schedule and pipeline variables.
+func isVariablesPath(path string) bool {
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "variables" {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue redacts the secrets in the given JSON value and reports whether it has changed anything.
+// The values of variables are redacted if the value is a response or request of a variables endpoint,
+// because unmasked variables may contain secrets as well.
+func redactValue(value interface{}, variables bool) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if s, ok := field.(string); ok && s != "" && redactedFields[key] {
+				v[key] = redacted
+				changed = true
+				continue
+			}
+			changed = redactValue(field, variables) || changed
+		}
+		// the values of CI/CD variables are secret, too
+		if masked, _ := v["masked"].(bool); masked || variables {
+			if s, ok := v["value"].(string); ok && s != "" {
+				v["value"] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			changed = redactValue(item, variables) || changed
+		}
+	}
+	return changed
+}
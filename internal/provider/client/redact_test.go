@@ -0,0 +1,97 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedact_redactBody(t *testing.T) {
+	cases := map[string]string{
+		`{"id": 1, "name": "my-token", "token": "glpat-secret"}`:                         `{"id":1,"name":"my-token","token":"***"}`,
+		`[{"id": 1, "authentication_token": "runner-secret", "token_expires_at": null}]`: `[{"authentication_token":"***","id":1,"token_expires_at":null}]`,
+		`{"key": "SECRET", "value": "masked-secret", "masked": true}`:                    `{"key":"SECRET","masked":true,"value":"***"}`,
+		`{"key": "PLAIN", "value": "plain-value", "masked": false}`:                      `{"key": "PLAIN", "value": "plain-value", "masked": false}`,
+		`{"id": 12345678901234567890, "token": ""}`:                                      `{"id": 12345678901234567890, "token": ""}`,
+		`{"agent": {"token": "agent-secret"}}`:                                           `{"agent":{"token":"***"}}`,
+		`not json, token: "secret"`:                                                      `not json, token: "secret"`,
+		``:                                                                               ``,
+	}
+
+	for body, expected := range cases {
+		if actual := redactBody([]byte(body), "/api/v4/projects/1/issues"); actual != expected {
+			t.Errorf("expected body %s to be redacted to %s, got %s", body, expected, actual)
+		}
+	}
+}
+
+func TestRedact_redactBodyVariables(t *testing.T) {
+	paths := []string{
+		"/api/v4/projects/1/variables",
+		"/api/v4/projects/1/variables/PLAIN",
+		"/api/v4/groups/my-group/variables",
+		"/api/v4/admin/ci/variables",
+		"/api/v4/projects/1/pipeline_schedules/2/variables",
+		"/api/v4/projects/1/pipelines/3/variables",
+	}
+	cases := map[string]string{
+		`{"key": "PLAIN", "value": "plain-value", "masked": false}`: `{"key":"PLAIN","masked":false,"value":"***"}`,
+		`[{"key": "PLAIN", "value": "plain-value"}]`:                `[{"key":"PLAIN","value":"***"}]`,
+		`{"key": "EMPTY", "value": ""}`:                             `{"key": "EMPTY", "value": ""}`,
+	}
+
+	for _, path := range paths {
+		for body, expected := range cases {
+			if actual := redactBody([]byte(body), path); actual != expected {
+				t.Errorf("expected body %s of %s to be redacted to %s, got %s", body, path, expected, actual)
+			}
+		}
+	}
+}
+
+func TestRedact_loggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key": "SECRET", "value": "response-secret", "token": "glpat-response-secret"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/v4/projects/1/variables", strings.NewReader(`{"key": "SECRET", "value": "request-secret", "masked": false}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req.Header.Set("Private-Token", "header-secret")
+
+	resp, err := newRedactingLoggingTransport(http.DefaultTransport).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(body), "glpat-response-secret") {
+		t.Fatalf("expected the response body to be passed on unredacted, got %s", body)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{"header-secret", "request-secret", "response-secret", "glpat-response-secret"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("expected %q to be redacted from the logs:\n%s", secret, logs)
+		}
+	}
+	if !strings.Contains(logs, "Sending HTTP Request") || !strings.Contains(logs, "Received HTTP Response") {
+		t.Errorf("expected the request and response to be logged:\n%s", logs)
+	}
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried if not configured otherwise.
+	DefaultMaxRetries = 5
+	// DefaultRetryWaitMin is the minimum time to wait between retries if not configured otherwise.
+	DefaultRetryWaitMin = 1 * time.Second
+	// DefaultRetryWaitMax is the maximum time to wait between retries if not configured otherwise.
+	DefaultRetryWaitMax = 30 * time.Second
+
+	// loggingSubsystem is the tflog subsystem used for all GitLab API related logs.
+	loggingSubsystem = "GitLab"
+
+	headerRetryAfter     = "Retry-After"
+	headerRateLimitReset = "RateLimit-Reset"
+)
+
+// idempotentMethods are the HTTP methods which are safe to send again
+// after the server may have already (partially) processed them.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// checkRetry is a retryablehttp.CheckRetry policy.
+// It retries rate limited requests (429) regardless of the HTTP method,
+// because the server did not process them. Server errors (5xx) and
+// connection resets are only retried for idempotent requests.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	// do not retry on context.Canceled or context.DeadlineExceeded
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		var urlErr *url.Error
+		if !errors.As(err, &urlErr) || !idempotentMethods[strings.ToUpper(urlErr.Op)] || !isConnectionReset(err) {
+			return false, err
+		}
+
+		tflog.SubsystemWarn(ctx, loggingSubsystem, "Retrying GitLab API request after connection error", map[string]interface{}{
+			"method": strings.ToUpper(urlErr.Op),
+			"url":    urlErr.URL,
+			"error":  err.Error(),
+		})
+		if u, err := url.Parse(urlErr.URL); err == nil {
+			sharedUsage.recordRetry(endpointOf(strings.ToUpper(urlErr.Op), u))
+		}
+		return true, nil
+	}
+
+	shouldRetry := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented && idempotentMethods[resp.Request.Method])
+	if shouldRetry {
+		tflog.SubsystemWarn(ctx, loggingSubsystem, "Retrying GitLab API request after failed response", map[string]interface{}{
+			"method":      resp.Request.Method,
+			"url":         resp.Request.URL.String(),
+			"status_code": resp.StatusCode,
+		})
+		sharedUsage.recordRetry(endpointOf(resp.Request.Method, resp.Request.URL))
+	}
+	return shouldRetry, nil
+}
+
+// isConnectionReset checks if the given error was caused by the connection
+// being dropped by the server or an intermediate proxy.
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff is a retryablehttp.Backoff policy.
+// If the server tells us when to retry with the `Retry-After` or `RateLimit-Reset` headers
+// we honor that, otherwise we back off exponentially with some jitter, bound by min and max.
+func backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := waitFromResponseHeaders(resp.Header, time.Now()); ok {
+			if wait < min {
+				return min
+			}
+			return wait
+		}
+	}
+
+	wait := float64(min) * math.Pow(2, float64(attemptNum))
+	if wait > float64(max) || math.IsInf(wait, 0) {
+		wait = float64(max)
+	}
+
+	// add up to 20% of jitter to prevent a thundering herd of parallel resources
+	jitter := rand.Float64() * 0.2 * wait
+	if sleep := time.Duration(wait + jitter); sleep < max {
+		return sleep
+	}
+	return max
+}
+
+// waitFromResponseHeaders returns the duration to wait until the next request,
+// as indicated by the server in the `Retry-After` or `RateLimit-Reset` headers.
+func waitFromResponseHeaders(header http.Header, now time.Time) (time.Duration, bool) {
+	// The `Retry-After` header is either in seconds or an HTTP date.
+	// see https://www.rfc-editor.org/rfc/rfc9110#field.retry-after
+	if v := header.Get(headerRetryAfter); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(v); err == nil {
+			return date.Sub(now), true
+		}
+	}
+
+	// The `RateLimit-Reset` header GitLab sends is a Unix timestamp.
+	// see https://docs.gitlab.com/ee/user/admin_area/settings/user_and_ip_rate_limits.html#response-headers
+	if v := header.Get(headerRateLimitReset); v != "" {
+		if reset, err := strconv.ParseInt(v, 10, 64); err == nil && reset > 0 {
+			return time.Unix(reset, 0).Sub(now), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetry_checkRetry(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Method     string
+		StatusCode int
+		Err        error
+		Expected   bool
+	}{
+		{Name: "success", Method: http.MethodGet, StatusCode: 200, Expected: false},
+		{Name: "not found", Method: http.MethodGet, StatusCode: 404, Expected: false},
+		{Name: "rate limited GET", Method: http.MethodGet, StatusCode: 429, Expected: true},
+		{Name: "rate limited POST", Method: http.MethodPost, StatusCode: 429, Expected: true},
+		{Name: "bad gateway GET", Method: http.MethodGet, StatusCode: 502, Expected: true},
+		{Name: "service unavailable PUT", Method: http.MethodPut, StatusCode: 503, Expected: true},
+		{Name: "bad gateway POST", Method: http.MethodPost, StatusCode: 502, Expected: false},
+		{Name: "not implemented GET", Method: http.MethodGet, StatusCode: 501, Expected: false},
+		{Name: "connection reset GET", Err: &url.Error{Op: "Get", URL: "http://example.com", Err: syscall.ECONNRESET}, Expected: true},
+		{Name: "connection reset POST", Err: &url.Error{Op: "Post", URL: "http://example.com", Err: syscall.ECONNRESET}, Expected: false},
+		{Name: "other error GET", Err: &url.Error{Op: "Get", URL: "http://example.com", Err: errors.New("unsupported protocol scheme")}, Expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var resp *http.Response
+			if tc.Err == nil {
+				req, _ := http.NewRequest(tc.Method, "http://example.com", nil)
+				resp = &http.Response{StatusCode: tc.StatusCode, Request: req}
+			}
+
+			retry, _ := checkRetry(context.Background(), resp, tc.Err)
+			if retry != tc.Expected {
+				t.Fatalf("expected retry to be %t, got %t", tc.Expected, retry)
+			}
+		})
+	}
+}
+
+func TestRetry_checkRetry_canceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	retry, err := checkRetry(ctx, &http.Response{StatusCode: 503, Request: req}, nil)
+	if retry || err == nil {
+		t.Fatalf("expected no retry and an error for a canceled context, got %t and %v", retry, err)
+	}
+}
+
+func TestRetry_backoff(t *testing.T) {
+	min, max := 1*time.Second, 30*time.Second
+
+	t.Run("exponential", func(t *testing.T) {
+		for attempt, expected := range []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+			wait := backoff(min, max, attempt, nil)
+			if wait < expected || wait > expected+expected/5 {
+				t.Fatalf("attempt %d: expected wait between %s and %s, got %s", attempt, expected, expected+expected/5, wait)
+			}
+		}
+	})
+
+	t.Run("bounded by max", func(t *testing.T) {
+		if wait := backoff(min, max, 20, nil); wait != max {
+			t.Fatalf("expected wait of %s, got %s", max, wait)
+		}
+	})
+
+	t.Run("retry after header", func(t *testing.T) {
+		resp := &http.Response{StatusCode: 429, Header: http.Header{}}
+		resp.Header.Set("Retry-After", "42")
+		if wait := backoff(min, max, 0, resp); wait != 42*time.Second {
+			t.Fatalf("expected wait of 42s, got %s", wait)
+		}
+	})
+
+	t.Run("rate limit reset header", func(t *testing.T) {
+		resp := &http.Response{StatusCode: 429, Header: http.Header{}}
+		resp.Header.Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10))
+		if wait := backoff(min, max, 0, resp); wait < 8*time.Second || wait > 10*time.Second {
+			t.Fatalf("expected wait of about 10s, got %s", wait)
+		}
+	})
+
+	t.Run("header in the past", func(t *testing.T) {
+		resp := &http.Response{StatusCode: 503, Header: http.Header{}}
+		resp.Header.Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(-10*time.Second).Unix(), 10))
+		if wait := backoff(min, max, 0, resp); wait != min {
+			t.Fatalf("expected wait of %s, got %s", min, wait)
+		}
+	})
+}
+
+func TestRetry_waitFromResponseHeaders(t *testing.T) {
+	now := time.Date(2022, 12, 24, 12, 0, 0, 0, time.UTC)
+
+	header := http.Header{}
+	header.Set("Retry-After", now.Add(5*time.Second).Format(http.TimeFormat))
+	if wait, ok := waitFromResponseHeaders(header, now); !ok || wait != 5*time.Second {
+		t.Fatalf("expected wait of 5s from HTTP date, got %s (%t)", wait, ok)
+	}
+
+	if _, ok := waitFromResponseHeaders(http.Header{}, now); ok {
+		t.Fatalf("expected no wait without headers")
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import "net/http"
+
+// sudoTransport is an http.RoundTripper which impersonates the configured user
+// for all requests which don't already impersonate a user themselves.
+type sudoTransport struct {
+	sudo      string
+	transport http.RoundTripper
+}
+
+func newSudoTransport(sudo string, transport http.RoundTripper) http.RoundTripper {
+	if sudo == "" {
+		return transport
+	}
+	return &sudoTransport{sudo: sudo, transport: transport}
+}
+
+func (t *sudoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Sudo") != "" {
+		return t.transport.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the original request.
+	r := req.Clone(req.Context())
+	r.Header.Set("Sudo", t.sudo)
+	return t.transport.RoundTrip(r)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestSudo_providerAndRequestSudo(t *testing.T) {
+	var actualSudo []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/user" {
+			actualSudo = append(actualSudo, r.Header.Get("Sudo"))
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "username": "root"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Token:   "secret",
+		BaseURL: server.URL + "/api/v4/",
+		Sudo:    "provider-user",
+	}
+	client, err := config.NewGitLabClient(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, _, err := client.Users.CurrentUser(gitlab.WithContext(context.Background())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := client.Users.CurrentUser(gitlab.WithSudo("resource-user")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(actualSudo) != 2 || actualSudo[0] != "provider-user" || actualSudo[1] != "resource-user" {
+		t.Fatalf("expected the provider sudo to be overridden by the request sudo, got %v", actualSudo)
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RequestBudget is the request budget of a provider instance, which is shared by all its resources and data sources,
+// i.e. by the clients of both the SDKv2 and the Framework provider of the muxed provider server.
+// Each provider instance creates its own budget, so that aliased providers never share one,
+// even if they are configured with the same instance and token.
+type RequestBudget struct {
+	mu sync.Mutex
+	// configured reports whether the throttle has been created for the limits.
+	configured            bool
+	requestsPerSecond     float64
+	maxConcurrentRequests int
+	throttle              *throttle
+}
+
+// NewRequestBudget returns a new request budget for a provider instance.
+func NewRequestBudget() *RequestBudget {
+	return &RequestBudget{}
+}
+
+// throttleFor returns the throttle for the limits of the configuration. It's created by the first client
+// and shared by the following ones, unless they are configured with different limits.
+func (b *RequestBudget) throttleFor(c *Config) *throttle {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.configured || b.requestsPerSecond != c.RequestsPerSecond || b.maxConcurrentRequests != c.MaxConcurrentRequests {
+		b.configured = true
+		b.requestsPerSecond = c.RequestsPerSecond
+		b.maxConcurrentRequests = c.MaxConcurrentRequests
+		b.throttle = c.newThrottle()
+	}
+	return b.throttle
+}
+
+// throttle limits the requests of the clients which share it.
+type throttle struct {
+	// limiter is a token bucket limiting the requests per second, nil if unlimited.
+	limiter *rate.Limiter
+	// slots is a semaphore limiting the requests in-flight, nil if unlimited.
+	slots chan struct{}
+}
+
+// throttle returns the throttle of the request budget of the configuration
+// or a new one for the client alone without a request budget.
+func (c *Config) throttle() *throttle {
+	if c.RequestBudget != nil {
+		return c.RequestBudget.throttleFor(c)
+	}
+	return c.newThrottle()
+}
+
+// newThrottle returns a new throttle for the configuration.
+// It returns nil if neither the requests per second nor the concurrent requests are limited.
+func (c *Config) newThrottle() *throttle {
+	if c.RequestsPerSecond <= 0 && c.MaxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	t := &throttle{}
+	if c.RequestsPerSecond > 0 {
+		// allow to burst up to a second worth of requests
+		t.limiter = rate.NewLimiter(rate.Limit(c.RequestsPerSecond), int(math.Ceil(c.RequestsPerSecond)))
+	}
+	if c.MaxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, c.MaxConcurrentRequests)
+	}
+	return t
+}
+
+// throttledTransport is an http.RoundTripper which waits for the throttle
+// to grant a request before passing it on to the wrapped transport.
+type throttledTransport struct {
+	throttle  *throttle
+	transport http.RoundTripper
+}
+
+func newThrottledTransport(t *throttle, transport http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return transport
+	}
+	return &throttledTransport{throttle: t, transport: transport}
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.throttle.slots != nil {
+		select {
+		case t.throttle.slots <- struct{}{}:
+			// slot acquired
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if t.throttle.slots != nil {
+			<-t.throttle.slots
+		}
+	}
+
+	if t.throttle.limiter != nil {
+		if err := t.throttle.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// The request is in-flight until its response body has been consumed.
+	resp.Body = &releasingReadCloser{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingReadCloser calls release exactly once when it is closed.
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (r *releasingReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottle_newThrottle(t *testing.T) {
+	unlimited := &Config{BaseURL: "https://gitlab.example.com/api/v4/"}
+	if unlimited.newThrottle() != nil {
+		t.Fatalf("expected no throttle for an unlimited configuration")
+	}
+
+	// aliased providers with the same instance, token and limits get their own budget
+	config := &Config{BaseURL: "https://gitlab.example.com/api/v4/", Token: "a", RequestsPerSecond: 10}
+	alias := &Config{BaseURL: "https://gitlab.example.com/api/v4/", Token: "a", RequestsPerSecond: 10}
+	if config.newThrottle() == alias.newThrottle() {
+		t.Fatalf("expected independent throttles for aliased providers")
+	}
+}
+
+func TestRequestBudget_throttleFor(t *testing.T) {
+	// the clients of the SDKv2 and the Framework provider of a provider instance share its budget
+	budget := NewRequestBudget()
+	sdkConfig := &Config{BaseURL: "https://gitlab.example.com/api/v4/", RequestsPerSecond: 10, RequestBudget: budget}
+	frameworkConfig := &Config{BaseURL: "https://gitlab.example.com/api/v4/", RequestsPerSecond: 10, RequestBudget: budget}
+	if sdkConfig.throttle() == nil || sdkConfig.throttle() != frameworkConfig.throttle() {
+		t.Fatalf("expected a shared throttle for the clients of a provider instance")
+	}
+
+	// aliased providers have their own budget
+	alias := &Config{BaseURL: "https://gitlab.example.com/api/v4/", RequestsPerSecond: 10, RequestBudget: NewRequestBudget()}
+	if alias.throttle() == sdkConfig.throttle() {
+		t.Fatalf("expected independent throttles for aliased providers")
+	}
+
+	// a reconfigured provider instance gets the new limits
+	previous := sdkConfig.throttle()
+	sdkConfig.RequestsPerSecond = 5
+	if throttle := sdkConfig.throttle(); throttle == previous || throttle.limiter.Limit() != 5 {
+		t.Fatalf("expected a new throttle for the new limits")
+	}
+}
+
+func TestThrottle_maxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	config := &Config{BaseURL: server.URL, MaxConcurrentRequests: 2}
+	client := &http.Client{Transport: newThrottledTransport(config.newThrottle(), http.DefaultTransport)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestThrottle_requestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	config := &Config{BaseURL: server.URL, RequestsPerSecond: 20}
+	client := &http.Client{Transport: newThrottledTransport(config.newThrottle(), http.DefaultTransport)}
+
+	start := time.Now()
+	// the first 20 requests are allowed as burst, the next 10 take about half a second.
+	for i := 0; i < 30; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, but 30 requests took only %s", elapsed)
+	}
+}
@@ -0,0 +1,312 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/xanzy/go-gitlab"
+)
+
+// RequestIDHeader is the header which correlates a request sent by the provider with the GitLab logs.
+// GitLab returns the ID it used for the request in the same response header.
+const RequestIDHeader = "X-Request-Id"
+
+// sharedUsage records the API usage of all clients in this process,
+// which lives as long as a single Terraform operation, like a plan or an apply.
+var sharedUsage = newUsage()
+
+// usage records the requests sent to GitLab per endpoint.
+type usage struct {
+	lock      sync.Mutex
+	endpoints map[string]*endpointUsage
+}
+
+type endpointUsage struct {
+	requests  int
+	errors    int
+	retries   int
+	latencies []time.Duration
+}
+
+func newUsage() *usage {
+	return &usage{endpoints: make(map[string]*endpointUsage)}
+}
+
+// endpointLocked returns the usage of the given endpoint, the caller must hold the lock.
+func (u *usage) endpointLocked(endpoint string) *endpointUsage {
+	e, ok := u.endpoints[endpoint]
+	if !ok {
+		e = &endpointUsage{}
+		u.endpoints[endpoint] = e
+	}
+	return e
+}
+
+func (u *usage) recordRequest(endpoint string, latency time.Duration, failed bool) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	e := u.endpointLocked(endpoint)
+	e.requests++
+	e.latencies = append(e.latencies, latency)
+	if failed {
+		e.errors++
+	}
+}
+
+func (u *usage) recordRetry(endpoint string) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.endpointLocked(endpoint).retries++
+}
+
+// endpointOf returns the endpoint of a request, like `GET /projects/:id/variables/:id`.
+// Numeric IDs and URL-encoded paths, like `my-group%2Fmy-project`, are replaced, to group the requests
+// for different resources of the same kind.
+func endpointOf(method string, u *url.URL) string {
+	segments := apiRelativePathSegments(u.EscapedPath())
+	for i, segment := range segments {
+		if isNumeric(segment) || strings.Contains(segment, "%") {
+			segments[i] = ":id"
+		}
+	}
+	return method + " /" + strings.Join(segments, "/")
+}
+
+// LogUsageSummary logs the number of requests, errors and retries and the latency percentiles
+// per endpoint of all the requests sent to GitLab by this process.
+// It's meant to be called once the provider shuts down.
+func LogUsageSummary(ctx context.Context) {
+	sharedUsage.logSummary(ctx)
+}
+
+func (u *usage) logSummary(ctx context.Context) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	endpoints := make([]string, 0, len(u.endpoints))
+	totalRequests, totalErrors, totalRetries := 0, 0, 0
+	for endpoint, e := range u.endpoints {
+		endpoints = append(endpoints, endpoint)
+		totalRequests += e.requests
+		totalErrors += e.errors
+		totalRetries += e.retries
+	}
+	if totalRequests == 0 {
+		return
+	}
+
+	// the busiest endpoints first
+	sort.Slice(endpoints, func(i, j int) bool {
+		a, b := u.endpoints[endpoints[i]], u.endpoints[endpoints[j]]
+		if a.requests != b.requests {
+			return a.requests > b.requests
+		}
+		return endpoints[i] < endpoints[j]
+	})
+
+	tflog.SubsystemInfo(ctx, loggingSubsystem, "GitLab API usage summary", map[string]interface{}{
+		"requests":  totalRequests,
+		"errors":    totalErrors,
+		"retries":   totalRetries,
+		"endpoints": len(endpoints),
+	})
+	for _, endpoint := range endpoints {
+		e := u.endpoints[endpoint]
+		latencies := make([]time.Duration, len(e.latencies))
+		copy(latencies, e.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		tflog.SubsystemInfo(ctx, loggingSubsystem, "GitLab API endpoint usage", map[string]interface{}{
+			"endpoint":       endpoint,
+			"requests":       e.requests,
+			"errors":         e.errors,
+			"retries":        e.retries,
+			"latency_p50_ms": percentile(latencies, 50).Milliseconds(),
+			"latency_p90_ms": percentile(latencies, 90).Milliseconds(),
+			"latency_p99_ms": percentile(latencies, 99).Milliseconds(),
+			"latency_max_ms": percentile(latencies, 100).Milliseconds(),
+		})
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+type failedRequestsContextKey struct{}
+
+// failedRequests holds the GitLab request IDs and the errors of the failed requests of an operation.
+type failedRequests struct {
+	lock     sync.Mutex
+	ids      []string
+	errors   []*gitlab.ErrorResponse
+	requests []FailedRequest
+}
+
+// FailedRequest is a failed request of an operation with the GitLab request ID of its response.
+type FailedRequest struct {
+	// ID is the GitLab request ID of the response.
+	ID string
+	// Err is the error of the response, like it is returned by the go-gitlab client, nil if the response couldn't be read.
+	Err *gitlab.ErrorResponse
+}
+
+// WithFailedRequests returns a context which records the GitLab request IDs and the errors of all failed requests
+// sent with it, so that they can be reported with the errors of an operation, see FailedRequestIDs and FailedRequestErrors.
+func WithFailedRequests(ctx context.Context) context.Context {
+	return context.WithValue(ctx, failedRequestsContextKey{}, &failedRequests{})
+}
+
+// withoutFailedRequests returns a context which doesn't record the failed requests sent with it,
+// e.g. for the requests which only gather information to explain an error.
+func withoutFailedRequests(ctx context.Context) context.Context {
+	return context.WithValue(ctx, failedRequestsContextKey{}, nil)
+}
+
+// FailedRequestIDs returns the GitLab request IDs of the failed requests recorded in the given context.
+func FailedRequestIDs(ctx context.Context) []string {
+	recorded, ok := ctx.Value(failedRequestsContextKey{}).(*failedRequests)
+	if !ok || recorded == nil {
+		return nil
+	}
+
+	recorded.lock.Lock()
+	defer recorded.lock.Unlock()
+	return append([]string(nil), recorded.ids...)
+}
+
+// FailedRequests returns the failed requests recorded in the given context, in the order they have been sent.
+func FailedRequests(ctx context.Context) []FailedRequest {
+	recorded, ok := ctx.Value(failedRequestsContextKey{}).(*failedRequests)
+	if !ok || recorded == nil {
+		return nil
+	}
+
+	recorded.lock.Lock()
+	defer recorded.lock.Unlock()
+	return append([]FailedRequest(nil), recorded.requests...)
+}
+
+// FailedRequestErrors returns the errors of the failed requests recorded in the given context,
+// like they are returned by the go-gitlab client.
+func FailedRequestErrors(ctx context.Context) []*gitlab.ErrorResponse {
+	recorded, ok := ctx.Value(failedRequestsContextKey{}).(*failedRequests)
+	if !ok || recorded == nil {
+		return nil
+	}
+
+	recorded.lock.Lock()
+	defer recorded.lock.Unlock()
+	return append([]*gitlab.ErrorResponse(nil), recorded.errors...)
+}
+
+func recordFailedRequest(ctx context.Context, id string, req *http.Request, resp *http.Response) {
+	recorded, ok := ctx.Value(failedRequestsContextKey{}).(*failedRequests)
+	if !ok || recorded == nil {
+		return
+	}
+
+	// the body of the response is read by the client as well, therefore it's restored for it.
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var errorResponse *gitlab.ErrorResponse
+	if err == nil {
+		// the same error the go-gitlab client returns for the response
+		errorResponse, _ = gitlab.CheckResponse(&http.Response{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    req,
+		}).(*gitlab.ErrorResponse)
+	}
+
+	recorded.lock.Lock()
+	defer recorded.lock.Unlock()
+	if errorResponse != nil {
+		recorded.errors = append(recorded.errors, errorResponse)
+	}
+	recorded.requests = append(recorded.requests, FailedRequest{ID: id, Err: errorResponse})
+	if id == "" {
+		return
+	}
+	for _, recordedID := range recorded.ids {
+		if recordedID == id {
+			return
+		}
+	}
+	recorded.ids = append(recorded.ids, id)
+}
+
+// usageTransport is an http.RoundTripper which attaches a correlation ID to every request
+// and records the usage of the endpoints.
+type usageTransport struct {
+	usage     *usage
+	transport http.RoundTripper
+}
+
+func newUsageTransport(u *usage, transport http.RoundTripper) http.RoundTripper {
+	return &usageTransport{usage: u, transport: transport}
+}
+
+func (t *usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := req.Header.Get(RequestIDHeader)
+	if requestID == "" {
+		var err error
+		if requestID, err = uuid.GenerateUUID(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The correlation ID is added to all logs of the request, which also contain the resource type.
+	ctx := tflog.SubsystemSetField(req.Context(), loggingSubsystem, "gitlab_correlation_id", requestID)
+	// A RoundTripper must not modify the original request.
+	r := req.Clone(ctx)
+	r.Header.Set(RequestIDHeader, requestID)
+
+	endpoint := endpointOf(req.Method, req.URL)
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(r)
+	latency := time.Since(start)
+
+	if err != nil {
+		t.usage.recordRequest(endpoint, latency, true)
+		return nil, err
+	}
+
+	failed := resp.StatusCode >= http.StatusBadRequest
+	t.usage.recordRequest(endpoint, latency, failed)
+	if failed {
+		gitlabRequestID := resp.Header.Get(RequestIDHeader)
+		if gitlabRequestID == "" {
+			gitlabRequestID = requestID
+		}
+		recordFailedRequest(req.Context(), gitlabRequestID, req, resp)
+		tflog.SubsystemDebug(ctx, loggingSubsystem, "GitLab API request failed", map[string]interface{}{
+			"endpoint":          endpoint,
+			"status_code":       resp.StatusCode,
+			"gitlab_request_id": gitlabRequestID,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUsage_endpointOf(t *testing.T) {
+	cases := map[string]string{
+		"https://gitlab.example.com/api/v4/projects/42/variables/FOO":                 "GET /projects/:id/variables/FOO",
+		"https://gitlab.example.com/api/v4/projects/my-group%2Fmy-project/hooks/1":    "GET /projects/:id/hooks/:id",
+		"https://gitlab.example.com/gitlab/api/v4/user":                               "GET /user",
+		"https://gitlab.example.com/api/v4/projects/1/repository/files/a%2Fb.txt?x=1": "GET /projects/:id/repository/files/:id",
+	}
+
+	for rawURL, expected := range cases {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual := endpointOf(http.MethodGet, u); actual != expected {
+			t.Errorf("expected endpoint %q for %s, got %q", expected, rawURL, actual)
+		}
+	}
+}
+
+func TestUsage_percentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	cases := map[int]time.Duration{50: 50 * time.Millisecond, 90: 90 * time.Millisecond, 99: 99 * time.Millisecond, 100: 100 * time.Millisecond}
+	for p, expected := range cases {
+		if actual := percentile(latencies, p); actual != expected {
+			t.Errorf("expected p%d to be %s, got %s", p, expected, actual)
+		}
+	}
+	if actual := percentile(nil, 50); actual != 0 {
+		t.Errorf("expected p50 of no latencies to be 0, got %s", actual)
+	}
+}
+
+func TestUsage_transport(t *testing.T) {
+	var sentRequestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sentRequestIDs = append(sentRequestIDs, r.Header.Get(RequestIDHeader))
+		if r.URL.Path == "/api/v4/projects/1" {
+			w.Header().Set(RequestIDHeader, "gitlab-request-id")
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	u := newUsage()
+	transport := newUsageTransport(u, http.DefaultTransport)
+	ctx := WithFailedRequests(context.Background())
+
+	for _, path := range []string{"/api/v4/projects/1", "/api/v4/projects/2", "/api/v4/projects/1"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(sentRequestIDs) != 3 || sentRequestIDs[0] == "" || sentRequestIDs[0] == sentRequestIDs[1] {
+		t.Errorf("expected a unique request ID for every request, got %v", sentRequestIDs)
+	}
+	if ids := FailedRequestIDs(ctx); len(ids) != 1 || ids[0] != "gitlab-request-id" {
+		t.Errorf("expected the GitLab request ID of the failed requests to be recorded once, got %v", ids)
+	}
+	if errs := FailedRequestErrors(ctx); len(errs) != 2 || errs[0].Response.StatusCode != http.StatusForbidden || !strings.HasPrefix(errs[0].Error(), "GET "+server.URL+"/api/v4/projects/1: 403 ") {
+		t.Errorf("expected the errors of the failed requests to be recorded like go-gitlab returns them, got %v", errs)
+	}
+
+	e := u.endpoints["GET /projects/:id"]
+	if e == nil || e.requests != 3 || e.errors != 2 || len(e.latencies) != 3 {
+		t.Errorf("expected 3 requests with 2 errors to be recorded, got %+v", e)
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const apiVersionPath = "/api/v4/"
+
+// ConfigError is an invalid value of a single provider configuration attribute.
+type ConfigError struct {
+	// Attribute is the name of the provider attribute the error belongs to.
+	Attribute string
+	Summary   string
+	Detail    string
+}
+
+func (e ConfigError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Summary, e.Detail)
+}
+
+// Validate validates the configuration values and normalizes them where it's unambiguous,
+// like a base URL without the API path. It's shared by the SDK and the Framework provider,
+// so that both report the same errors for the same configuration.
+func (c *Config) Validate(ctx context.Context) []ConfigError {
+	var errs []ConfigError
+
+	if c.BaseURL != "" {
+		baseURL, err := normalizeBaseURL(c.BaseURL)
+		if err != nil {
+			errs = append(errs, ConfigError{Attribute: "base_url", Summary: "Invalid GitLab Base URL", Detail: fmt.Sprintf("The base URL %q is invalid: %v.", c.BaseURL, err)})
+		} else if baseURL != c.BaseURL {
+			tflog.SubsystemInfo(ctx, loggingSubsystem, "Normalized the GitLab base URL", map[string]interface{}{
+				"base_url":            c.BaseURL,
+				"normalized_base_url": baseURL,
+			})
+			c.BaseURL = baseURL
+		}
+	}
+
+	if c.CACertFile != "" {
+		caCert, err := os.ReadFile(c.CACertFile)
+		if err != nil {
+			errs = append(errs, ConfigError{Attribute: "cacert_file", Summary: "Unreadable GitLab CA Certificate File", Detail: fmt.Sprintf("The CA certificate file cannot be read: %v", err)})
+		} else if !x509.NewCertPool().AppendCertsFromPEM(caCert) {
+			errs = append(errs, ConfigError{Attribute: "cacert_file", Summary: "Invalid GitLab CA Certificate File", Detail: fmt.Sprintf("The CA certificate file %s does not contain any PEM encoded certificate.", c.CACertFile)})
+		}
+	}
+	if c.CACert != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(c.CACert)) {
+		errs = append(errs, ConfigError{Attribute: "cacert", Summary: "Invalid GitLab CA Certificate", Detail: "The CA certificate does not contain any PEM encoded certificate."})
+	}
+
+	if c.ClientCert != "" {
+		if _, err := os.Stat(c.ClientCert); err != nil {
+			errs = append(errs, ConfigError{Attribute: "client_cert", Summary: "Unreadable GitLab Client Certificate File", Detail: fmt.Sprintf("The client certificate file cannot be read: %v", err)})
+		}
+	}
+	if c.ClientKey != "" {
+		if _, err := os.Stat(c.ClientKey); err != nil {
+			errs = append(errs, ConfigError{Attribute: "client_key", Summary: "Unreadable GitLab Client Key File", Detail: fmt.Sprintf("The client key file cannot be read: %v", err)})
+		}
+	}
+
+	// the client certificate and key may each be given as file or as PEM encoded data, but always together.
+	hasClientCert := c.ClientCert != "" || c.ClientCertPEM != ""
+	hasClientKey := c.ClientKey != "" || c.ClientKeyPEM != ""
+	if hasClientCert && !hasClientKey {
+		errs = append(errs, ConfigError{Attribute: "client_key", Summary: "Missing GitLab Client Key", Detail: "The client_key or client_key_pem attribute is required when a client certificate is set."})
+	}
+	if hasClientKey && !hasClientCert {
+		errs = append(errs, ConfigError{Attribute: "client_cert", Summary: "Missing GitLab Client Certificate", Detail: "The client_cert or client_cert_pem attribute is required when a client key is set."})
+	}
+
+	if c.ProxyURL != "" {
+		if err := validateProxyURL(c.ProxyURL); err != nil {
+			errs = append(errs, ConfigError{Attribute: "proxy_url", Summary: "Invalid GitLab Proxy URL", Detail: fmt.Sprintf("The proxy URL is invalid: %v.", err)})
+		}
+	}
+
+	return errs
+}
+
+// normalizeBaseURL returns the base URL of the GitLab API v4 for the given URL,
+// which may also be the URL of the GitLab instance itself, e.g. `https://gitlab.example.com`.
+func normalizeBaseURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("it must be an absolute http or https URL like `https://gitlab.example.com/api/v4/`")
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	switch {
+	case strings.HasSuffix(path+"/", apiVersionPath):
+		// already the API v4 endpoint, maybe without the trailing slash
+	case strings.Contains(path+"/", "/api/"):
+		// it's not clear what is meant by any other API path
+		return "", fmt.Errorf("it must be the GitLab API v4 endpoint ending with `%s`, like `https://gitlab.example.com/api/v4/`", apiVersionPath)
+	default:
+		// the URL of the GitLab instance
+		path += strings.TrimSuffix(apiVersionPath, "/")
+	}
+	u.Path = path + "/"
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+func validateProxyURL(proxyURL string) error {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return nil
+	default:
+		return fmt.Errorf("unsupported scheme %q, must be one of http, https, socks5", u.Scheme)
+	}
+}
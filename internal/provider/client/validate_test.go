@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate_normalizeBaseURL(t *testing.T) {
+	cases := map[string]string{
+		"https://gitlab.example.com/api/v4/":        "https://gitlab.example.com/api/v4/",
+		"https://gitlab.example.com/api/v4":         "https://gitlab.example.com/api/v4/",
+		"https://gitlab.example.com":                "https://gitlab.example.com/api/v4/",
+		"https://gitlab.example.com/":               "https://gitlab.example.com/api/v4/",
+		"https://example.com/gitlab":                "https://example.com/gitlab/api/v4/",
+		"http://localhost:8080/gitlab/api/v4":       "http://localhost:8080/gitlab/api/v4/",
+		"https://example.com/gitlab/api/v4/?foo=ba": "https://example.com/gitlab/api/v4/?foo=ba",
+	}
+
+	for baseURL, expected := range cases {
+		actual, err := normalizeBaseURL(baseURL)
+		if err != nil {
+			t.Errorf("unexpected error for base URL %q: %v", baseURL, err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("expected base URL %q to be normalized to %q, got %q", baseURL, expected, actual)
+		}
+	}
+}
+
+func TestValidate_normalizeBaseURL_errors(t *testing.T) {
+	for _, baseURL := range []string{
+		"gitlab.example.com/api/v4/",
+		"ftp://gitlab.example.com/api/v4/",
+		"https://gitlab.example.com/api/v3/",
+		"https://gitlab.example.com/api/",
+		"https://gitlab.example.com/api/v4/projects/",
+		"https://gitlab.example.com/%zz",
+	} {
+		if actual, err := normalizeBaseURL(baseURL); err == nil {
+			t.Errorf("expected an error for base URL %q, got %q", baseURL, actual)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	missingFile := filepath.Join(t.TempDir(), "missing.pem")
+
+	cases := []struct {
+		Name               string
+		Config             Config
+		ExpectedAttributes []string
+	}{
+		{Name: "valid", Config: Config{BaseURL: "https://gitlab.example.com/api/v4/"}},
+		{Name: "invalid base URL", Config: Config{BaseURL: "https://gitlab.example.com/api/v3/"}, ExpectedAttributes: []string{"base_url"}},
+		{Name: "unreadable CA certificate file", Config: Config{CACertFile: missingFile}, ExpectedAttributes: []string{"cacert_file"}},
+		{Name: "invalid CA certificate", Config: Config{CACert: "not a certificate"}, ExpectedAttributes: []string{"cacert"}},
+		{Name: "client certificate without key", Config: Config{ClientCertPEM: "cert"}, ExpectedAttributes: []string{"client_key"}},
+		{Name: "client key without certificate", Config: Config{ClientKeyPEM: "key"}, ExpectedAttributes: []string{"client_cert"}},
+		{Name: "unreadable client certificate file", Config: Config{ClientCert: missingFile, ClientKeyPEM: "key"}, ExpectedAttributes: []string{"client_cert"}},
+		{Name: "invalid proxy URL", Config: Config{ProxyURL: "ftp://proxy.example.com"}, ExpectedAttributes: []string{"proxy_url"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			errs := tc.Config.Validate(context.Background())
+			if len(errs) != len(tc.ExpectedAttributes) {
+				t.Fatalf("expected %d errors, got %v", len(tc.ExpectedAttributes), errs)
+			}
+			for i, err := range errs {
+				if err.Attribute != tc.ExpectedAttributes[i] {
+					t.Errorf("expected error for attribute %q, got %v", tc.ExpectedAttributes[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_normalizesBaseURL(t *testing.T) {
+	config := Config{BaseURL: "https://gitlab.example.com"}
+	if errs := config.Validate(context.Background()); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if config.BaseURL != "https://gitlab.example.com/api/v4/" {
+		t.Fatalf("expected the base URL to be normalized, got %q", config.BaseURL)
+	}
+}
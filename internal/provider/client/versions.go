@@ -20,29 +20,34 @@ func IsGitLabVersionLessThan(ctx context.Context, client *gitlab.Client, version
 
 // IsGitLabVersionAtLeast is a SkipFunc that checks that the version of GitLab is at least the
 // provided wantVersion. It only checks the major and minor version numbers, not the patch.
+// The version of GitLab is cached per client, see `GetInstanceInfo`.
 func IsGitLabVersionAtLeast(ctx context.Context, client *gitlab.Client, wantVersion string) func() (bool, error) {
 	return func() (bool, error) {
-		wantMajor, wantMinor, err := parseVersionMajorMinor(wantVersion)
-		if err != nil {
-			return false, fmt.Errorf("failed to parse wanted version %q: %w", wantVersion, err)
-		}
-
-		actualVersion, _, err := client.Version.GetVersion(gitlab.WithContext(ctx))
+		info, err := GetInstanceInfo(ctx, client)
 		if err != nil {
 			return false, err
 		}
 
-		actualMajor, actualMinor, err := parseVersionMajorMinor(actualVersion.Version)
-		if err != nil {
-			return false, fmt.Errorf("failed to parse actual version %q: %w", actualVersion.Version, err)
-		}
+		return isVersionAtLeast(info.Version, wantVersion)
+	}
+}
 
-		if actualMajor == wantMajor {
-			return actualMinor >= wantMinor, nil
-		}
+func isVersionAtLeast(actualVersion string, wantVersion string) (bool, error) {
+	wantMajor, wantMinor, err := parseVersionMajorMinor(wantVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse wanted version %q: %w", wantVersion, err)
+	}
 
-		return actualMajor > wantMajor, nil
+	actualMajor, actualMinor, err := parseVersionMajorMinor(actualVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse actual version %q: %w", actualVersion, err)
 	}
+
+	if actualMajor == wantMajor {
+		return actualMinor >= wantMinor, nil
+	}
+
+	return actualMajor > wantMajor, nil
 }
 
 func parseVersionMajorMinor(version string) (int, int, error) {
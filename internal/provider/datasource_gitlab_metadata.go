@@ -122,10 +122,7 @@ func (d *gitlabMetadataDataSource) Read(ctx context.Context, req datasource.Read
 		return err
 	}()
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to fetch GitLab Metadata from API",
-			err.Error(),
-		)
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, d.client, resp.State.Schema, "Unable to fetch GitLab Metadata from API", err)
 		return
 	}
 
@@ -7,11 +7,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
 	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/sdk"
 )
 
 func NewMuxedProviderServer(ctx context.Context, version string) (func() tfprotov6.ProviderServer, error) {
-	sdkProvider, err := sdk.NewV6(ctx, version)
+	// the SDKv2 and the Framework provider of this provider instance share its request budget
+	budget := client.NewRequestBudget()
+	sdkProvider, err := sdk.NewV6WithRequestBudget(ctx, version, budget)
 	if err != nil {
 		return nil, err
 	}
@@ -20,12 +23,14 @@ func NewMuxedProviderServer(ctx context.Context, version string) (func() tfproto
 		// SDKv2 provider server
 		func() tfprotov6.ProviderServer { return sdkProvider },
 		// Framework provider server
-		providerserver.NewProtocol6(New(version)()),
+		providerserver.NewProtocol6(NewWithRequestBudget(version, budget)()),
 	}
 
 	muxServer, err := tf6muxserver.NewMuxServer(ctx, providers...)
 	if err != nil {
 		return nil, err
 	}
-	return muxServer.ProviderServer, nil
+	return func() tfprotov6.ProviderServer {
+		return requestIDProviderServer{ProviderServer: muxServer.ProviderServer()}
+	}, nil
 }
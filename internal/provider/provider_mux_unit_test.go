@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// dynamicValue returns the value of the schema with the given attributes,
+// all other attributes are null and all nested blocks are empty.
+func dynamicValue(t *testing.T, schema *tfprotov6.Schema, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	objectType := schema.ValueType().(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for _, block := range schema.Block.BlockTypes {
+		switch block.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+			attributes[block.TypeName] = tftypes.NewValue(objectType.AttributeTypes[block.TypeName], []tftypes.Value{})
+		}
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+	value, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, attributes))
+	if err != nil {
+		t.Fatalf("failed to create the value: %v", err)
+	}
+	return &value
+}
+
+func TestMuxedProviderServer_requestBudget(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	paths := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths[r.URL.Path] = true
+		mu.Unlock()
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	serverFactory, err := NewMuxedProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatalf("failed to create the provider server: %v", err)
+	}
+	s := serverFactory()
+	schemas, err := s.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil || len(schemas.Diagnostics) > 0 {
+		t.Fatalf("failed to get the provider schema: %v, %v", err, schemas.Diagnostics)
+	}
+
+	// both the SDKv2 and the Framework provider are configured with a single request in-flight
+	configured, err := s.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: dynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+			"token":                   tftypes.NewValue(tftypes.String, "token"),
+			"base_url":                tftypes.NewValue(tftypes.String, server.URL+"/api/v4"),
+			"early_auth_check":        tftypes.NewValue(tftypes.Bool, false),
+			"max_concurrent_requests": tftypes.NewValue(tftypes.Number, 1),
+		}),
+	})
+	if err != nil || len(configured.Diagnostics) > 0 {
+		t.Fatalf("failed to configure the provider: %v, %v", err, configured.Diagnostics)
+	}
+
+	// the data sources of the SDKv2 and the Framework provider share the limit
+	atomic.StoreInt32(&maxInFlight, 0)
+	var wg sync.WaitGroup
+	for _, typeName := range []string{"gitlab_current_user", "gitlab_metadata"} {
+		typeName := typeName
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+				TypeName: typeName,
+				Config:   dynamicValue(t, schemas.DataSourceSchemas[typeName], nil),
+			}); err != nil {
+				t.Errorf("failed to read %s: %v", typeName, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !paths["/api/graphql"] || !paths["/api/v4/metadata"] {
+		t.Fatalf("expected a request of each data source, got %v", paths)
+	}
+	if maxInFlight != 1 {
+		t.Fatalf("expected at most one request in-flight, got %d", maxInFlight)
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
+)
+
+// requestIDProviderServer is a tfprotov6.ProviderServer which adds the GitLab request IDs
+// of the failed API requests of an operation to the error diagnostics which report them,
+// so that GitLab administrators can find the requests in the GitLab logs.
+// The failed requests are recorded per RPC, so that the diagnostics never contain the requests of another operation.
+// It wraps the muxed server, which makes it work for the SDK and the Framework provider alike.
+type requestIDProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (s requestIDProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	ctx = client.WithFailedRequests(ctx)
+	resp, err := s.ProviderServer.ConfigureProvider(ctx, req)
+	if resp != nil {
+		addRequestIDsToDiagnostics(ctx, "ConfigureProvider", "", resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s requestIDProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx = client.WithFailedRequests(ctx)
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	if resp != nil {
+		addRequestIDsToDiagnostics(ctx, "ReadResource", req.TypeName, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s requestIDProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx = client.WithFailedRequests(ctx)
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		addRequestIDsToDiagnostics(ctx, "PlanResourceChange", req.TypeName, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s requestIDProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	ctx = client.WithFailedRequests(ctx)
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		addRequestIDsToDiagnostics(ctx, "ApplyResourceChange", req.TypeName, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s requestIDProviderServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx = client.WithFailedRequests(ctx)
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		addRequestIDsToDiagnostics(ctx, "ImportResourceState", req.TypeName, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s requestIDProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx = client.WithFailedRequests(ctx)
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		addRequestIDsToDiagnostics(ctx, "ReadDataSource", req.TypeName, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+// addRequestIDsToDiagnostics adds the GitLab request IDs of the failed requests recorded in the context
+// to the detail of the error diagnostics which report them, and logs them with the resource or data source type,
+// because Terraform reports the address of the resource with the diagnostics, but doesn't send it to the provider.
+func addRequestIDsToDiagnostics(ctx context.Context, rpc string, typeName string, diagnostics []*tfprotov6.Diagnostic) {
+	failedRequests := client.FailedRequests(ctx)
+	if len(failedRequests) == 0 {
+		return
+	}
+
+	for _, d := range diagnostics {
+		if d == nil || d.Severity != tfprotov6.DiagnosticSeverityError {
+			continue
+		}
+		requestIDs := reportedRequestIDs(failedRequests, d)
+		if len(requestIDs) == 0 {
+			continue
+		}
+
+		note := fmt.Sprintf("GitLab request ID of the failed API request: %s", requestIDs[0])
+		if len(requestIDs) > 1 {
+			note = fmt.Sprintf("GitLab request IDs of the failed API requests: %s", strings.Join(requestIDs, ", "))
+		}
+		if d.Detail == "" {
+			d.Detail = note
+		} else {
+			d.Detail = d.Detail + "\n\n" + note
+		}
+
+		for _, requestID := range requestIDs {
+			tflog.Info(ctx, "GitLab API request failed", map[string]interface{}{
+				"tf_rpc":            rpc,
+				"tf_resource_type":  typeName,
+				"gitlab_request_id": requestID,
+				"diagnostic":        d.Summary,
+			})
+		}
+	}
+}
+
+// reportedRequestIDs returns the GitLab request IDs of the failed requests whose error is reported by the diagnostic.
+// The failed requests which have been handled by the provider, like a `404 Not Found` of a resource which is gone,
+// are not reported by any diagnostic.
+func reportedRequestIDs(failedRequests []client.FailedRequest, d *tfprotov6.Diagnostic) []string {
+	var requestIDs []string
+	reported := make(map[string]bool)
+	for _, failed := range failedRequests {
+		if failed.ID == "" || failed.Err == nil {
+			continue
+		}
+		if !strings.Contains(d.Summary, failed.Err.Error()) && !strings.Contains(d.Detail, failed.Err.Error()) {
+			continue
+		}
+		if !reported[failed.ID] {
+			reported[failed.ID] = true
+			requestIDs = append(requestIDs, failed.ID)
+		}
+	}
+	return requestIDs
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
+)
+
+// failingProviderServer is a provider server which sends the requests of its data sources to GitLab
+// and returns an error diagnostic for every failed request which isn't ignored.
+type failingProviderServer struct {
+	tfprotov6.ProviderServer
+	client *gitlab.Client
+}
+
+func (s failingProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	resp := &tfprotov6.ReadDataSourceResponse{}
+	for _, project := range strings.Split(req.TypeName, ",") {
+		_, _, err := s.client.Projects.GetProject(strings.TrimPrefix(project, "ignored:"), nil, gitlab.WithContext(ctx))
+		if err != nil && !strings.HasPrefix(project, "ignored:") {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{Severity: tfprotov6.DiagnosticSeverityError, Summary: err.Error()})
+		}
+	}
+	resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{Severity: tfprotov6.DiagnosticSeverityError, Summary: "unrelated error"})
+	return resp, nil
+}
+
+func TestRequestIDProviderServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(client.RequestIDHeader, "request-"+strings.TrimPrefix(r.URL.Path, "/api/v4/projects/"))
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "404 Project Not Found"}`))
+	}))
+	defer server.Close()
+
+	config := &client.Config{Token: "token", BaseURL: server.URL}
+	gitlabClient, err := config.NewGitLabClient(context.Background())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	s := requestIDProviderServer{ProviderServer: failingProviderServer{client: gitlabClient}}
+
+	cases := []struct {
+		projects string
+		details  []string
+	}{
+		{projects: "1", details: []string{"GitLab request ID of the failed API request: request-1", ""}},
+		{projects: "ignored:1,2", details: []string{"GitLab request ID of the failed API request: request-2", ""}},
+		{projects: "ignored:3", details: []string{""}},
+	}
+
+	for _, c := range cases {
+		resp, err := s.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{TypeName: c.projects})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Diagnostics) != len(c.details) {
+			t.Fatalf("expected %d diagnostics for %s, got %d", len(c.details), c.projects, len(resp.Diagnostics))
+		}
+		for i, d := range resp.Diagnostics {
+			if d.Detail != c.details[i] {
+				t.Errorf("expected the detail %q for the diagnostic %q of %s, got %q", c.details[i], d.Summary, c.projects, d.Detail)
+			}
+		}
+	}
+}
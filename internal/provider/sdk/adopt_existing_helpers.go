@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+// adoptExistingSchema returns a resource schema with the attribute to adopt an existing object instead of failing to create it.
+// The resource must call `shouldAdoptExisting` when the create request fails with `isAlreadyExistsError`.
+func adoptExistingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"adopt_existing": {
+			Description: "Adopt the object into the state if it already exists, e.g. because it was created outside of Terraform, instead of failing to create it. The existing object is updated to match the configuration. Defaults to the `adopt_existing` provider attribute. Only applies when the resource is created.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+	}
+}
+
+// shouldAdoptExisting reports whether the resource adopts an existing object, as configured by the `adoptExistingSchema`
+// attribute of the resource, which overrides the `adopt_existing` provider attribute.
+func shouldAdoptExisting(d *schema.ResourceData, meta interface{}) bool {
+	// nolint:staticcheck // SA1019 ignore deprecated GetOkExists, `false` overrides the provider attribute
+	// lintignore: XR001 // TODO: replace with alternative for GetOkExists
+	if v, ok := d.GetOkExists("adopt_existing"); ok {
+		return v.(bool)
+	}
+	return meta.(*providerMeta).adoptExisting
+}
+
+// isAlreadyExistsError reports whether GitLab rejected to create an object, because it already exists.
+// Depending on the endpoint, GitLab responds with a conflict or with a validation error.
+func isAlreadyExistsError(err error) bool {
+	var errResponse *gitlab.ErrorResponse
+	if !errors.As(err, &errResponse) || errResponse.Response == nil {
+		return false
+	}
+	switch errResponse.Response.StatusCode {
+	case http.StatusConflict:
+		return true
+	case http.StatusBadRequest:
+		message := strings.ToLower(errResponse.Message)
+		return strings.Contains(message, "already exists") || strings.Contains(message, "has already been taken")
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package sdk
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAdoptExisting(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client}
+	group, _, err := fake.Client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("acme"), Path: gitlab.String("acme")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("api"), NamespaceID: gitlab.Int(group.ID), InitializeWithReadme: gitlab.Bool(true)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	user, _, err := fake.Client.Users.CreateUser(&gitlab.CreateUserOptions{Name: gitlab.String("Jane"), Username: gitlab.String("jane"), Email: gitlab.String("jane@example.com"), Password: gitlab.String("password")})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	userID := strconv.Itoa(user.ID)
+
+	for name, tc := range map[string]struct {
+		resource string
+		config   map[string]interface{}
+		existing func() error
+		expected map[string]interface{}
+	}{
+		"label": {
+			resource: "gitlab_label",
+			config:   map[string]interface{}{"project": "acme/api", "name": "bug", "color": "#00ff00", "description": "Something is broken"},
+			existing: func() error {
+				_, _, err := fake.Client.Labels.CreateLabel(project.ID, &gitlab.CreateLabelOptions{Name: gitlab.String("bug"), Color: gitlab.String("#ff0000")})
+				return err
+			},
+			expected: map[string]interface{}{"description": "Something is broken"},
+		},
+		"group label": {
+			resource: "gitlab_group_label",
+			config:   map[string]interface{}{"group": "acme", "name": "bug", "color": "#00ff00"},
+			existing: func() error {
+				_, _, err := fake.Client.GroupLabels.CreateGroupLabel(group.ID, &gitlab.CreateGroupLabelOptions{Name: gitlab.String("bug"), Color: gitlab.String("#ff0000")})
+				return err
+			},
+			expected: map[string]interface{}{"color": "#00ff00"},
+		},
+		"project variable": {
+			resource: "gitlab_project_variable",
+			config:   map[string]interface{}{"project": "acme/api", "key": "FOO", "value": "new"},
+			existing: func() error {
+				_, _, err := fake.Client.ProjectVariables.CreateVariable(project.ID, &gitlab.CreateProjectVariableOptions{Key: gitlab.String("FOO"), Value: gitlab.String("old")})
+				return err
+			},
+			expected: map[string]interface{}{"value": "new"},
+		},
+		"group variable": {
+			resource: "gitlab_group_variable",
+			config:   map[string]interface{}{"group": "acme", "key": "FOO", "value": "new"},
+			existing: func() error {
+				_, _, err := fake.Client.GroupVariables.CreateVariable(group.ID, &gitlab.CreateGroupVariableOptions{Key: gitlab.String("FOO"), Value: gitlab.String("old")})
+				return err
+			},
+			expected: map[string]interface{}{"value": "new"},
+		},
+		"branch": {
+			resource: "gitlab_branch",
+			config:   map[string]interface{}{"project": "acme/api", "name": "develop", "ref": "main"},
+			existing: func() error {
+				_, _, err := fake.Client.Branches.CreateBranch(project.ID, &gitlab.CreateBranchOptions{Branch: gitlab.String("develop"), Ref: gitlab.String("main")})
+				return err
+			},
+			expected: map[string]interface{}{"name": "develop"},
+		},
+		"project membership": {
+			resource: "gitlab_project_membership",
+			config:   map[string]interface{}{"project_id": "acme/api", "user_id": user.ID, "access_level": "maintainer"},
+			existing: func() error {
+				_, _, err := fake.Client.ProjectMembers.AddProjectMember(project.ID, &gitlab.AddProjectMemberOptions{UserID: userID, AccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions)})
+				return err
+			},
+			expected: map[string]interface{}{"access_level": "maintainer"},
+		},
+		"group membership": {
+			resource: "gitlab_group_membership",
+			config:   map[string]interface{}{"group_id": "acme", "user_id": user.ID, "access_level": "maintainer"},
+			existing: func() error {
+				_, _, err := fake.Client.GroupMembers.AddGroupMember(group.ID, &gitlab.AddGroupMemberOptions{UserID: gitlab.Int(user.ID), AccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions)})
+				return err
+			},
+			expected: map[string]interface{}{"access_level": "maintainer"},
+		},
+		"project hook": {
+			resource: "gitlab_project_hook",
+			config:   map[string]interface{}{"project": "acme/api", "url": "https://example.com/hook", "push_events": false, "tag_push_events": true},
+			existing: func() error {
+				_, _, err := fake.Client.Projects.AddProjectHook(project.ID, &gitlab.AddProjectHookOptions{URL: gitlab.String("https://example.com/hook"), PushEvents: gitlab.Bool(true)})
+				return err
+			},
+			expected: map[string]interface{}{"push_events": false, "tag_push_events": true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.existing(); err != nil {
+				t.Fatalf("failed to create the existing object: %v", err)
+			}
+			r := New("unittest")().ResourcesMap[tc.resource]
+
+			d := schema.TestResourceDataRaw(t, r.Schema, tc.config)
+			diags := r.CreateContext(context.Background(), d, meta)
+			if tc.resource != "gitlab_branch" && tc.resource != "gitlab_project_hook" && !diags.HasError() {
+				t.Fatalf("expected the create to fail without adopt_existing")
+			}
+
+			config := map[string]interface{}{"adopt_existing": true}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			d = schema.TestResourceDataRaw(t, r.Schema, config)
+			if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("failed to adopt the existing object: %v", diags)
+			}
+			if d.Id() == "" {
+				t.Fatalf("expected the existing object to be adopted")
+			}
+			for k, v := range tc.expected {
+				if actual := d.Get(k); actual != v {
+					t.Errorf("expected %s to be updated to %v, got %v", k, v, actual)
+				}
+			}
+
+			// the provider attribute is the default of the resource
+			d = schema.TestResourceDataRaw(t, r.Schema, tc.config)
+			if diags := r.CreateContext(context.Background(), d, &providerMeta{client: fake.Client, adoptExisting: true}); diags.HasError() || d.Id() == "" {
+				t.Fatalf("failed to adopt the existing object by default: %v", diags)
+			}
+		})
+	}
+}
+
+func TestIsAlreadyExistsError(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("api")})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	options := &gitlab.CreateProjectVariableOptions{Key: gitlab.String("FOO"), Value: gitlab.String("bar")}
+	if _, _, err := fake.Client.ProjectVariables.CreateVariable(project.ID, options); err != nil {
+		t.Fatalf("failed to create variable: %v", err)
+	}
+	_, _, err = fake.Client.ProjectVariables.CreateVariable(project.ID, options)
+	if !isAlreadyExistsError(err) {
+		t.Errorf("expected a duplicate variable to be an already exists error: %v", err)
+	}
+
+	_, _, err = fake.Client.Labels.CreateLabel(project.ID, &gitlab.CreateLabelOptions{Name: gitlab.String("bug")})
+	if err == nil || isAlreadyExistsError(err) {
+		t.Errorf("expected an invalid label not to be an already exists error: %v", err)
+	}
+	_, _, err = fake.Client.Projects.GetProject("unknown", nil)
+	if err == nil || isAlreadyExistsError(err) {
+		t.Errorf("expected a missing project not to be an already exists error: %v", err)
+	}
+}
+
+func TestAdoptExisting_branchRef(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client, adoptExisting: true}
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("api"), InitializeWithReadme: gitlab.Bool(true)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if _, _, err := fake.Client.Branches.CreateBranch(project.ID, &gitlab.CreateBranchOptions{Branch: gitlab.String("develop"), Ref: gitlab.String("main")}); err != nil {
+		t.Fatalf("failed to create branch: %v", err)
+	}
+	r := New("unittest")().ResourcesMap["gitlab_branch"]
+	config := map[string]interface{}{"project": strconv.Itoa(project.ID), "name": "develop", "ref": "main"}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, meta); len(diags) > 0 {
+		t.Fatalf("expected the branch to be adopted without diagnostics, got %v", diags)
+	}
+
+	// the branch which is adopted no longer points to the ref
+	if _, _, err := fake.Client.Commits.CreateCommit(project.ID, &gitlab.CreateCommitOptions{
+		Branch:        gitlab.String("main"),
+		CommitMessage: gitlab.String("Update README"),
+		Actions: []*gitlab.CommitActionOptions{{
+			Action:   gitlab.FileAction(gitlab.FileUpdate),
+			FilePath: gitlab.String("README.md"),
+			Content:  gitlab.String("updated"),
+		}},
+	}); err != nil {
+		t.Fatalf("failed to create commit: %v", err)
+	}
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := r.CreateContext(context.Background(), d, meta)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning for the adopted branch which doesn't point to the ref, got %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("expected the branch to be adopted")
+	}
+}
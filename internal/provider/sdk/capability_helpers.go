@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
+)
+
+// withCapabilityChecks adds a `CustomizeDiff` to the resource which checks at plan time that the GitLab instance
+// supports the attributes registered in `client.AttributeCapabilities` for the resource type.
+func withCapabilityChecks(resourceType string, r *schema.Resource) *schema.Resource {
+	// data sources share the type of the resources, but can't customize a diff
+	if r.CreateContext == nil && r.CreateWithoutTimeout == nil {
+		return r
+	}
+	if len(client.AttributeCapabilities(resourceType)) == 0 {
+		return r
+	}
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = capabilitiesDiff(resourceType)
+	} else {
+		r.CustomizeDiff = customdiff.All(r.CustomizeDiff, capabilitiesDiff(resourceType))
+	}
+	return r
+}
+
+// capabilitiesDiff returns an error for the attributes of the resource which are configured with a non-zero value,
+// but which the GitLab instance doesn't support. Only new resources and changed attributes are checked,
+// the instance version is looked up once per provider.
+func capabilitiesDiff(resourceType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		m, ok := meta.(*providerMeta)
+		if !ok || m == nil {
+			return nil
+		}
+		gitlabClient := m.client
+		config := d.GetRawConfig()
+		if !config.IsKnown() || config.IsNull() {
+			return nil
+		}
+
+		var attributes []string
+		for attribute := range client.AttributeCapabilities(resourceType) {
+			attributes = append(attributes, attribute)
+		}
+		sort.Strings(attributes)
+
+		var messages []string
+		for _, attribute := range attributes {
+			if !config.Type().HasAttribute(attribute) || !isConfiguredNonZero(config.GetAttr(attribute)) {
+				continue
+			}
+			if d.Id() != "" && !d.HasChange(attribute) {
+				continue
+			}
+			if err := client.CheckAttributeCapability(ctx, gitlabClient, resourceType, attribute); err != nil {
+				messages = append(messages, fmt.Sprintf("%q %s", attribute, err))
+			}
+		}
+		if len(messages) > 0 {
+			return fmt.Errorf("the GitLab instance doesn't support the configured attributes: %s", strings.Join(messages, "; "))
+		}
+		return nil
+	}
+}
+
+// isConfiguredNonZero reports whether the configured value is set to something else than the zero value of its type,
+// which is usually the default of GitLab and accepted by every instance, like `false`.
+func isConfiguredNonZero(value cty.Value) bool {
+	if value.IsNull() {
+		return false
+	}
+	if !value.IsKnown() {
+		return true
+	}
+
+	switch {
+	case value.Type() == cty.Bool:
+		return value.True()
+	case value.Type() == cty.String:
+		return value.AsString() != ""
+	case value.Type() == cty.Number:
+		return !value.RawEquals(cty.Zero)
+	case value.CanIterateElements():
+		return value.LengthInt() > 0
+	}
+	return true
+}
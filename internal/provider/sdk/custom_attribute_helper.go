@@ -29,7 +29,7 @@ func CreateCustomAttributeResource(idName string, createGetter CreateGetter, cre
 	}
 
 	readFunc := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-		client := meta.(*gitlab.Client)
+		client := meta.(*providerMeta).client
 		getter := createGetter(client)
 		log.Printf("[DEBUG] read Custom Attribute %s", d.Id())
 
@@ -48,7 +48,7 @@ func CreateCustomAttributeResource(idName string, createGetter CreateGetter, cre
 	}
 
 	setFunc := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-		client := meta.(*gitlab.Client)
+		client := meta.(*providerMeta).client
 		setter := createSetter(client)
 
 		id := d.Get(idName).(int)
@@ -69,7 +69,7 @@ func CreateCustomAttributeResource(idName string, createGetter CreateGetter, cre
 	}
 
 	deleteFunc := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-		client := meta.(*gitlab.Client)
+		client := meta.(*providerMeta).client
 		deleter := createDeleter(client)
 		log.Printf("[DEBUG] delete Custom Attribute %s", d.Id())
 
@@ -25,6 +25,7 @@ var _ = registerDataSource("gitlab_cluster_agents", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"cluster_agents": {
 				Description: "List of the registered agents.",
 				Type:        schema.TypeList,
@@ -38,23 +39,16 @@ var _ = registerDataSource("gitlab_cluster_agents", func() *schema.Resource {
 })
 
 func dataSourceGitlabClusterAgentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
-	options := gitlab.ListAgentsOptions{
-		PerPage: 20,
-		Page:    1,
-	}
-
-	var clusterAgents []*gitlab.Agent
-	for options.Page != 0 {
-		paginatedClusterAgents, resp, err := client.ClusterAgents.ListAgents(project, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
-		clusterAgents = append(clusterAgents, paginatedClusterAgents...)
-		options.Page = resp.NextPage
+	clusterAgents, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Agent, *gitlab.Response, error) {
+		options := gitlab.ListAgentsOptions(listOptions)
+		return client.ClusterAgents.ListAgents(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] list GitLab Agents for Kubernetes in project %s", project)
@@ -7,7 +7,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
 )
 
 var _ = registerDataSource("gitlab_current_user", func() *schema.Resource {
@@ -68,46 +69,45 @@ var _ = registerDataSource("gitlab_current_user", func() *schema.Resource {
 })
 
 func dataSourceGitlabCurrentUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	gitlabClient := meta.(*providerMeta).client
 
-	query := GraphQLQuery{
-		`query {currentUser {name, bot, groupCount, id, namespace{id}, publicEmail, username}}`,
+	request := client.GraphQLRequest{
+		OperationName: "currentUser",
+		Query:         `query currentUser {currentUser {name, bot, groupCount, id, namespace{id}, publicEmail, username}}`,
 	}
-	log.Printf("[DEBUG] executing GraphQL Query %s to retrieve current user", query.Query)
+	log.Printf("[DEBUG] executing GraphQL Query %s to retrieve current user", request.Query)
 
 	var response CurrentUserResponse
-	if _, err := SendGraphQLRequest(ctx, client, query, &response); err != nil {
-		return diag.FromErr(err)
+	if err := client.SendGraphQLRequest(ctx, gitlabClient, request, &response); err != nil {
+		return graphQLDiagnostics(err)
 	}
 
-	userID, err := extractIIDFromGlobalID(response.Data.CurrentUser.ID)
+	userID, err := extractIIDFromGlobalID(response.CurrentUser.ID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	namespaceID, err := extractIIDFromGlobalID(response.Data.CurrentUser.Namespace.ID)
+	namespaceID, err := extractIIDFromGlobalID(response.CurrentUser.Namespace.ID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%d", userID))
-	d.Set("global_id", response.Data.CurrentUser.ID)
-	d.Set("username", response.Data.CurrentUser.Username)
-	d.Set("name", response.Data.CurrentUser.Name)
-	d.Set("bot", response.Data.CurrentUser.Bot)
-	d.Set("group_count", response.Data.CurrentUser.GroupCount)
+	d.Set("global_id", response.CurrentUser.ID)
+	d.Set("username", response.CurrentUser.Username)
+	d.Set("name", response.CurrentUser.Name)
+	d.Set("bot", response.CurrentUser.Bot)
+	d.Set("group_count", response.CurrentUser.GroupCount)
 	d.Set("namespace_id", fmt.Sprintf("%d", namespaceID))
-	d.Set("global_namespace_id", response.Data.CurrentUser.Namespace.ID)
-	d.Set("public_email", response.Data.CurrentUser.PublicEmail)
+	d.Set("global_namespace_id", response.CurrentUser.Namespace.ID)
+	d.Set("public_email", response.CurrentUser.PublicEmail)
 
 	return nil
 }
 
 // Struct representing current user based on the input API token
 type CurrentUserResponse struct {
-	Data struct {
-		CurrentUser GraphQLUser `json:"currentUser"`
-	} `json:"data"`
+	CurrentUser GraphQLUser `json:"currentUser"`
 }
 
 type GraphQLUser struct {
@@ -21,7 +21,7 @@ var _ = registerDataSource("gitlab_group_hook", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupHookRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 	hookID := d.Get("hook_id").(int)
 
@@ -21,6 +21,7 @@ var _ = registerDataSource("gitlab_group_hooks", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"hooks": {
 				Description: "The list of hooks.",
 				Type:        schema.TypeList,
@@ -34,23 +35,16 @@ var _ = registerDataSource("gitlab_group_hooks", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupHooksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group := d.Get("group").(string)
-	options := gitlab.ListGroupHooksOptions{
-		PerPage: 20,
-		Page:    1,
-	}
-
-	var hooks []*gitlab.GroupHook
-	for options.Page != 0 {
-		paginatedHooks, resp, err := client.Groups.ListGroupHooks(group, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
-		hooks = append(hooks, paginatedHooks...)
-		options.Page = resp.NextPage
+	hooks, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.GroupHook, *gitlab.Response, error) {
+		options := gitlab.ListGroupHooksOptions(listOptions)
+		return client.Groups.ListGroupHooks(group, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(group)
@@ -42,6 +42,7 @@ var _ = registerDataSource("gitlab_group_membership", func() *schema.Resource {
 				Optional:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validGroupAccessLevelNames, false)),
 			},
+			"max_results": maxResultsSchema(),
 			"members": {
 				Description: "The list of group members.",
 				Type:        schema.TypeList,
@@ -96,7 +97,7 @@ var _ = registerDataSource("gitlab_group_membership", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var group *gitlab.Group
 	var err error
@@ -125,26 +126,11 @@ func dataSourceGitlabGroupMembershipRead(ctx context.Context, d *schema.Resource
 	log.Printf("[INFO] Reading Gitlab group memberships")
 
 	// Get group memberships
-	listOptions := &gitlab.ListGroupMembersOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
-	}
-
-	var allGms []*gitlab.GroupMember
-	for {
-		gms, resp, err := client.Groups.ListGroupMembers(group.ID, listOptions, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		allGms = append(allGms, gms...)
-
-		if resp.NextPage == 0 {
-			break
-		}
-		listOptions.Page = resp.NextPage
+	allGms, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.GroupMember, *gitlab.Response, error) {
+		return client.Groups.ListGroupMembers(group.ID, &gitlab.ListGroupMembersOptions{ListOptions: listOptions}, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.Set("group_id", group.ID)
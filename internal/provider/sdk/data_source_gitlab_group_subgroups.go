@@ -78,6 +78,7 @@ var _ = registerDataSource("gitlab_group_subgroups", func() *schema.Resource {
 				Computed:    true,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"subgroups": {
 				Description: "Subgroups of the parent group.",
 				Type:        schema.TypeList,
@@ -91,7 +92,7 @@ var _ = registerDataSource("gitlab_group_subgroups", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupSubgroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var subgroups []*gitlab.Group
 	var err error
@@ -102,7 +103,9 @@ func dataSourceGitlabGroupSubgroupsRead(ctx context.Context, d *schema.ResourceD
 
 	if groupIDOk {
 		// Get group subgroups by id
-		subgroups, _, err = client.Groups.ListSubGroups(groupIDData.(int), nil, gitlab.WithContext(ctx))
+		subgroups, err = paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Group, *gitlab.Response, error) {
+			return client.Groups.ListSubGroups(groupIDData.(int), &gitlab.ListSubGroupsOptions{ListOptions: listOptions}, requestOptions...)
+		})
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -29,6 +29,7 @@ var _ = registerDataSource("gitlab_group_variables", func() *schema.Resource {
 				Optional:    true,
 				Default:     "*",
 			},
+			"max_results": maxResultsSchema(),
 			"variables": {
 				Description: "The list of variables returned by the search",
 				Type:        schema.TypeList,
@@ -42,24 +43,16 @@ var _ = registerDataSource("gitlab_group_variables", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 	environmentScope := d.Get("environment_scope").(string)
 
-	options := &gitlab.ListGroupVariablesOptions{
-		Page:    1,
-		PerPage: 20,
-	}
-
-	var variables []*gitlab.GroupVariable
-	for options.Page != 0 {
-		paginatedVariables, resp, err := client.GroupVariables.ListVariables(group, options, gitlab.WithContext(ctx), withEnvironmentScopeFilter(ctx, environmentScope))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		variables = append(variables, paginatedVariables...)
-		options.Page = resp.NextPage
+	variables, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.GroupVariable, *gitlab.Response, error) {
+		options := gitlab.ListGroupVariablesOptions(listOptions)
+		return client.GroupVariables.ListVariables(group, &options, append(requestOptions, withEnvironmentScopeFilter(ctx, environmentScope))...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s:%s", group, environmentScope))
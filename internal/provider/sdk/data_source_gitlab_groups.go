@@ -44,6 +44,7 @@ var _ = registerDataSource("gitlab_groups", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"groups": {
 				Description: "The list of groups.",
 				Type:        schema.TypeList,
@@ -131,24 +132,19 @@ var _ = registerDataSource("gitlab_groups", func() *schema.Resource {
 })
 
 func dataSourceGitlabGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	listGroupsOptions, id, err := expandGitlabGroupsOptions(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	page := 1
-	groupslen := 0
-	var groups []*gitlab.Group
-	for page == 1 || groupslen != 0 {
-		listGroupsOptions.Page = page
-		paginatedGroups, _, err := client.Groups.ListGroups(listGroupsOptions, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		groups = append(groups, paginatedGroups...)
-		groupslen = len(paginatedGroups)
-		page = page + 1
+	groups, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Group, *gitlab.Response, error) {
+		options := *listGroupsOptions
+		options.ListOptions = listOptions
+		return client.Groups.ListGroups(&options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	err = d.Set("groups", flattenGitlabGroups(groups))
@@ -26,6 +26,7 @@ var _ = registerDataSource("gitlab_instance_deploy_keys", func() *schema.Resourc
 				Type:        schema.TypeBool,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"deploy_keys": {
 				Description: "The list of all deploy keys across all projects of the GitLab instance.",
 				Type:        schema.TypeList,
@@ -109,28 +110,22 @@ var _ = registerDataSource("gitlab_instance_deploy_keys", func() *schema.Resourc
 })
 
 func dataSourceGitlabInstanceDeployKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	// Get group memberships
 	options := &gitlab.ListInstanceDeployKeysOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
 		Public: gitlab.Bool(d.Get("public").(bool)),
 	}
 
 	log.Printf("[INFO] Reading Instance Deploy Keys, with: %v", options)
 
-	var instanceDeployKeys []*gitlab.InstanceDeployKey
-	for options.Page != 0 {
-		paginatedInstancedeployKeys, resp, err := client.DeployKeys.ListAllDeployKeys(options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		instanceDeployKeys = append(instanceDeployKeys, paginatedInstancedeployKeys...)
-
-		options.Page = resp.NextPage
+	instanceDeployKeys, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.InstanceDeployKey, *gitlab.Response, error) {
+		options := *options
+		options.ListOptions = listOptions
+		return client.DeployKeys.ListAllDeployKeys(&options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	// NOTE: this data source doesn't have a "real" id, but the query to the API
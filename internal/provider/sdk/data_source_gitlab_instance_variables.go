@@ -16,6 +16,7 @@ var _ = registerDataSource("gitlab_instance_variables", func() *schema.Resource
 
 		ReadContext: dataSourceGitlabInstanceVariablesRead,
 		Schema: map[string]*schema.Schema{
+			"max_results": maxResultsSchema(),
 			"variables": {
 				Description: "The list of variables returned by the search",
 				Type:        schema.TypeList,
@@ -29,22 +30,14 @@ var _ = registerDataSource("gitlab_instance_variables", func() *schema.Resource
 })
 
 func dataSourceGitlabInstanceVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
-
-	options := &gitlab.ListInstanceVariablesOptions{
-		Page:    1,
-		PerPage: 20,
-	}
-
-	var variables []*gitlab.InstanceVariable
-	for options.Page != 0 {
-		paginatedVariables, resp, err := client.InstanceVariables.ListVariables(options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		variables = append(variables, paginatedVariables...)
-		options.Page = resp.NextPage
+	client := meta.(*providerMeta).client
+
+	variables, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.InstanceVariable, *gitlab.Response, error) {
+		options := gitlab.ListInstanceVariablesOptions(listOptions)
+		return client.InstanceVariables.ListVariables(&options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId("instance_variables")
@@ -26,6 +26,7 @@ var _ = registerDataSource("gitlab_project_branches", func() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
+			"max_results": maxResultsSchema(),
 			"branches": {
 				Description: "The list of branches of the project, as defined below.",
 				Type:        schema.TypeList,
@@ -87,31 +88,25 @@ var _ = registerDataSource("gitlab_project_branches", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectBranchesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	log.Printf("[INFO] Reading Gitlab branches")
 
 	project := d.Get("project").(string)
 
-	options := &gitlab.ListBranchesOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
-	}
+	options := &gitlab.ListBranchesOptions{}
 	h, err := hashstructure.Hash(*options, hashstructure.FormatV1, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var allBranches []*gitlab.Branch
-	for options.Page != 0 {
-		branches, resp, err := client.Branches.ListBranches(project, options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		allBranches = append(allBranches, branches...)
-		options.Page = resp.NextPage
+	allBranches, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Branch, *gitlab.Response, error) {
+		options := *options
+		options.ListOptions = listOptions
+		return client.Branches.ListBranches(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s:%d", project, h))
@@ -21,6 +21,7 @@ var _ = registerDataSource("gitlab_project_hooks", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"hooks": {
 				Description: "The list of hooks.",
 				Type:        schema.TypeList,
@@ -34,23 +35,16 @@ var _ = registerDataSource("gitlab_project_hooks", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectHooksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
-	options := gitlab.ListProjectHooksOptions{
-		PerPage: 20,
-		Page:    1,
-	}
-
-	var hooks []*gitlab.ProjectHook
-	for options.Page != 0 {
-		paginatedHooks, resp, err := client.Projects.ListProjectHooks(project, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
 
-		hooks = append(hooks, paginatedHooks...)
-		options.Page = resp.NextPage
+	hooks, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectHook, *gitlab.Response, error) {
+		options := gitlab.ListProjectHooksOptions(listOptions)
+		return client.Projects.ListProjectHooks(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(project)
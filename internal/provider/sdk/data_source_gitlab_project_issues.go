@@ -200,6 +200,7 @@ var _ = registerDataSource("gitlab_project_issues", func() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"issues": {
 				Description: "The list of issues returned by the search.",
 				Type:        schema.TypeList,
@@ -213,15 +214,10 @@ var _ = registerDataSource("gitlab_project_issues", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectIssuesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
-	options := gitlab.ListProjectIssuesOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
-	}
+	options := gitlab.ListProjectIssuesOptions{}
 
 	if v, ok := d.GetOk("iids"); ok {
 		options.IIDs = intSetToIntSlice(v.(*schema.Set))
@@ -345,15 +341,13 @@ func dataSourceGitlabProjectIssuesRead(ctx context.Context, d *schema.ResourceDa
 		options.IssueType = gitlab.String(v.(string))
 	}
 
-	var issues []*gitlab.Issue
-	for options.Page != 0 {
-		paginatedIssues, resp, err := client.Issues.ListProjectIssues(project, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		issues = append(issues, paginatedIssues...)
-		options.Page = resp.NextPage
+	issues, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Issue, *gitlab.Response, error) {
+		options := options
+		options.ListOptions = listOptions
+		return client.Issues.ListProjectIssues(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	optionsHash, err := hashstructure.Hash(&options, hashstructure.FormatV1, nil)
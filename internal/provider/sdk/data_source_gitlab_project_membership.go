@@ -45,6 +45,7 @@ var _ = registerDataSource("gitlab_project_membership", func() *schema.Resource
 				Type:        schema.TypeBool,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"members": {
 				Description: "The list of project members.",
 				Type:        schema.TypeList,
@@ -99,7 +100,7 @@ var _ = registerDataSource("gitlab_project_membership", func() *schema.Resource
 })
 
 func dataSourceGitlabProjectMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var project *gitlab.Project
 	var err error
@@ -130,28 +131,16 @@ func dataSourceGitlabProjectMembershipRead(ctx context.Context, d *schema.Resour
 	log.Printf("[INFO] Reading Gitlab project memberships")
 
 	// Get project memberships
-	listOptions := &gitlab.ListProjectMembersOptions{
-		Query: query,
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
-	}
-
 	listMembers := client.ProjectMembers.ListProjectMembers
 	if inherited, ok := d.GetOk("inherited"); ok && inherited.(bool) {
 		listMembers = client.ProjectMembers.ListAllProjectMembers
 	}
 
-	var allPMs []*gitlab.ProjectMember
-	for listOptions.Page != 0 {
-		pms, resp, err := listMembers(project.ID, listOptions, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		allPMs = append(allPMs, pms...)
-		listOptions.Page = resp.NextPage
+	allPMs, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectMember, *gitlab.Response, error) {
+		return listMembers(project.ID, &gitlab.ListProjectMembersOptions{Query: query, ListOptions: listOptions}, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	var optionsHash strings.Builder
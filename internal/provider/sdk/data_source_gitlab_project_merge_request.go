@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+var _ = registerDataSource("gitlab_project_merge_request", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_merge_request`" + ` data source allows to retrieve details about a merge request in a project.
+
+**Upstream API**: [GitLab API docs](https://docs.gitlab.com/ee/api/merge_requests.html)`,
+
+		ReadContext: dataSourceGitlabProjectMergeRequestRead,
+		Schema:      datasourceSchemaFromResourceSchema(gitlabProjectMergeRequestGetSchema(), []string{"project", "iid"}, nil),
+	}
+})
+
+func dataSourceGitlabProjectMergeRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+	mergeRequestIID := d.Get("iid").(int)
+
+	mergeRequest, _, err := client.MergeRequests.GetMergeRequest(project, mergeRequestIID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(resourceGitLabProjectMergeRequestBuildId(project, mergeRequestIID))
+	stateMap := gitlabProjectMergeRequestToStateMap(project, mergeRequest)
+
+	if err := setStateMapInResourceData(stateMap, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccDataSourceGitlabProjectMergeRequest_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	testBranch := testutil.CreateBranches(t, testProject, 1)[0]
+	testutil.CreateProjectFile(t, testProject.ID, "bWVvdyBtZW93IG1lb3c=", "meow.txt", testBranch.Name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_project_merge_request" "this" {
+				  project       = %d
+				  source_branch = "%s"
+				  target_branch = "%s"
+				  title         = "feature: cats"
+				  description   = "meow meow meow"
+				  labels        = ["cats"]
+				}
+
+				data "gitlab_project_merge_request" "this" {
+				  project = gitlab_project_merge_request.this.project
+				  iid     = gitlab_project_merge_request.this.iid
+				}
+				`, testProject.ID, testBranch.Name, testProject.DefaultBranch),
+				Check: testAccDataSourceGitlabProjectMergeRequest("gitlab_project_merge_request.this", "data.gitlab_project_merge_request.this"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGitlabProjectMergeRequest(src, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceAttributes := s.RootModule().Resources[src].Primary.Attributes
+		datasourceAttributes := s.RootModule().Resources[n].Primary.Attributes
+
+		for _, attribute := range attributeNamesFromSchema(gitlabProjectMergeRequestGetSchema()) {
+			if datasourceAttributes[attribute] != resourceAttributes[attribute] {
+				return fmt.Errorf("Expected merge request's attribute `%s` to be: %s, but got: `%s`", attribute, resourceAttributes[attribute], datasourceAttributes[attribute])
+			}
+		}
+		return nil
+	}
+}
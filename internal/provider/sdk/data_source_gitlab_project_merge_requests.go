@@ -0,0 +1,180 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/xanzy/go-gitlab"
+)
+
+var _ = registerDataSource("gitlab_project_merge_requests", func() *schema.Resource {
+	validMergeRequestStateValues := []string{"opened", "closed", "locked", "merged", "all"}
+	validMergeRequestOrderByValues := []string{"created_at", "updated_at", "title"}
+	validMergeRequestSortValues := []string{"asc", "desc"}
+
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_merge_requests`" + ` data source allows to retrieve details about merge requests in a project.
+
+**Upstream API**: [GitLab API docs](https://docs.gitlab.com/ee/api/merge_requests.html#list-project-merge-requests)`,
+
+		ReadContext: dataSourceGitlabProjectMergeRequestsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The name or id of the project.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"state": {
+				Description:      fmt.Sprintf("Return merge requests with the given state. Valid values are %s. Defaults to all.", renderValueListForDocs(validMergeRequestStateValues)),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validMergeRequestStateValues, false)),
+			},
+			"labels": {
+				Description: "Return merge requests with labels. Merge requests must have all labels to be returned. None lists all merge requests with no labels. Any lists all merge requests with at least one label. Predefined names are case-insensitive.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+			},
+			"not_labels": {
+				Description: "Return merge requests that do not match the labels.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+			},
+			"author_id": {
+				Description: "Return merge requests created by the given user id. Mutually exclusive with author_username.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ConflictsWith: []string{
+					"author_username",
+				},
+			},
+			"author_username": {
+				Description: "Return merge requests created by the given username. Mutually exclusive with author_id.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ConflictsWith: []string{
+					"author_id",
+				},
+			},
+			"source_branch": {
+				Description: "Return merge requests with the given source branch.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"target_branch": {
+				Description: "Return merge requests with the given target branch.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"search": {
+				Description: "Search project merge requests against their title and description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"order_by": {
+				Description:      fmt.Sprintf("Return merge requests ordered by. Valid values are %s. Default is created_at", renderValueListForDocs(validMergeRequestOrderByValues)),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validMergeRequestOrderByValues, false)),
+			},
+			"sort": {
+				Description:      "Return merge requests sorted in asc or desc order. Default is desc",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validMergeRequestSortValues, false)),
+			},
+			"max_results": maxResultsSchema(),
+			"merge_requests": {
+				Description: "The list of merge requests returned by the search.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: datasourceSchemaFromResourceSchema(gitlabProjectMergeRequestGetSchema(), nil, nil),
+				},
+			},
+		},
+	}
+})
+
+func dataSourceGitlabProjectMergeRequestsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	options := gitlab.ListProjectMergeRequestsOptions{}
+
+	if v, ok := d.GetOk("state"); ok {
+		options.State = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("labels"); ok {
+		gitlabLabels := gitlab.Labels(*stringSetToStringSlice(v.(*schema.Set)))
+		options.Labels = &gitlabLabels
+	}
+
+	if v, ok := d.GetOk("not_labels"); ok {
+		gitlabLabels := gitlab.Labels(*stringSetToStringSlice(v.(*schema.Set)))
+		options.NotLabels = &gitlabLabels
+	}
+
+	if v, ok := d.GetOk("author_id"); ok {
+		options.AuthorID = gitlab.Int(v.(int))
+	}
+
+	if v, ok := d.GetOk("author_username"); ok {
+		options.AuthorUsername = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("source_branch"); ok {
+		options.SourceBranch = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("target_branch"); ok {
+		options.TargetBranch = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("search"); ok {
+		options.Search = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("order_by"); ok {
+		options.OrderBy = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("sort"); ok {
+		options.Sort = gitlab.String(v.(string))
+	}
+
+	mergeRequests, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.MergeRequest, *gitlab.Response, error) {
+		options := options
+		options.ListOptions = listOptions
+		return client.MergeRequests.ListProjectMergeRequests(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	optionsHash, err := hashstructure.Hash(&options, hashstructure.FormatV1, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d", project, optionsHash))
+	if err = d.Set("merge_requests", flattenGitlabProjectMergeRequests(mergeRequests)); err != nil {
+		return diag.Errorf("failed to set merge requests to state: %v", err)
+	}
+
+	return nil
+}
+
+func flattenGitlabProjectMergeRequests(mergeRequests []*gitlab.MergeRequest) (values []map[string]interface{}) {
+	for _, mergeRequest := range mergeRequests {
+		values = append(values, gitlabProjectMergeRequestToStateMap(fmt.Sprintf("%d", mergeRequest.ProjectID), mergeRequest))
+	}
+	return values
+}
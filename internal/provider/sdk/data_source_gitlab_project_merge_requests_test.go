@@ -0,0 +1,59 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccDataSourceGitlabProjectMergeRequests_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	for i, branch := range testutil.CreateBranches(t, testProject, 2) {
+		testutil.CreateProjectFile(t, testProject.ID, "bWVvdyBtZW93IG1lb3c=", "meow.txt", branch.Name)
+		if _, _, err := testutil.TestGitlabClient.MergeRequests.CreateMergeRequest(testProject.ID, &gitlab.CreateMergeRequestOptions{
+			Title:        gitlab.String(fmt.Sprintf("Merge request %d", i)),
+			SourceBranch: gitlab.String(branch.Name),
+			TargetBranch: gitlab.String(testProject.DefaultBranch),
+			Labels:       &gitlab.Labels{fmt.Sprintf("label-%d", i)},
+		}); err != nil {
+			t.Fatalf("failed to create merge request: %v", err)
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "gitlab_project_merge_requests" "all" {
+				  project = %d
+				  state   = "opened"
+
+				  // only for determinism
+				  order_by = "created_at"
+				  sort     = "asc"
+				}
+
+				data "gitlab_project_merge_requests" "label" {
+				  project = %d
+				  labels  = ["label-1"]
+				}
+				`, testProject.ID, testProject.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gitlab_project_merge_requests.all", "merge_requests.#", "2"),
+					resource.TestCheckResourceAttr("data.gitlab_project_merge_requests.all", "merge_requests.0.title", "Merge request 0"),
+					resource.TestCheckResourceAttr("data.gitlab_project_merge_requests.all", "merge_requests.1.title", "Merge request 1"),
+					resource.TestCheckResourceAttr("data.gitlab_project_merge_requests.label", "merge_requests.#", "1"),
+					resource.TestCheckResourceAttr("data.gitlab_project_merge_requests.label", "merge_requests.0.title", "Merge request 1"),
+				),
+			},
+		},
+	})
+}
@@ -55,6 +55,7 @@ var _ = registerDataSource("gitlab_project_milestones", func() *schema.Resource
 				Type:        schema.TypeBool,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"milestones": {
 				Description: "List of milestones from a project.",
 				Type:        schema.TypeList,
@@ -68,15 +69,10 @@ var _ = registerDataSource("gitlab_project_milestones", func() *schema.Resource
 })
 
 func dataSourceGitlabProjectMilestonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
-	options := gitlab.ListMilestonesOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
-	}
+	options := gitlab.ListMilestonesOptions{}
 
 	if v, ok := d.GetOk("iids"); ok {
 		options.IIDs = intSetToIntSlice(v.(*schema.Set))
@@ -103,15 +99,13 @@ func dataSourceGitlabProjectMilestonesRead(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	var milestones []*gitlab.Milestone
-	for options.Page != 0 {
-		paginatedMilestones, resp, err := client.Milestones.ListMilestones(project, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		milestones = append(milestones, paginatedMilestones...)
-		options.Page = resp.NextPage
+	milestones, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Milestone, *gitlab.Response, error) {
+		options := options
+		options.ListOptions = listOptions
+		return client.Milestones.ListMilestones(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] get gitlab milestones from project: %s", project)
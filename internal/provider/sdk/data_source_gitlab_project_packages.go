@@ -0,0 +1,222 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/xanzy/go-gitlab"
+)
+
+// listProjectPackagesOptions are the options to list the packages of a project with the version filter,
+// which the options of go-gitlab don't contain yet.
+type listProjectPackagesOptions struct {
+	gitlab.ListProjectPackagesOptions
+	PackageVersion *string `url:"package_version,omitempty" json:"package_version,omitempty"`
+}
+
+var _ = registerDataSource("gitlab_project_packages", func() *schema.Resource {
+	validPackageTypeValues := []string{"composer", "conan", "generic", "golang", "helm", "maven", "npm", "nuget", "pypi", "terraform_module"}
+	validPackageStatusValues := []string{"default", "hidden", "processing", "error", "pending_destruction"}
+	validPackageOrderByValues := []string{"created_at", "name", "version", "type"}
+	validPackageSortValues := []string{"asc", "desc"}
+
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_packages`" + ` data source allows to retrieve details about the packages of a project.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/packages.html#within-a-project)`,
+
+		ReadContext: dataSourceGitlabProjectPackagesRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The ID or full path of the project.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"package_type": {
+				Description:      fmt.Sprintf("Return packages of the given type. Valid values are %s.", renderValueListForDocs(validPackageTypeValues)),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validPackageTypeValues, false)),
+			},
+			"package_name": {
+				Description: "Return packages with a name which contains the given name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"package_version": {
+				Description: "Return packages with the given version.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description:      fmt.Sprintf("Return packages with the given status. Valid values are %s. By default, hidden packages aren't returned.", renderValueListForDocs(validPackageStatusValues)),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validPackageStatusValues, false)),
+			},
+			"order_by": {
+				Description:      fmt.Sprintf("Return packages ordered by. Valid values are %s. Default is created_at", renderValueListForDocs(validPackageOrderByValues)),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validPackageOrderByValues, false)),
+			},
+			"sort": {
+				Description:      "Return packages sorted in asc or desc order. Default is asc",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validPackageSortValues, false)),
+			},
+			"max_results": maxResultsSchema(),
+			"packages": {
+				Description: "The list of packages returned by the search.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"package_id": {
+							Description: "The ID of the package.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"version": {
+							Description: "The version of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"package_type": {
+							Description: "The type of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The status of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tags": {
+							Description: "The tags of the package.",
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+						},
+						"web_path": {
+							Description: "The path of the package in the UI.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "When the package was created. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+})
+
+func dataSourceGitlabProjectPackagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	options := listProjectPackagesOptions{}
+
+	if v, ok := d.GetOk("package_type"); ok {
+		options.PackageType = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("package_name"); ok {
+		options.PackageName = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("package_version"); ok {
+		options.PackageVersion = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("status"); ok {
+		options.Status = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("order_by"); ok {
+		options.OrderBy = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("sort"); ok {
+		options.Sort = gitlab.String(v.(string))
+	}
+
+	packages, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Package, *gitlab.Response, error) {
+		options := options
+		options.ListOptions = listOptions
+		return listGitlabProjectPackages(client, project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	optionsHash, err := hashstructure.Hash(&options, hashstructure.FormatV1, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d", project, optionsHash))
+	if err = d.Set("packages", flattenGitlabProjectPackages(packages, options.PackageVersion)); err != nil {
+		return diag.Errorf("failed to set packages to state: %v", err)
+	}
+
+	return nil
+}
+
+// listGitlabProjectPackages lists a page of the packages of a project, optionally filtered by their version.
+func listGitlabProjectPackages(client *gitlab.Client, project string, options *listProjectPackagesOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Package, *gitlab.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("projects/%s/packages", gitlab.PathEscape(project)), options, requestOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	var packages []*gitlab.Package
+	resp, err := client.Do(req, &packages)
+	if err != nil {
+		return nil, resp, err
+	}
+	return packages, resp, nil
+}
+
+// flattenGitlabProjectPackages returns the packages with the version, if it's given.
+// Older GitLab versions ignore the version filter, so the packages are filtered here as well.
+func flattenGitlabProjectPackages(packages []*gitlab.Package, version *string) (values []map[string]interface{}) {
+	for _, pkg := range packages {
+		if version != nil && pkg.Version != *version {
+			continue
+		}
+		value := map[string]interface{}{
+			"package_id":   pkg.ID,
+			"name":         pkg.Name,
+			"version":      pkg.Version,
+			"package_type": pkg.PackageType,
+			"status":       pkg.Status,
+			"tags":         pkg.Tags,
+			"web_path":     "",
+			"created_at":   nil,
+		}
+		if pkg.Links != nil {
+			value["web_path"] = pkg.Links.WebPath
+		}
+		if pkg.CreatedAt != nil {
+			value["created_at"] = pkg.CreatedAt.Format(time.RFC3339)
+		}
+		values = append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,68 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccDataSourceGitlabProjectPackages_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		if _, _, err := testutil.TestGitlabClient.GenericPackages.PublishPackageFile(testProject.ID, "api", version, "api.txt", strings.NewReader(version), nil); err != nil {
+			t.Fatalf("failed to publish package file: %v", err)
+		}
+	}
+	hidden := gitlab.PackageHidden
+	if _, _, err := testutil.TestGitlabClient.GenericPackages.PublishPackageFile(testProject.ID, "internal", "1.0.0", "internal.txt", strings.NewReader("internal"), &gitlab.PublishPackageFileOptions{Status: &hidden}); err != nil {
+		t.Fatalf("failed to publish package file: %v", err)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "gitlab_project_packages" "all" {
+				  project      = %d
+				  package_type = "generic"
+
+				  // only for determinism
+				  order_by = "version"
+				  sort     = "asc"
+				}
+
+				data "gitlab_project_packages" "version" {
+				  project         = %d
+				  package_name    = "api"
+				  package_version = "2.0.0"
+				}
+
+				data "gitlab_project_packages" "hidden" {
+				  project = %d
+				  status  = "hidden"
+				}
+				`, testProject.ID, testProject.ID, testProject.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.all", "packages.#", "2"),
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.all", "packages.0.version", "1.0.0"),
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.all", "packages.1.version", "2.0.0"),
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.all", "packages.0.package_type", "generic"),
+					resource.TestCheckResourceAttrSet("data.gitlab_project_packages.all", "packages.0.web_path"),
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.version", "packages.#", "1"),
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.version", "packages.0.version", "2.0.0"),
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.hidden", "packages.#", "1"),
+					resource.TestCheckResourceAttr("data.gitlab_project_packages.hidden", "packages.0.name", "internal"),
+				),
+			},
+		},
+	})
+}
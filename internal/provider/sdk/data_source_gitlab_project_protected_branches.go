@@ -24,6 +24,7 @@ var _ = registerDataSource("gitlab_project_protected_branches", func() *schema.R
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"protected_branches": {
 				Description: "A list of protected branches, as defined below.",
 				Type:        schema.TypeList,
@@ -60,7 +61,7 @@ var _ = registerDataSource("gitlab_project_protected_branches", func() *schema.R
 })
 
 func dataSourceGitlabProjectProtectedBranchesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	log.Printf("[INFO] Reading Gitlab protected branch")
 
@@ -71,24 +72,19 @@ func dataSourceGitlabProjectProtectedBranchesRead(ctx context.Context, d *schema
 		return diag.FromErr(err)
 	}
 
-	var allProtectedBranches []*gitlab.ProtectedBranch
-	totalPages := -1
-	opts := &gitlab.ListProtectedBranchesOptions{}
-	for opts.Page = 0; opts.Page != totalPages; opts.Page++ {
-		// Get protected branch by project ID/path and branch name
-		pbs, resp, err := client.ProtectedBranches.ListProtectedBranches(project, opts, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		totalPages = resp.TotalPages
-		allProtectedBranches = append(allProtectedBranches, pbs...)
+	allProtectedBranches, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProtectedBranch, *gitlab.Response, error) {
+		opts := gitlab.ListProtectedBranchesOptions(listOptions)
+		return client.ProtectedBranches.ListProtectedBranches(project, &opts, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("protected_branches", flattenProtectedBranches(allProtectedBranches)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	h, err := hashstructure.Hash(*opts, hashstructure.FormatV1, nil)
+	h, err := hashstructure.Hash(gitlab.ListProtectedBranchesOptions{}, hashstructure.FormatV1, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
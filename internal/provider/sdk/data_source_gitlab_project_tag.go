@@ -30,7 +30,7 @@ var _ = registerDataSource("gitlab_project_tag", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectTagRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	name := d.Get("name").(string)
 	project := d.Get("project").(string)
 	log.Printf("[DEBUG] read gitlab tag %s/%s", project, name)
@@ -39,6 +39,7 @@ var _ = registerDataSource("gitlab_project_tags", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"tags": {
 				Description: "List of repository tags from a project.",
 				Type:        schema.TypeList,
@@ -52,15 +53,10 @@ var _ = registerDataSource("gitlab_project_tags", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectTagsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
-	options := gitlab.ListTagsOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
-	}
+	options := gitlab.ListTagsOptions{}
 
 	if v, ok := d.GetOk("order_by"); ok {
 		options.OrderBy = gitlab.String(v.(string))
@@ -79,15 +75,13 @@ func dataSourceGitlabProjectTagsRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	var tags []*gitlab.Tag
-	for options.Page != 0 {
-		paginatedTags, resp, err := client.Tags.ListTags(project, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		tags = append(tags, paginatedTags...)
-		options.Page = resp.NextPage
+	tags, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Tag, *gitlab.Response, error) {
+		options := options
+		options.ListOptions = listOptions
+		return client.Tags.ListTags(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] get gitlab tags from project: %s", project)
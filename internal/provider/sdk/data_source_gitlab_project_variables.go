@@ -29,6 +29,7 @@ var _ = registerDataSource("gitlab_project_variables", func() *schema.Resource {
 				Optional:    true,
 				Default:     "*",
 			},
+			"max_results": maxResultsSchema(),
 			"variables": {
 				Description: "The list of variables returned by the search",
 				Type:        schema.TypeList,
@@ -42,24 +43,16 @@ var _ = registerDataSource("gitlab_project_variables", func() *schema.Resource {
 })
 
 func dataSourceGitlabProjectVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	environmentScope := d.Get("environment_scope").(string)
 
-	options := &gitlab.ListProjectVariablesOptions{
-		Page:    1,
-		PerPage: 20,
-	}
-
-	var variables []*gitlab.ProjectVariable
-	for options.Page != 0 {
-		paginatedVariables, resp, err := client.ProjectVariables.ListVariables(project, options, gitlab.WithContext(ctx), withEnvironmentScopeFilter(ctx, environmentScope))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		variables = append(variables, paginatedVariables...)
-		options.Page = resp.NextPage
+	variables, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
+		options := gitlab.ListProjectVariablesOptions(listOptions)
+		return client.ProjectVariables.ListVariables(project, &options, append(requestOptions, withEnvironmentScopeFilter(ctx, environmentScope))...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s:%s", project, environmentScope))
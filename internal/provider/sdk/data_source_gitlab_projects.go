@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -243,7 +244,7 @@ var _ = registerDataSource("gitlab_projects", func() *schema.Resource {
 				Optional:    true,
 			},
 			"order_by": {
-				Description: "Return projects ordered by `id`, `name`, `path`, `created_at`, `updated_at`, or `last_activity_at` fields. Default is `created_at`.",
+				Description: "Return projects ordered by `id`, `name`, `path`, `created_at`, `updated_at`, or `last_activity_at` fields. Default is `created_at`. Ordering by `id` uses keyset pagination, which is recommended for large numbers of projects.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				ValidateFunc: validation.StringInSlice([]string{
@@ -358,6 +359,7 @@ var _ = registerDataSource("gitlab_projects", func() *schema.Resource {
 					"min_access_level",
 				},
 			},
+			"max_results": maxResultsSchema(),
 			"projects": {
 				Description: "A list containing the projects matching the supplied arguments",
 				Type:        schema.TypeList,
@@ -972,9 +974,11 @@ var _ = registerDataSource("gitlab_projects", func() *schema.Resource {
 
 // CRUD methods
 
+// projectsPageConcurrency is the number of project pages requested at once with offset pagination.
+const projectsPageConcurrency = 4
+
 func dataSourceGitlabProjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
-	var projectList []*gitlab.Project
+	client := meta.(*providerMeta).client
 
 	// Permanent parameters
 
@@ -1095,6 +1099,17 @@ func dataSourceGitlabProjectsRead(ctx context.Context, d *schema.ResourceData, m
 		withSharedPtr = &d
 	}
 
+	pagination := paginationOptions{
+		Page:        page,
+		PerPage:     perPage,
+		MaxPages:    maxQueryablePages,
+		MaxResults:  d.Get("max_results").(int),
+		Concurrency: projectsPageConcurrency,
+		// keyset pagination is only supported when ordering by ID,
+		// but it's not restricted by the offset limits of GitLab for large result sets.
+		Keyset: orderByPtr != nil && strings.EqualFold(*orderByPtr, "id"),
+	}
+
 	log.Printf("[DEBUG] Reading Gitlab projects")
 
 	switch groupId, ok := d.GetOk("group_id"); ok {
@@ -1120,18 +1135,13 @@ func dataSourceGitlabProjectsRead(ctx context.Context, d *schema.ResourceData, m
 			WithCustomAttributes:     withCustomAttributesPtr,
 		}
 
-		for {
-			projects, response, err := client.Groups.ListGroupProjects(groupId.(int), opts, gitlab.WithContext(ctx))
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			projectList = append(projectList, projects...)
-			opts.ListOptions.Page++
-
-			log.Printf("[INFO] Currentpage: %d, Total: %d", response.CurrentPage, response.TotalPages)
-			if response.CurrentPage == response.TotalPages || response.CurrentPage > maxQueryablePages {
-				break
-			}
+		projectList, err := paginate(ctx, pagination, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+			opts := *opts
+			opts.ListOptions = listOptions
+			return client.Groups.ListGroupProjects(groupId.(int), &opts, requestOptions...)
+		})
+		if err != nil {
+			return diag.FromErr(err)
 		}
 		h, err := hashstructure.Hash(*opts, hashstructure.FormatV1, nil)
 		if err != nil {
@@ -1166,18 +1176,13 @@ func dataSourceGitlabProjectsRead(ctx context.Context, d *schema.ResourceData, m
 			WithProgrammingLanguage:  withProgrammingLanguagePtr,
 		}
 
-		for {
-			projects, response, err := client.Projects.ListProjects(opts, nil, gitlab.WithContext(ctx))
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			projectList = append(projectList, projects...)
-			opts.ListOptions.Page++
-
-			log.Printf("[INFO] Currentpage: %d, Total: %d", response.CurrentPage, response.TotalPages)
-			if response.CurrentPage == response.TotalPages || response.CurrentPage > maxQueryablePages {
-				break
-			}
+		projectList, err := paginate(ctx, pagination, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+			opts := *opts
+			opts.ListOptions = listOptions
+			return client.Projects.ListProjects(&opts, requestOptions...)
+		})
+		if err != nil {
+			return diag.FromErr(err)
 		}
 		h, err := hashstructure.Hash(*opts, hashstructure.FormatV1, nil)
 		if err != nil {
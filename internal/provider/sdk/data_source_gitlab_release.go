@@ -0,0 +1,37 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var _ = registerDataSource("gitlab_release", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_release`" + ` data source allows to retrieve details about a release of a project, including all its asset links.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/releases/#get-a-release-by-a-tag-name)`,
+
+		ReadContext: dataSourceGitlabReleaseRead,
+		Schema:      datasourceSchemaFromResourceSchema(gitlabReleaseGetSchema(), []string{"project", "tag_name"}, nil),
+	}
+})
+
+func dataSourceGitlabReleaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+	tagName := d.Get("tag_name").(string)
+
+	release, err := getGitlabRelease(ctx, client, project, tagName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(buildTwoPartID(&project, &tagName))
+	stateMap := gitlabReleaseToStateMap(project, release, release.Assets.Links)
+
+	if err := setStateMapInResourceData(stateMap, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ var _ = registerDataSource("gitlab_release_links", func() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"release_links": {
 				Description: "List of release links",
 				Type:        schema.TypeList,
@@ -43,28 +44,22 @@ var _ = registerDataSource("gitlab_release_links", func() *schema.Resource {
 })
 
 func dataSourceGitlabReleaseLinksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	tagName := d.Get("tag_name").(string)
-	options := gitlab.ListReleaseLinksOptions(
-		gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		})
 
-	var releaseLinks []*gitlab.ReleaseLink
-	for options.Page != 0 {
-		paginatedReleaseLinks, resp, err := client.ReleaseLinks.ListReleaseLinks(project, tagName, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			if is404(err) && (options.Page == 1) {
-				break
-			} else {
-				return diag.FromErr(err)
-			}
+	releaseLinks, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.ReleaseLink, *gitlab.Response, error) {
+		options := gitlab.ListReleaseLinksOptions(listOptions)
+		releaseLinks, resp, err := client.ReleaseLinks.ListReleaseLinks(project, tagName, &options, requestOptions...)
+		// a release without links is not found
+		if err != nil && is404(err) && listOptions.Page == 1 {
+			return nil, &gitlab.Response{}, nil
 		}
-		releaseLinks = append(releaseLinks, paginatedReleaseLinks...)
-		options.Page = resp.NextPage
+		return releaseLinks, resp, err
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] get list release links project/tagName: %s/%s", project, tagName)
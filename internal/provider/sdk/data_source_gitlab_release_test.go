@@ -0,0 +1,38 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccDataSourceGitlabRelease_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	testReleases := testutil.CreateReleases(t, testProject, 1)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "gitlab_release" "this" {
+				  project  = %d
+				  tag_name = "%s"
+				}
+				`, testProject.ID, testReleases[0].TagName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gitlab_release.this", "name", testReleases[0].Name),
+					resource.TestCheckResourceAttr("data.gitlab_release.this", "asset_link.#", "2"),
+					resource.TestCheckResourceAttrSet("data.gitlab_release.this", "commit_sha"),
+					resource.TestCheckResourceAttrSet("data.gitlab_release.this", "released_at"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/xanzy/go-gitlab"
+)
+
+var _ = registerDataSource("gitlab_releases", func() *schema.Resource {
+	validReleaseOrderByValues := []string{"released_at", "created_at"}
+	validReleaseSortValues := []string{"asc", "desc"}
+
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_releases`" + ` data source allows to retrieve details about the releases of a project.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/releases/#list-releases)`,
+
+		ReadContext: dataSourceGitlabReleasesRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The ID or full path of the project.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"order_by": {
+				Description:      fmt.Sprintf("Return releases ordered by. Valid values are %s. Default is released_at", renderValueListForDocs(validReleaseOrderByValues)),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validReleaseOrderByValues, false)),
+			},
+			"sort": {
+				Description:      "Return releases sorted in asc or desc order. Default is desc",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validReleaseSortValues, false)),
+			},
+			"max_results": maxResultsSchema(),
+			"releases": {
+				Description: "The list of releases returned by the search.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: datasourceSchemaFromResourceSchema(gitlabReleaseGetSchema(), nil, nil),
+				},
+			},
+		},
+	}
+})
+
+func dataSourceGitlabReleasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	options := gitlab.ListReleasesOptions{}
+
+	if v, ok := d.GetOk("order_by"); ok {
+		options.OrderBy = gitlab.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("sort"); ok {
+		options.Sort = gitlab.String(v.(string))
+	}
+
+	releases, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlabRelease, *gitlab.Response, error) {
+		options := options
+		options.ListOptions = listOptions
+		return listGitlabReleases(client, project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	optionsHash, err := hashstructure.Hash(&options, hashstructure.FormatV1, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d", project, optionsHash))
+	if err = d.Set("releases", flattenGitlabReleases(project, releases)); err != nil {
+		return diag.Errorf("failed to set releases to state: %v", err)
+	}
+
+	return nil
+}
+
+// listGitlabReleases lists a page of the releases with their milestones and evidences.
+func listGitlabReleases(client *gitlab.Client, project string, options *gitlab.ListReleasesOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlabRelease, *gitlab.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("projects/%s/releases", gitlab.PathEscape(project)), options, requestOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	var releases []*gitlabRelease
+	resp, err := client.Do(req, &releases)
+	if err != nil {
+		return nil, resp, err
+	}
+	return releases, resp, nil
+}
+
+func flattenGitlabReleases(project string, releases []*gitlabRelease) (values []map[string]interface{}) {
+	for _, release := range releases {
+		values = append(values, gitlabReleaseToStateMap(project, release, release.Assets.Links))
+	}
+	return values
+}
@@ -0,0 +1,49 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccDataSourceGitlabReleases_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	testReleases := testutil.CreateReleases(t, testProject, 2)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "gitlab_releases" "all" {
+				  project = %d
+
+				  // only for determinism
+				  order_by = "created_at"
+				  sort     = "asc"
+				}
+
+				data "gitlab_releases" "first" {
+				  project     = %d
+				  order_by    = "created_at"
+				  sort        = "asc"
+				  max_results = 1
+				}
+				`, testProject.ID, testProject.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gitlab_releases.all", "releases.#", "2"),
+					resource.TestCheckResourceAttr("data.gitlab_releases.all", "releases.0.tag_name", testReleases[0].TagName),
+					resource.TestCheckResourceAttr("data.gitlab_releases.all", "releases.1.tag_name", testReleases[1].TagName),
+					resource.TestCheckResourceAttr("data.gitlab_releases.all", "releases.0.asset_link.#", "2"),
+					resource.TestCheckResourceAttr("data.gitlab_releases.first", "releases.#", "1"),
+				),
+			},
+		},
+	})
+}
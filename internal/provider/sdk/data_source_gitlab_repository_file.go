@@ -22,7 +22,7 @@ var _ = registerDataSource("gitlab_repository_file", func() *schema.Resource {
 })
 
 func dataSourceGitlabRepositoryFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	filePath := d.Get("file_path").(string)
 
@@ -38,6 +38,7 @@ var _ = registerDataSource("gitlab_repository_tree", func() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"tree": {
 				Description: "The list of files/directories returned by the search",
 				Type:        schema.TypeList,
@@ -77,30 +78,25 @@ var _ = registerDataSource("gitlab_repository_tree", func() *schema.Resource {
 })
 
 func dataSourceGitlabRepositoryTreeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	options := &gitlab.ListTreeOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 20,
-			Page:    1,
-		},
 		Path:      gitlab.String(d.Get("path").(string)),
 		Ref:       gitlab.String(d.Get("ref").(string)),
 		Recursive: gitlab.Bool(d.Get("recursive").(bool)),
 	}
 
-	var nodes []*gitlab.TreeNode
-	for options.Page != 0 {
-
-		paginatedNodes, resp, err := client.Repositories.ListTree(project, options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		nodes = append(nodes, paginatedNodes...)
-
-		options.Page = resp.NextPage
+	pagination := paginationOptionsFromResourceData(d)
+	// the offset pagination of the repository tree is limited for large repositories
+	pagination.Keyset = true
+	nodes, err := paginate(ctx, pagination, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.TreeNode, *gitlab.Response, error) {
+		options := *options
+		options.ListOptions = listOptions
+		return client.Repositories.ListTree(project, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	optionsHash, err := hashstructure.Hash(&options, hashstructure.FormatV1, nil)
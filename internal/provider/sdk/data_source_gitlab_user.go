@@ -182,7 +182,7 @@ var _ = registerDataSource("gitlab_user", func() *schema.Resource {
 })
 
 func dataSourceGitlabUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var user *gitlab.User
 	var err error
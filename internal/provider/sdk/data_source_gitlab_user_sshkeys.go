@@ -37,6 +37,7 @@ var _ = registerDataSource("gitlab_user_sshkeys", func() *schema.Resource {
 					"user_id",
 				},
 			},
+			"max_results": maxResultsSchema(),
 			"keys": {
 				Description: "The user's keys.",
 				Type:        schema.TypeList,
@@ -50,15 +51,9 @@ var _ = registerDataSource("gitlab_user_sshkeys", func() *schema.Resource {
 })
 
 func dataSourceGitlabUserKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[INFO] Reading Gitlab user")
 
-	options := gitlab.ListSSHKeysForUserOptions{
-		PerPage: 2,
-		Page:    1,
-	}
-	var keys []*gitlab.SSHKey
-
 	userIDData, userIDOk := d.GetOk("user_id")
 	usernameData, usernameOk := d.GetOk("username")
 	var uid interface{}
@@ -70,13 +65,12 @@ func dataSourceGitlabUserKeysRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("one and only one of user_id or username must be set")
 	}
 
-	for options.Page != 0 {
-		paginatedKeys, resp, err := client.Users.ListSSHKeysForUser(uid, &options, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		keys = append(keys, paginatedKeys...)
-		options.Page = resp.NextPage
+	keys, err := paginate(ctx, paginationOptionsFromResourceData(d), func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.SSHKey, *gitlab.Response, error) {
+		options := gitlab.ListSSHKeysForUserOptions(listOptions)
+		return client.Users.ListSSHKeysForUser(uid, &options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%d", userIDData))
@@ -76,6 +76,7 @@ var _ = registerDataSource("gitlab_users", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"max_results": maxResultsSchema(),
 			"users": {
 				Description: "The list of users.",
 				Type:        schema.TypeList,
@@ -226,21 +227,23 @@ var _ = registerDataSource("gitlab_users", func() *schema.Resource {
 })
 
 func dataSourceGitlabUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	listUsersOptions, id, err := expandGitlabUsersOptions(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var users []*gitlab.User
-	for listUsersOptions.Page != 0 {
-		paginatedUsers, resp, err := client.Users.ListUsers(listUsersOptions, gitlab.WithContext(ctx))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		users = append(users, paginatedUsers...)
-		listUsersOptions.Page = resp.NextPage
+	pagination := paginationOptionsFromResourceData(d)
+	// keyset pagination is supported when ordering by ID, which is the default
+	pagination.Keyset = listUsersOptions.OrderBy != nil && *listUsersOptions.OrderBy == "id"
+	users, err := paginate(ctx, pagination, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.User, *gitlab.Response, error) {
+		options := *listUsersOptions
+		options.ListOptions = listOptions
+		return client.Users.ListUsers(&options, requestOptions...)
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%d", id))
@@ -298,8 +301,6 @@ func flattenGitlabUsers(users []*gitlab.User) []interface{} {
 
 func expandGitlabUsersOptions(d *schema.ResourceData) (*gitlab.ListUsersOptions, int, error) {
 	listUsersOptions := &gitlab.ListUsersOptions{}
-	listUsersOptions.PerPage = 20
-	listUsersOptions.Page = 1
 	var optionsHash strings.Builder
 
 	if data, ok := d.GetOk("order_by"); ok {
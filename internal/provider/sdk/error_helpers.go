@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
+)
+
+// apiErrorDiagnostics returns the diagnostics for an error returned by the GitLab API.
+// The validation errors of a `400 Bad Request` are returned per field, for the attribute of the same name
+// if the resource has one, and a `403 Forbidden` is explained with the token scopes and the role of the user.
+// Any other error is returned as is.
+func apiErrorDiagnostics(ctx context.Context, gitlabClient *gitlab.Client, d *schema.ResourceData, err error) diag.Diagnostics {
+	if fieldErrors := client.ValidationErrors(err); len(fieldErrors) > 0 {
+		var diags diag.Diagnostics
+		for _, fieldError := range fieldErrors {
+			diagnostic := diag.Diagnostic{
+				Severity: diag.Error,
+				Detail:   err.Error(),
+			}
+			attribute := strings.SplitN(fieldError.Field, ".", 2)[0]
+			if fieldError.Field == "base" {
+				diagnostic.Summary = fmt.Sprintf("Invalid request: %s", strings.Join(fieldError.Messages, ", "))
+			} else {
+				diagnostic.Summary = fmt.Sprintf("Invalid %s: %s", fieldError.Field, strings.Join(fieldError.Messages, ", "))
+				if hasAttribute(d, attribute) {
+					diagnostic.AttributePath = cty.GetAttrPath(attribute)
+				}
+			}
+			diags = append(diags, diagnostic)
+		}
+		return diags
+	}
+
+	if gitlabClient != nil {
+		if detail := client.ForbiddenDetail(ctx, gitlabClient, err); detail != "" {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  err.Error(),
+				Detail:   detail,
+			}}
+		}
+	}
+
+	return diag.FromErr(err)
+}
+
+// hasAttribute reports whether the resource or data source of the given data has a top level attribute of the given name.
+func hasAttribute(d *schema.ResourceData, name string) bool {
+	if d == nil {
+		return false
+	}
+	for _, value := range []cty.Value{d.GetRawPlan(), d.GetRawState(), d.GetRawConfig()} {
+		if value.Type().IsObjectType() {
+			return value.Type().HasAttribute(name)
+		}
+	}
+	return false
+}
+
+// summarizedAPIError is a GitLab API error with the summary of the diagnostic it was returned with,
+// which may contain additional context, like `failed to create project: POST https://...`.
+type summarizedAPIError struct {
+	summary string
+	err     *gitlab.ErrorResponse
+}
+
+func (e summarizedAPIError) Error() string { return e.summary }
+func (e summarizedAPIError) Unwrap() error { return e.err }
+
+// withAPIErrorDiagnostics translates the error diagnostics of the CRUD functions of the resource
+// which stem from a GitLab API error with `apiErrorDiagnostics`.
+// It makes the translation available to all resources, also if they only return the error with `diag.FromErr`.
+func withAPIErrorDiagnostics(r *schema.Resource) *schema.Resource {
+	r.CreateContext = translateAPIErrors(r.CreateContext)
+	r.ReadContext = translateAPIErrors(r.ReadContext)
+	r.UpdateContext = translateAPIErrors(r.UpdateContext)
+	r.DeleteContext = translateAPIErrors(r.DeleteContext)
+	return r
+}
+
+func translateAPIErrors[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if !diags.HasError() {
+			return diags
+		}
+		// the errors are only recorded in the context of an operation of the provider server
+		apiErrors := client.FailedRequestErrors(ctx)
+		if len(apiErrors) == 0 {
+			return diags
+		}
+		var gitlabClient *gitlab.Client
+		if m, ok := meta.(*providerMeta); ok && m != nil {
+			gitlabClient = m.client
+		}
+
+		var translated diag.Diagnostics
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error && diagnostic.AttributePath == nil && diagnostic.Detail == "" {
+				if apiErr := findAPIError(apiErrors, diagnostic.Summary); apiErr != nil {
+					translated = append(translated, apiErrorDiagnostics(ctx, gitlabClient, d, summarizedAPIError{summary: diagnostic.Summary, err: apiErr})...)
+					continue
+				}
+			}
+			translated = append(translated, diagnostic)
+		}
+		return translated
+	}
+}
+
+// findAPIError returns the latest API error which is contained in the given diagnostic summary.
+func findAPIError(apiErrors []*gitlab.ErrorResponse, summary string) *gitlab.ErrorResponse {
+	for i := len(apiErrors) - 1; i >= 0; i-- {
+		if strings.Contains(summary, apiErrors[i].Error()) {
+			return apiErrors[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestApiErrorDiagnostics(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Optional: true},
+	}, map[string]interface{}{"name": "my-project"})
+
+	req, err := http.NewRequest(http.MethodPost, "https://gitlab.example.com/api/v4/projects", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	apiErr := gitlab.CheckResponse(&http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"message": {"name": ["has already been taken"], "visibility_level": ["is not allowed"]}}`)),
+		Request:    req,
+	})
+
+	diags := apiErrorDiagnostics(context.Background(), nil, d, apiErr)
+	expected := diag.Diagnostics{
+		{Severity: diag.Error, Summary: "Invalid name: has already been taken", Detail: apiErr.Error(), AttributePath: cty.GetAttrPath("name")},
+		{Severity: diag.Error, Summary: "Invalid visibility_level: is not allowed", Detail: apiErr.Error()},
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), diags)
+	}
+	for i := range expected {
+		if diags[i].Summary != expected[i].Summary || diags[i].Detail != expected[i].Detail || !diags[i].AttributePath.Equals(expected[i].AttributePath) {
+			t.Errorf("expected diagnostic %+v, got %+v", expected[i], diags[i])
+		}
+	}
+
+	notFound := gitlab.CheckResponse(&http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody, Request: req})
+	if diags := apiErrorDiagnostics(context.Background(), nil, d, notFound); len(diags) != 1 || diags[0].Summary != notFound.Error() {
+		t.Errorf("expected other errors to be returned as is, got %v", diags)
+	}
+}
@@ -0,0 +1,268 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+// unitTestProviderFactories are used to instantiate the provider in unit tests against the fake GitLab API.
+var unitTestProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"gitlab": func() (tfprotov6.ProviderServer, error) {
+		return NewV6(context.Background(), "unittest")
+	},
+}
+
+// skipWithoutTerraform skips unit tests of resources if no local Terraform CLI is available,
+// instead of downloading it, so that the unit tests also run on machines without network access.
+func skipWithoutTerraform(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("the Terraform CLI is required to unit test resources, set TF_ACC_TERRAFORM_PATH or add it to the PATH")
+	}
+}
+
+// fakeGitLabResource calls the CRUD functions of a resource directly against the fake GitLab API,
+// to unit test them in more detail than through the Terraform CLI.
+type fakeGitLabResource struct {
+	fake     *testutil.FakeGitLab
+	meta     *providerMeta
+	resource *schema.Resource
+	// project is `root/api`, with a README on the `main` branch.
+	project *gitlab.Project
+}
+
+// newFakeGitLabResource returns the resource of the given type with a new fake GitLab API and project.
+func newFakeGitLabResource(t *testing.T, resourceType string) *fakeGitLabResource {
+	t.Helper()
+	fake := testutil.NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("api"), InitializeWithReadme: gitlab.Bool(true)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	return &fakeGitLabResource{
+		fake:     fake,
+		meta:     &providerMeta{client: fake.Client},
+		resource: New("unittest")().ResourcesMap[resourceType],
+		project:  project,
+	}
+}
+
+// create creates the resource with the configuration and returns its resource data.
+func (f *fakeGitLabResource) create(t *testing.T, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, f.resource.Schema, config)
+	if diags := f.resource.CreateContext(context.Background(), d, f.meta); diags.HasError() {
+		t.Fatalf("failed to create the resource: %v", diags)
+	}
+	return d
+}
+
+// read refreshes the resource data.
+func (f *fakeGitLabResource) read(t *testing.T, d *schema.ResourceData) {
+	t.Helper()
+	if diags := f.resource.ReadContext(context.Background(), d, f.meta); diags.HasError() {
+		t.Fatalf("failed to read the resource: %v", diags)
+	}
+}
+
+// update plans and applies the configuration, see `testutil.UpdateResource`.
+func (f *fakeGitLabResource) update(t *testing.T, d *schema.ResourceData, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	return testutil.UpdateResource(t, f.resource, d, config, f.meta)
+}
+
+// diff plans the configuration for the state of the resource data.
+func (f *fakeGitLabResource) diff(t *testing.T, d *schema.ResourceData, config map[string]interface{}) *terraform.InstanceDiff {
+	t.Helper()
+	diff, err := f.resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), f.meta)
+	if err != nil {
+		t.Fatalf("failed to diff the resource: %v", err)
+	}
+	return diff
+}
+
+// delete deletes the resource and returns the warnings.
+func (f *fakeGitLabResource) delete(t *testing.T, d *schema.ResourceData) diag.Diagnostics {
+	t.Helper()
+	diags := f.resource.DeleteContext(context.Background(), d, f.meta)
+	if diags.HasError() {
+		t.Fatalf("failed to delete the resource: %v", diags)
+	}
+	return diags
+}
+
+// readDataSource reads the data source of the given type with the configuration and returns its resource data.
+func (f *fakeGitLabResource) readDataSource(t *testing.T, dataSourceType string, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	ds := New("unittest")().DataSourcesMap[dataSourceType]
+	d := schema.TestResourceDataRaw(t, ds.Schema, config)
+	if diags := ds.ReadContext(context.Background(), d, f.meta); diags.HasError() {
+		t.Fatalf("failed to read the data source: %v", diags)
+	}
+	return d
+}
+
+// repositoryFile returns the content and the execute flag of the file on the ref, or nil if it doesn't exist.
+func (f *fakeGitLabResource) repositoryFile(t *testing.T, ref string, path string) map[string]interface{} {
+	t.Helper()
+	raw, _, err := f.fake.Client.RepositoryFiles.GetRawFile(f.project.ID, path, &gitlab.GetRawFileOptions{Ref: gitlab.String(ref)})
+	if is404(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("failed to get %s on %s: %v", path, ref, err)
+	}
+	file, _, err := f.fake.Client.RepositoryFiles.GetFileMetaData(f.project.ID, path, &gitlab.GetFileMetaDataOptions{Ref: gitlab.String(ref)})
+	if err != nil {
+		t.Fatalf("failed to get the metadata of %s on %s: %v", path, ref, err)
+	}
+	return map[string]interface{}{"content": string(raw), "execute_filemode": file.ExecuteFilemode}
+}
+
+// mergeRequest returns the merge request of the project.
+func (f *fakeGitLabResource) mergeRequest(t *testing.T, iid int) *gitlab.MergeRequest {
+	t.Helper()
+	mergeRequest, _, err := f.fake.Client.MergeRequests.GetMergeRequest(f.project.ID, iid, nil)
+	if err != nil {
+		t.Fatalf("failed to get merge request !%d: %v", iid, err)
+	}
+	return mergeRequest
+}
+
+// checkAttributes reports every attribute of the resource data which doesn't have the expected value,
+// like `d.Get` returns it, e.g. a map for `files` or an int for the number of elements `milestones.#`.
+// The `id` is the ID of the resource.
+func checkAttributes(t *testing.T, d *schema.ResourceData, expected map[string]interface{}) {
+	t.Helper()
+	actual := make(map[string]interface{}, len(expected))
+	for key := range expected {
+		if key == "id" {
+			actual[key] = d.Id()
+			continue
+		}
+		actual[key] = d.Get(key)
+	}
+	checkValues(t, actual, expected)
+}
+
+// checkValues reports every value which doesn't equal the expected value with the same name.
+func checkValues(t *testing.T, actual map[string]interface{}, expected map[string]interface{}) {
+	t.Helper()
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !reflect.DeepEqual(actual[name], expected[name]) {
+			t.Errorf("expected %s to be %#v, got %#v", name, expected[name], actual[name])
+		}
+	}
+}
+
+func TestUnitGitlabProjectVariableAndLabel_fakeGitLab(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := testutil.NewFakeGitLab(t)
+
+	config := func(value string) string {
+		return fake.ProviderConfig() + fmt.Sprintf(`
+resource "gitlab_project" "this" {
+  name = "foo"
+}
+
+resource "gitlab_project_variable" "this" {
+  project = gitlab_project.this.id
+  key     = "FOO"
+  value   = %q
+}
+
+resource "gitlab_label" "this" {
+  project = gitlab_project.this.id
+  name    = "bug"
+  color   = "#FF0000"
+}
+`, value)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: unitTestProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if _, _, err := fake.Client.Projects.GetProject("root/foo", nil); !is404(err) {
+				return fmt.Errorf("expected the project to be deleted, got %v", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project.this", "path_with_namespace", "root/foo"),
+					resource.TestCheckResourceAttr("gitlab_project_variable.this", "environment_scope", "*"),
+					resource.TestCheckResourceAttr("gitlab_label.this", "color", "#FF0000"),
+				),
+			},
+			{
+				Config: config("baz"),
+				Check: func(*terraform.State) error {
+					variable, _, err := fake.Client.ProjectVariables.GetVariable("root/foo", "FOO", nil)
+					if err != nil {
+						return err
+					}
+					if variable.Value != "baz" {
+						return fmt.Errorf("expected the updated value, got %q", variable.Value)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:      "gitlab_label.this",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitGitlabProjectVariable_fakeGitLabValidationError(t *testing.T) {
+	skipWithoutTerraform(t)
+	fake := testutil.NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo")})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: unitTestProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.ProviderConfig() + fmt.Sprintf(`
+resource "gitlab_project_variable" "this" {
+  project = %d
+  key     = "FOO"
+  value   = "short"
+  masked  = true
+}
+`, project.ID),
+				ExpectError: regexp.MustCompile(`the value of the masked variable "FOO" can't be masked by GitLab: it must be at least 8 characters long`),
+			},
+		},
+	})
+}
@@ -5,26 +5,49 @@ package sdk
 
 import (
 	"context"
-	"log"
 	"testing"
 
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
 	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
 )
 
 func TestAcc_GraphQL_basic(t *testing.T) {
 
-	query := GraphQLQuery{
-		`query {currentUser {name, bot, gitpodEnabled, groupCount, id, namespace{id}, publicEmail, username}}`,
+	request := client.GraphQLRequest{
+		Query: `query {currentUser {name, bot, gitpodEnabled, groupCount, id, namespace{id}, publicEmail, username}}`,
 	}
 
 	var response CurrentUserResponse
-	_, err := SendGraphQLRequest(context.Background(), testutil.TestGitlabClient, query, &response)
-	if err != nil {
-		log.Println(err)
-		t.Fail()
+	if err := client.SendGraphQLRequest(context.Background(), testutil.TestGitlabClient, request, &response); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if response.Data.CurrentUser.Name != "Administrator" {
+	if response.CurrentUser.Name != "Administrator" {
 		t.Fail()
 	}
 }
+
+func TestAcc_GraphQL_variablesAndErrors(t *testing.T) {
+	request := client.GraphQLRequest{
+		OperationName: "user",
+		Query:         `query user($username: String!) {user(username: $username) {username}}`,
+		Variables:     map[string]interface{}{"username": "root"},
+	}
+
+	var response struct {
+		User struct {
+			Username string `json:"username"`
+		} `json:"user"`
+	}
+	if err := client.SendGraphQLRequest(context.Background(), testutil.TestGitlabClient, request, &response); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.User.Username != "root" {
+		t.Fatalf("expected user root, got %q", response.User.Username)
+	}
+
+	request.Query = `query user($username: String!) {user(username: $username) {unknownField}}`
+	if err := client.SendGraphQLRequest(context.Background(), testutil.TestGitlabClient, request, &response); err == nil {
+		t.Fatalf("expected the GraphQL errors to be returned")
+	}
+}
@@ -0,0 +1,305 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+// namespaceReference is an attribute of a resource which references a project or group by its ID or full path.
+type namespaceReference struct {
+	// attribute is the configured reference, like `project` or `group_id`.
+	attribute string
+	// kind is either `project` or `group`.
+	kind string
+	// upgradeState is true for resources with states written before the numeric ID was stored,
+	// which are upgraded to the next schema version.
+	upgradeState bool
+}
+
+// namespaceReferences are the references of the resources which resolve the project or group they belong to
+// to its numeric ID, see `withNamespaceReferences`. A resource opts in by adding its references here,
+// e.g. both the optional `project` and `group` of a resource which belongs to either of them.
+var namespaceReferences = map[string][]namespaceReference{
+	"gitlab_branch":                        {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_branch_protection":             {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_cluster_agent":                 {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_cluster_agent_token":           {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_deploy_key":                    {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_deploy_key_enable":             {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_deploy_token":                  {{attribute: "project", kind: "project", upgradeState: true}, {attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_group_access_token":            {{attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_group_badge":                   {{attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_group_cluster":                 {{attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_group_hook":                    {{attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_group_label":                   {{attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_group_ldap_link":               {{attribute: "group_id", kind: "group", upgradeState: true}},
+	"gitlab_group_membership":              {{attribute: "group_id", kind: "group", upgradeState: true}},
+	"gitlab_group_saml_link":               {{attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_group_share_group":             {{attribute: "group_id", kind: "group", upgradeState: true}},
+	"gitlab_group_variable":                {{attribute: "group", kind: "group", upgradeState: true}},
+	"gitlab_label":                         {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_managed_license":               {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_pipeline_schedule":             {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_pipeline_schedule_variable":    {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_pipeline_trigger":              {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_access_token":          {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_approval_rule":         {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_badge":                 {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_cluster":               {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_environment":           {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_freeze_period":         {{attribute: "project_id", kind: "project", upgradeState: true}},
+	"gitlab_project_generic_package_file":  {{attribute: "project", kind: "project"}},
+	"gitlab_project_hook":                  {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_issue":                 {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_issue_board":           {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_membership":            {{attribute: "project_id", kind: "project", upgradeState: true}},
+	"gitlab_project_merge_request":         {{attribute: "project", kind: "project"}},
+	"gitlab_project_milestone":             {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_mirror":                {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_protected_environment": {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_runner_enablement":     {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_share_group":           {{attribute: "project_id", kind: "project", upgradeState: true}},
+	"gitlab_project_tag":                   {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_project_variable":              {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_release":                       {{attribute: "project", kind: "project"}},
+	"gitlab_release_link":                  {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_repository_file":               {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_repository_files":              {{attribute: "project", kind: "project"}},
+	"gitlab_service_emails_on_push":        {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_service_external_wiki":         {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_service_github":                {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_service_jira":                  {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_service_microsoft_teams":       {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_service_pipelines_email":       {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_service_slack":                 {{attribute: "project", kind: "project", upgradeState: true}},
+	"gitlab_tag_protection":                {{attribute: "project", kind: "project", upgradeState: true}},
+}
+
+// numericAttribute is the computed attribute which stores the numeric ID the reference resolves to.
+func (ref namespaceReference) numericAttribute() string {
+	return "numeric_" + ref.kind + "_id"
+}
+
+// resolve returns the numeric ID of the referenced project or group as string.
+func (ref namespaceReference) resolve(ctx context.Context, client *gitlab.Client, value string) (string, error) {
+	if _, err := strconv.Atoi(value); err == nil {
+		return value, nil
+	}
+	if ref.kind == "group" {
+		group, _, err := client.Groups.GetGroup(value, &gitlab.GetGroupOptions{WithProjects: gitlab.Bool(false)}, gitlab.WithContext(ctx))
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve the ID of group %q: %w", value, err)
+		}
+		return strconv.Itoa(group.ID), nil
+	}
+	project, _, err := client.Projects.GetProject(value, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the ID of project %q: %w", value, err)
+	}
+	return strconv.Itoa(project.ID), nil
+}
+
+// withNamespaceReferences makes the project and group references of the resource registered in `namespaceReferences`
+// accept either the ID or the full path. An optional reference which isn't configured is left empty. The reference is resolved to the numeric ID once, which is stored in the computed
+// `numeric_project_id` or `numeric_group_id` attribute, while the reference keeps the configured value.
+// The CRUD functions of the resource always see the numeric ID in the reference, so that the resource IDs built from it
+// are stable and the resource survives a rename or transfer of the project or group.
+// Changing the reference to the numeric ID it has been resolved to doesn't change the resource. A reference which
+// replaces the resource on change, only replaces it if the new value resolves to another project or group,
+// so that e.g. following the new full path after a rename or transfer is updated in place.
+func withNamespaceReferences(resourceType string, r *schema.Resource) *schema.Resource {
+	refs, ok := namespaceReferences[resourceType]
+	// data sources share the type of the resources, but don't store references
+	if !ok || (r.CreateContext == nil && r.CreateWithoutTimeout == nil) {
+		return r
+	}
+
+	priorType := r.CoreConfigSchema().ImpliedType()
+	var upgradeRefs []namespaceReference
+	for _, ref := range refs {
+		r.Schema[ref.numericAttribute()] = &schema.Schema{
+			Description: fmt.Sprintf("The numeric ID of the %s referenced by `%s`, which may be given as ID or full path.", ref.kind, ref.attribute),
+			Type:        schema.TypeString,
+			Computed:    true,
+		}
+		if ref.upgradeState {
+			upgradeRefs = append(upgradeRefs, ref)
+		}
+		r.Schema[ref.attribute].DiffSuppressFunc = suppressNamespaceReferenceDiff(ref, r.Schema[ref.attribute].DiffSuppressFunc)
+		if r.Schema[ref.attribute].ForceNew {
+			r.Schema[ref.attribute].ForceNew = false
+			if r.CustomizeDiff == nil {
+				r.CustomizeDiff = forceNewNamespaceReference(ref)
+			} else {
+				r.CustomizeDiff = customdiff.All(forceNewNamespaceReference(ref), r.CustomizeDiff)
+			}
+			// the references are the only attributes which may change in place
+			if r.UpdateContext == nil && r.UpdateWithoutTimeout == nil {
+				r.UpdateContext = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil }
+			}
+		}
+
+		r.CreateContext = resolveNamespaceReference(ref, r.CreateContext)
+		r.CreateWithoutTimeout = resolveNamespaceReference(ref, r.CreateWithoutTimeout)
+		r.ReadContext = resolveNamespaceReference(ref, r.ReadContext)
+		r.ReadWithoutTimeout = resolveNamespaceReference(ref, r.ReadWithoutTimeout)
+		r.UpdateContext = resolveNamespaceReference(ref, r.UpdateContext)
+		r.UpdateWithoutTimeout = resolveNamespaceReference(ref, r.UpdateWithoutTimeout)
+		r.DeleteContext = resolveNamespaceReference(ref, r.DeleteContext)
+		r.DeleteWithoutTimeout = resolveNamespaceReference(ref, r.DeleteWithoutTimeout)
+	}
+	if len(upgradeRefs) > 0 {
+		r.StateUpgraders = append(r.StateUpgraders, schema.StateUpgrader{
+			Type:    priorType,
+			Upgrade: namespaceReferenceStateUpgrade(upgradeRefs...),
+			Version: r.SchemaVersion,
+		})
+		r.SchemaVersion++
+	}
+	return r
+}
+
+// suppressNamespaceReferenceDiff suppresses the change of the reference to the numeric ID it has been resolved to,
+// e.g. from `my-group/my-project` to `42`, which references the same project or group.
+func suppressNamespaceReferenceDiff(ref namespaceReference, suppress schema.SchemaDiffSuppressFunc) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if suppress != nil && suppress(k, old, new, d) {
+			return true
+		}
+		numericID, _ := d.Get(ref.numericAttribute()).(string)
+		return old != "" && numericID != "" && new == numericID
+	}
+}
+
+// forceNewNamespaceReference replaces the resource when the reference changes to another project or group.
+// The new value is resolved to its numeric ID, the change to another ID or full path of the same project or group,
+// like the new full path after a rename or transfer, is kept as an in-place update which only stores the new value.
+// The resource is replaced if the new value isn't known yet or doesn't exist, like a project created in the same apply.
+func forceNewNamespaceReference(ref namespaceReference) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" || !d.HasChange(ref.attribute) {
+			return nil
+		}
+		m, ok := meta.(*providerMeta)
+		numericID, _ := d.Get(ref.numericAttribute()).(string)
+		if !ok || m == nil || numericID == "" || !d.NewValueKnown(ref.attribute) || d.Get(ref.attribute) == "" {
+			return d.ForceNew(ref.attribute)
+		}
+
+		resolved, err := ref.resolve(ctx, m.client, d.Get(ref.attribute).(string))
+		if err != nil {
+			var errResponse *gitlab.ErrorResponse
+			if errors.As(err, &errResponse) && is404(errResponse) {
+				return d.ForceNew(ref.attribute)
+			}
+			return err
+		}
+		if resolved != numericID {
+			return d.ForceNew(ref.attribute)
+		}
+		return nil
+	}
+}
+
+// resolveNamespaceReference calls the CRUD function with the numeric ID in the reference attribute
+// and restores the configured value afterwards. The numeric ID is resolved if it isn't known yet,
+// i.e. on create, import or for a state which was upgraded without access to the GitLab API.
+func resolveNamespaceReference[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](ref namespaceReference, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*providerMeta).client
+		configured := d.Get(ref.attribute).(string)
+		numericID := d.Get(ref.numericAttribute()).(string)
+		// the resource is updated to reference another project or group
+		if d.HasChange(ref.attribute) {
+			numericID = ""
+		}
+
+		if numericID == "" && configured != "" {
+			resolved, err := ref.resolve(ctx, client, configured)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			numericID = resolved
+		}
+		if numericID != "" {
+			d.Set(ref.attribute, numericID)
+		}
+
+		diags := f(ctx, d, meta)
+		// the resource is gone or failed to be created
+		if d.Id() == "" {
+			return diags
+		}
+
+		if configured == "" {
+			// on import, the reference is only known after the read
+			configured = d.Get(ref.attribute).(string)
+		}
+		if numericID == "" && configured != "" {
+			resolved, err := ref.resolve(ctx, client, configured)
+			if err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+			numericID = resolved
+		}
+		d.Set(ref.attribute, configured)
+		d.Set(ref.numericAttribute(), numericID)
+		d.SetId(normalizeNamespaceReferenceID(d.Id(), configured, numericID))
+		return diags
+	}
+}
+
+// normalizeNamespaceReferenceID replaces the reference at the start of the resource ID with the numeric ID,
+// e.g. `group/project:key` with `42:key`. IDs which don't contain the reference are returned as is.
+func normalizeNamespaceReferenceID(id string, reference string, numericID string) string {
+	if reference == "" || numericID == "" || reference == numericID {
+		return id
+	}
+	if id == reference {
+		return numericID
+	}
+	if strings.HasPrefix(id, reference+":") {
+		return numericID + strings.TrimPrefix(id, reference)
+	}
+	return id
+}
+
+// namespaceReferenceStateUpgrade resolves the references of a state written before the numeric IDs were stored
+// and normalizes the resource ID. Without access to the GitLab API, the numeric IDs are resolved on the next read instead.
+func namespaceReferenceStateUpgrade(refs ...namespaceReference) schema.StateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		m, ok := meta.(*providerMeta)
+		if !ok || m == nil {
+			return rawState, nil
+		}
+
+		for _, ref := range refs {
+			reference, _ := rawState[ref.attribute].(string)
+			if reference == "" {
+				continue
+			}
+			numericID, err := ref.resolve(ctx, m.client, reference)
+			if err != nil {
+				log.Printf("[WARN] unable to upgrade the state of %s %q, it's resolved on the next read: %v", ref.attribute, reference, err)
+				continue
+			}
+			rawState[ref.numericAttribute()] = numericID
+			if id, ok := rawState["id"].(string); ok {
+				rawState["id"] = normalizeNamespaceReferenceID(id, reference, numericID)
+			}
+		}
+		return rawState, nil
+	}
+}
@@ -0,0 +1,273 @@
+package sdk
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestNamespaceReference_renamedProject(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client}
+	group, _, err := fake.Client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("acme"), Path: gitlab.String("acme")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("api"), NamespaceID: gitlab.Int(group.ID)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	projectID := strconv.Itoa(project.ID)
+
+	r := New("unittest")().ResourcesMap["gitlab_project_variable"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project": "acme/api",
+		"key":     "FOO",
+		"value":   "bar",
+	})
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("failed to create the variable: %v", diags)
+	}
+	if d.Id() != projectID+":FOO:*" {
+		t.Fatalf("expected the ID to contain the numeric project ID, got %q", d.Id())
+	}
+	if d.Get("project") != "acme/api" || d.Get("numeric_project_id") != projectID {
+		t.Fatalf("expected the configured path and the numeric ID in the state, got %q and %q", d.Get("project"), d.Get("numeric_project_id"))
+	}
+
+	if _, _, err := fake.Client.Projects.EditProject(project.ID, &gitlab.EditProjectOptions{Path: gitlab.String("renamed")}); err != nil {
+		t.Fatalf("failed to rename the project: %v", err)
+	}
+	d = r.Data(d.State())
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("failed to read the variable: %v", diags)
+	}
+	if d.Id() == "" || d.Get("value") != "bar" || d.Get("project") != "acme/api" {
+		t.Fatalf("expected the variable to be read after the rename of the project, got ID %q and project %q", d.Id(), d.Get("project"))
+	}
+
+	for _, tc := range []struct {
+		project     string
+		requiresNew bool
+	}{
+		{project: "acme/api", requiresNew: false},
+		{project: projectID, requiresNew: false},
+		// the new path resolves to the same project
+		{project: "acme/renamed", requiresNew: false},
+		{project: "1", requiresNew: true},
+		{project: "acme/unknown", requiresNew: true},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{"project": tc.project, "key": "FOO", "value": "bar"})
+		diff, err := r.Diff(context.Background(), d.State(), config, meta)
+		if err != nil {
+			t.Fatalf("failed to diff project %q: %v", tc.project, err)
+		}
+		if diff.RequiresNew() != tc.requiresNew {
+			t.Errorf("expected the change of the project to %q to require a new resource: %t", tc.project, tc.requiresNew)
+		}
+	}
+
+	// following the new path is stored in place
+	d = testutil.UpdateResource(t, r, d, map[string]interface{}{"project": "acme/renamed", "key": "FOO", "value": "bar"}, meta)
+	if actual := d.Get("project"); actual != "acme/renamed" {
+		t.Errorf("expected the new path in the state, got %q", actual)
+	}
+	if actual := d.Get("numeric_project_id"); actual != projectID {
+		t.Errorf("expected the numeric ID %q in the state, got %q", projectID, actual)
+	}
+	if actual := d.Id(); actual != projectID+":FOO:*" {
+		t.Errorf("expected the ID to be unchanged, got %q", actual)
+	}
+}
+
+func TestNamespaceReference_deployToken(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client}
+	group, _, err := fake.Client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("acme"), Path: gitlab.String("acme")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	groupID := strconv.Itoa(group.ID)
+	if _, _, err := fake.Client.Groups.UpdateGroup(group.ID, &gitlab.UpdateGroupOptions{Path: gitlab.String("renamed")}); err != nil {
+		t.Fatalf("failed to rename the group: %v", err)
+	}
+
+	r := New("unittest")().ResourcesMap["gitlab_deploy_token"]
+	state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{
+		"id":               "1",
+		"group":            "acme",
+		"numeric_group_id": groupID,
+		"name":             "deploy",
+		"scopes.#":         "1",
+		"scopes.0":         "read_repository",
+	}}
+	for _, tc := range []struct {
+		name        string
+		config      map[string]interface{}
+		requiresNew bool
+	}{
+		{name: "same group", config: map[string]interface{}{"group": "acme"}, requiresNew: false},
+		{name: "renamed group", config: map[string]interface{}{"group": "renamed"}, requiresNew: false},
+		{name: "group ID", config: map[string]interface{}{"group": groupID}, requiresNew: false},
+		{name: "other group", config: map[string]interface{}{"group": "1"}, requiresNew: true},
+		{name: "project", config: map[string]interface{}{"project": "acme/api"}, requiresNew: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config["name"] = "deploy"
+			tc.config["scopes"] = []interface{}{"read_repository"}
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), meta)
+			if err != nil {
+				t.Fatalf("failed to diff: %v", err)
+			}
+			if diff.RequiresNew() != tc.requiresNew {
+				t.Errorf("expected the change to require a new resource: %t", tc.requiresNew)
+			}
+		})
+	}
+}
+
+func TestNamespaceReferences(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client}
+	group, _, err := fake.Client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("acme"), Path: gitlab.String("acme")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("api"), NamespaceID: gitlab.Int(group.ID)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	references := map[string]struct{ path, numericID string }{
+		"group":   {path: "acme", numericID: strconv.Itoa(group.ID)},
+		"project": {path: "acme/api", numericID: strconv.Itoa(project.ID)},
+	}
+
+	resources := New("unittest")().ResourcesMap
+	for resourceType, refs := range namespaceReferences {
+		resourceType, refs := resourceType, refs
+		t.Run(resourceType, func(t *testing.T) {
+			r, ok := resources[resourceType]
+			if !ok {
+				t.Fatalf("resource %s is not registered", resourceType)
+			}
+			original := allResources[resourceType]()
+			if len(refs) == 0 {
+				t.Fatalf("expected at least one reference")
+			}
+			for _, ref := range refs {
+				testNamespaceReference(t, r, original, ref, references[ref.kind].path, references[ref.kind].numericID)
+			}
+
+			if !refs[0].upgradeState {
+				if r.SchemaVersion != original.SchemaVersion || len(r.StateUpgraders) != len(original.StateUpgraders) {
+					t.Fatalf("expected no state upgrade for a resource which always stored the numeric ID")
+				}
+				return
+			}
+			if r.SchemaVersion != original.SchemaVersion+1 || len(r.StateUpgraders) != len(original.StateUpgraders)+1 {
+				t.Fatalf("expected a state upgrade to schema version %d", original.SchemaVersion+1)
+			}
+			upgrader := r.StateUpgraders[len(r.StateUpgraders)-1]
+			if upgrader.Version != original.SchemaVersion {
+				t.Fatalf("expected the state upgrade from schema version %d, got %d", original.SchemaVersion, upgrader.Version)
+			}
+			for _, ref := range refs {
+				reference := references[ref.kind]
+				actual, err := upgrader.Upgrade(context.Background(), map[string]interface{}{"id": reference.path + ":1", ref.attribute: reference.path}, meta)
+				if err != nil {
+					t.Fatalf("failed to upgrade the state of %s: %v", ref.attribute, err)
+				}
+				if actual["id"] != reference.numericID+":1" || actual[ref.attribute] != reference.path || actual[ref.numericAttribute()] != reference.numericID {
+					t.Errorf("unexpected upgraded state of %s: %v", ref.attribute, actual)
+				}
+			}
+		})
+	}
+}
+
+// testNamespaceReference checks the schema of a reference registered in `namespaceReferences`.
+func testNamespaceReference(t *testing.T, r *schema.Resource, original *schema.Resource, ref namespaceReference, path string, numericID string) {
+	t.Helper()
+
+	// the reference keeps the behavior the resource defines
+	s, ok := r.Schema[ref.attribute]
+	if !ok || s.Type != schema.TypeString || s.Required != original.Schema[ref.attribute].Required {
+		t.Fatalf("expected %s to be a string reference", ref.attribute)
+	}
+	if original.Schema[ref.attribute].ForceNew {
+		// a replacement is planned by the CustomizeDiff, unless the reference resolves to the same ID
+		if s.ForceNew || r.CustomizeDiff == nil {
+			t.Errorf("expected %s to force a new resource in the CustomizeDiff", ref.attribute)
+		}
+		if r.UpdateContext == nil && r.UpdateWithoutTimeout == nil {
+			t.Errorf("expected the resource to update %s in place", ref.attribute)
+		}
+	} else if s.ForceNew || (r.UpdateContext == nil && r.UpdateWithoutTimeout == nil) != (original.UpdateContext == nil && original.UpdateWithoutTimeout == nil) {
+		t.Errorf("expected the resource to keep its update")
+	}
+	if numeric, ok := r.Schema[ref.numericAttribute()]; !ok || !numeric.Computed {
+		t.Errorf("expected the computed %s attribute", ref.numericAttribute())
+	}
+
+	d := r.TestResourceData()
+	if err := d.Set(ref.numericAttribute(), numericID); err != nil {
+		t.Fatalf("failed to set %s: %v", ref.numericAttribute(), err)
+	}
+	if !s.DiffSuppressFunc(ref.attribute, path, numericID, d) {
+		t.Errorf("expected the change of %s to the numeric ID to be suppressed", ref.attribute)
+	}
+	if s.DiffSuppressFunc(ref.attribute, path, "1", d) {
+		t.Errorf("expected the change of %s to another ID not to be suppressed", ref.attribute)
+	}
+}
+
+func TestNamespaceReferenceStateUpgrade(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client}
+	group, _, err := fake.Client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("acme"), Path: gitlab.String("acme")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	groupID := strconv.Itoa(group.ID)
+	upgrade := namespaceReferenceStateUpgrade(namespaceReference{attribute: "group", kind: "group"})
+
+	actual, err := upgrade(context.Background(), map[string]interface{}{"id": "acme:FOO:*", "group": "acme"}, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual["id"] != groupID+":FOO:*" || actual["group"] != "acme" || actual["numeric_group_id"] != groupID {
+		t.Fatalf("unexpected upgraded state: %v", actual)
+	}
+
+	// without access to the API, the reference is resolved on the next read
+	actual, err = upgrade(context.Background(), map[string]interface{}{"id": "acme:FOO:*", "group": "acme"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual["id"] != "acme:FOO:*" || actual["numeric_group_id"] != nil {
+		t.Fatalf("expected the state to be unchanged, got %v", actual)
+	}
+}
+
+func TestNormalizeNamespaceReferenceID(t *testing.T) {
+	for _, tc := range []struct {
+		id, reference, numericID, expected string
+	}{
+		{"acme/api:FOO:*", "acme/api", "42", "42:FOO:*"},
+		{"acme/api", "acme/api", "42", "42"},
+		{"42:FOO:*", "acme/api", "42", "42:FOO:*"},
+		{"7", "acme/api", "42", "7"},
+		{"acme/api-2:FOO", "acme/api", "42", "acme/api-2:FOO"},
+	} {
+		if actual := normalizeNamespaceReferenceID(tc.id, tc.reference, tc.numericID); actual != tc.expected {
+			t.Errorf("expected %q for ID %q, got %q", tc.expected, tc.id, actual)
+		}
+	}
+}
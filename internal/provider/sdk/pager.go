@@ -0,0 +1,35 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/pager"
+)
+
+// paginationOptions configure how `paginate` lists all pages of a GitLab list endpoint, see `pager.Options`.
+type paginationOptions = pager.Options
+
+// maxResultsSchema returns the schema of the `max_results` attribute of the list data sources.
+func maxResultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:  "The maximum number of results to return. By default, all results are returned.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+}
+
+// paginationOptionsFromResourceData returns the pagination options for the `max_results` attribute of a data source.
+func paginationOptionsFromResourceData(d *schema.ResourceData) paginationOptions {
+	return paginationOptions{MaxResults: d.Get("max_results").(int)}
+}
+
+// paginate lists all pages of a GitLab list endpoint, up to the limits of the given options,
+// and impersonates the user set with `withSudo` for every request.
+func paginate[T any](ctx context.Context, options paginationOptions, listPage pager.ListPageFunc[T]) ([]T, error) {
+	options.ContextOption = withContext
+	return pager.Paginate(ctx, options, listPage)
+}
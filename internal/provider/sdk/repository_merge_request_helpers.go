@@ -0,0 +1,327 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+// repositoryMergeRequestSchema returns the `merge_request` block of the repository file resources, which propose their
+// changes with a merge request instead of committing them to the branch directly.
+func repositoryMergeRequestSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Propose the changes with a merge request into the `branch` instead of committing them to the branch directly, e.g. because the branch is protected. " +
+			"The changes are committed to a generated source branch, which is created from the `branch`. " +
+			"Further changes are committed to the same source branch as long as the merge request is open. " +
+			"Once the merge request is merged or closed, the files are read from the `branch` again, so that the changes of a merge request which has been closed without merging are proposed again. " +
+			"Removing the block closes the open merge request and commits the changes to the `branch` directly. " +
+			"**Note**: on destroy, the open merge request is closed, but the deletion of files which have already been merged is only proposed with another merge request. " +
+			"Terraform doesn't track that merge request, it only returns a warning with its URL, so the files remain on the `branch` until it's merged.",
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"title": {
+					Description: "The title of the merge request. Defaults to the `commit_message`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"description": {
+					Description: "The description of the merge request.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"labels": {
+					Description: "The labels of the merge request.",
+					Type:        schema.TypeSet,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Optional:    true,
+				},
+				"assignee_ids": {
+					Description: "The IDs of the users to assign the merge request to.",
+					Type:        schema.TypeSet,
+					Elem:        &schema.Schema{Type: schema.TypeInt},
+					Optional:    true,
+				},
+				"auto_merge": {
+					Description: "Merge the merge request automatically when the pipeline succeeds.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"remove_source_branch": {
+					Description: "Remove the source branch when the merge request is merged.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"source_branch": {
+					Description: "The source branch of the merge request.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"iid": {
+					Description: "The internal ID of the merge request within the project.",
+					Type:        schema.TypeInt,
+					Computed:    true,
+				},
+				"state": {
+					Description: "The state of the merge request, i.e. `opened`, `merged` or `closed`.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"web_url": {
+					Description: "The URL of the merge request.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// hasRepositoryMergeRequest reports whether the resource proposes its changes with a merge request.
+func hasRepositoryMergeRequest(d *schema.ResourceData) bool {
+	return len(d.Get("merge_request").([]interface{})) > 0
+}
+
+// prepareRepositoryChange returns the branch to commit the changes of the resource to. Without the `merge_request` block,
+// that's the `branch` itself. Otherwise, it's the source branch of the open merge request of the resource or a new
+// source branch, which is created from the `branch`.
+func prepareRepositoryChange(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string, branch string) (string, error) {
+	if !hasRepositoryMergeRequest(d) {
+		return branch, nil
+	}
+
+	sourceBranch := fmt.Sprintf("terraform/%s-%d", branch, time.Now().UnixNano())
+	mergeRequest, err := readOpenRepositoryMergeRequest(ctx, d, client, project)
+	if err != nil {
+		return "", err
+	}
+	if mergeRequest != nil {
+		sourceBranch = mergeRequest.SourceBranch
+		_, _, err := client.Branches.GetBranch(project, sourceBranch, gitlab.WithContext(ctx))
+		if err == nil {
+			return sourceBranch, nil
+		}
+		if !is404(err) {
+			return "", err
+		}
+		log.Printf("[DEBUG] source branch %s of merge request !%d of project %s not found, recreating it", sourceBranch, mergeRequest.IID, project)
+	}
+
+	log.Printf("[DEBUG] create source branch %s from %s of project %s", sourceBranch, branch, project)
+	options := &gitlab.CreateBranchOptions{
+		Branch: gitlab.String(sourceBranch),
+		Ref:    gitlab.String(branch),
+	}
+	if _, _, err := client.Branches.CreateBranch(project, options, gitlab.WithContext(ctx)); err != nil {
+		return "", fmt.Errorf("failed to create the source branch %s of the merge request: %w", sourceBranch, err)
+	}
+	return sourceBranch, nil
+}
+
+// finishRepositoryChange opens a merge request with the title for the changes committed to the source branch or updates
+// the open merge request of the resource and stores its attributes. An empty source branch means that nothing has been
+// committed. It's a no-op without the `merge_request` block.
+func finishRepositoryChange(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string, branch string, sourceBranch string, title string) error {
+	if !hasRepositoryMergeRequest(d) {
+		return nil
+	}
+	mergeRequest, err := readOpenRepositoryMergeRequest(ctx, d, client, project)
+	if err != nil {
+		return err
+	}
+	labels := gitlab.Labels(*stringSetToStringSlice(d.Get("merge_request.0.labels").(*schema.Set)))
+	assigneeIDs := intSetToIntSlice(d.Get("merge_request.0.assignee_ids").(*schema.Set))
+	removeSourceBranch := d.Get("merge_request.0.remove_source_branch").(bool)
+
+	switch {
+	case mergeRequest != nil:
+		iid := mergeRequest.IID
+		log.Printf("[DEBUG] update merge request !%d of project %s", iid, project)
+		options := &gitlab.UpdateMergeRequestOptions{
+			Title:              gitlab.String(title),
+			Description:        gitlab.String(d.Get("merge_request.0.description").(string)),
+			Labels:             &labels,
+			AssigneeIDs:        assigneeIDs,
+			RemoveSourceBranch: gitlab.Bool(removeSourceBranch),
+		}
+		mergeRequest, _, err = client.MergeRequests.UpdateMergeRequest(project, iid, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to update merge request !%d: %w", iid, err)
+		}
+	case sourceBranch != "":
+		log.Printf("[DEBUG] create merge request from %s into %s of project %s", sourceBranch, branch, project)
+		options := &gitlab.CreateMergeRequestOptions{
+			Title:              gitlab.String(title),
+			Description:        gitlab.String(d.Get("merge_request.0.description").(string)),
+			SourceBranch:       gitlab.String(sourceBranch),
+			TargetBranch:       gitlab.String(branch),
+			Labels:             &labels,
+			AssigneeIDs:        assigneeIDs,
+			RemoveSourceBranch: gitlab.Bool(removeSourceBranch),
+		}
+		mergeRequest, _, err = client.MergeRequests.CreateMergeRequest(project, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to create the merge request from %s into %s: %w", sourceBranch, branch, err)
+		}
+	default:
+		return nil
+	}
+
+	autoMerge := d.Get("merge_request.0.auto_merge").(bool)
+	switch {
+	case autoMerge && !mergeRequest.MergeWhenPipelineSucceeds:
+		log.Printf("[DEBUG] enable auto-merge for merge request !%d of project %s", mergeRequest.IID, project)
+		options := &gitlab.AcceptMergeRequestOptions{
+			MergeWhenPipelineSucceeds: gitlab.Bool(true),
+			ShouldRemoveSourceBranch:  gitlab.Bool(removeSourceBranch),
+		}
+		iid := mergeRequest.IID
+		mergeRequest, err = acceptGitlabMergeRequest(ctx, client, project, iid, options)
+		if err != nil {
+			return fmt.Errorf("failed to enable auto-merge for merge request !%d: %w", iid, err)
+		}
+	case !autoMerge && mergeRequest.MergeWhenPipelineSucceeds:
+		log.Printf("[DEBUG] cancel auto-merge for merge request !%d of project %s", mergeRequest.IID, project)
+		iid := mergeRequest.IID
+		mergeRequest, _, err = client.MergeRequests.CancelMergeWhenPipelineSucceeds(project, iid, gitlab.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to cancel auto-merge for merge request !%d: %w", iid, err)
+		}
+	}
+
+	return setRepositoryMergeRequest(d, mergeRequest)
+}
+
+// repositoryMergeRequestTitle returns the configured title of the merge request, which defaults to the commit message.
+func repositoryMergeRequestTitle(d *schema.ResourceData) string {
+	if title, ok := d.GetOk("merge_request.0.title"); ok {
+		return title.(string)
+	}
+	return d.Get("commit_message").(string)
+}
+
+// readRepositoryMergeRequest refreshes the merge request of the resource and returns the ref to read the files from,
+// which is the source branch while the merge request is open and the `branch` otherwise.
+func readRepositoryMergeRequest(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string, branch string) (string, error) {
+	iid := d.Get("merge_request.0.iid").(int)
+	if !hasRepositoryMergeRequest(d) || iid == 0 {
+		return branch, nil
+	}
+
+	mergeRequest, _, err := client.MergeRequests.GetMergeRequest(project, iid, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		if !is404(err) {
+			return "", err
+		}
+		log.Printf("[DEBUG] merge request !%d of project %s not found", iid, project)
+		mergeRequest = &gitlab.MergeRequest{}
+	}
+	if err := setRepositoryMergeRequest(d, mergeRequest); err != nil {
+		return "", err
+	}
+
+	if mergeRequest.State == "opened" {
+		return mergeRequest.SourceBranch, nil
+	}
+	return branch, nil
+}
+
+// closeRepositoryMergeRequest closes the open merge request of the resource and deletes its source branch,
+// because its changes have never been merged.
+func closeRepositoryMergeRequest(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string) error {
+	_, err := closeOpenRepositoryMergeRequest(ctx, client, project, d.Get("merge_request.0.iid").(int))
+	return err
+}
+
+// closeRemovedRepositoryMergeRequest closes the open merge request of the resource if the `merge_request` block has been
+// removed, because the changes are committed to the `branch` directly from now on. It reports whether it has been closed.
+func closeRemovedRepositoryMergeRequest(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string) (bool, error) {
+	if !d.HasChange("merge_request") || hasRepositoryMergeRequest(d) {
+		return false, nil
+	}
+	old, _ := d.GetChange("merge_request")
+	values, ok := old.([]interface{})[0].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	return closeOpenRepositoryMergeRequest(ctx, client, project, values["iid"].(int))
+}
+
+// closeOpenRepositoryMergeRequest closes the merge request with the internal ID if it's still open and deletes its
+// source branch. It reports whether it has been closed.
+func closeOpenRepositoryMergeRequest(ctx context.Context, client *gitlab.Client, project string, iid int) (bool, error) {
+	mergeRequest, err := readOpenRepositoryMergeRequestByIID(ctx, client, project, iid)
+	if err != nil || mergeRequest == nil {
+		return false, err
+	}
+
+	log.Printf("[DEBUG] close merge request !%d of project %s", mergeRequest.IID, project)
+	options := &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.String("close"),
+	}
+	if _, _, err := client.MergeRequests.UpdateMergeRequest(project, mergeRequest.IID, options, gitlab.WithContext(ctx)); err != nil {
+		return false, fmt.Errorf("failed to close merge request !%d: %w", mergeRequest.IID, err)
+	}
+	if _, err := client.Branches.DeleteBranch(project, mergeRequest.SourceBranch, gitlab.WithContext(ctx)); err != nil && !is404(err) {
+		return true, fmt.Errorf("failed to delete the source branch %s of merge request !%d: %w", mergeRequest.SourceBranch, mergeRequest.IID, err)
+	}
+	return true, nil
+}
+
+// repositoryDeletionMergeRequestWarning returns the warning that the deletion of the files on the `branch` has only been
+// proposed with the merge request of the resource, which Terraform doesn't track anymore after the destroy.
+func repositoryDeletionMergeRequestWarning(d *schema.ResourceData, branch string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The deletion has only been proposed with merge request !%d", d.Get("merge_request.0.iid").(int)),
+		Detail: fmt.Sprintf("The resource has been removed from the state, but its files remain on branch %q until the merge request %s is merged. "+
+			"Terraform doesn't track the merge request.", branch, d.Get("merge_request.0.web_url").(string)),
+	}}
+}
+
+// readOpenRepositoryMergeRequest returns the merge request of the resource if it's still open.
+func readOpenRepositoryMergeRequest(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string) (*gitlab.MergeRequest, error) {
+	return readOpenRepositoryMergeRequestByIID(ctx, client, project, d.Get("merge_request.0.iid").(int))
+}
+
+// readOpenRepositoryMergeRequestByIID returns the merge request with the internal ID if it's still open.
+func readOpenRepositoryMergeRequestByIID(ctx context.Context, client *gitlab.Client, project string, iid int) (*gitlab.MergeRequest, error) {
+	if iid == 0 {
+		return nil, nil
+	}
+	mergeRequest, _, err := client.MergeRequests.GetMergeRequest(project, iid, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		if is404(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read merge request !%d: %w", iid, err)
+	}
+	if mergeRequest.State != "opened" {
+		return nil, nil
+	}
+	return mergeRequest, nil
+}
+
+// setRepositoryMergeRequest stores the computed attributes of the merge request in the `merge_request` block.
+func setRepositoryMergeRequest(d *schema.ResourceData, mergeRequest *gitlab.MergeRequest) error {
+	values := make(map[string]interface{})
+	if v, ok := d.Get("merge_request").([]interface{})[0].(map[string]interface{}); ok {
+		for k, value := range v {
+			values[k] = value
+		}
+	}
+	values["source_branch"] = mergeRequest.SourceBranch
+	values["iid"] = mergeRequest.IID
+	values["state"] = mergeRequest.State
+	values["web_url"] = mergeRequest.WebURL
+	return d.Set("merge_request", []interface{}{values})
+}
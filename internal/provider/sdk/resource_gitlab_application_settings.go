@@ -38,7 +38,7 @@ var _ = registerResource("gitlab_application_settings", func() *schema.Resource
 })
 
 func resourceGitlabApplicationSettingsSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	log.Printf("[DEBUG] update GitLab Application Settings")
 	options := gitlabApplicationSettingsToUpdateOptions(d)
@@ -58,7 +58,7 @@ func resourceGitlabApplicationSettingsRead(ctx context.Context, d *schema.Resour
 		return diag.Errorf("The `gitlab_application_settings` resource can only exist once and requires the id to be `gitlab`")
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] read GitLab Application settings")
 	settings, _, err := client.Settings.GetSettings(gitlab.WithContext(ctx))
 	if err != nil {
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -18,11 +19,13 @@ var _ = registerResource("gitlab_branch", func() *schema.Resource {
 
 		CreateContext: resourceGitlabBranchCreate,
 		ReadContext:   resourceGitlabBranchRead,
+		// all other attributes force a new branch, a change of `adopt_existing` only updates the state
+		UpdateContext: resourceGitlabBranchRead,
 		DeleteContext: resourceGitlabBranchDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: map[string]*schema.Schema{
+		Schema: constructSchema(map[string]*schema.Schema{
 			"name": {
 				Description: "The name for this branch.",
 				Type:        schema.TypeString,
@@ -83,7 +86,7 @@ var _ = registerResource("gitlab_branch", func() *schema.Resource {
 				Set:         schema.HashResource(commitSchema),
 				Elem:        commitSchema,
 			},
-		},
+		}, adoptExistingSchema()),
 	}
 })
 
@@ -150,7 +153,7 @@ var commitSchema = &schema.Resource{
 }
 
 func resourceGitlabBranchCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	name := d.Get("name").(string)
 	project := d.Get("project").(string)
 	ref := d.Get("ref").(string)
@@ -162,15 +165,51 @@ func resourceGitlabBranchCreate(ctx context.Context, d *schema.ResourceData, met
 	branch, resp, err := client.Branches.CreateBranch(project, branchOptions, gitlab.WithContext(ctx))
 	if err != nil {
 		log.Printf("[DEBUG] failed to create gitlab branch %v response %v", branch, resp)
-		return diag.FromErr(err)
+		if !isAlreadyExistsError(err) || !shouldAdoptExisting(d, meta) {
+			return diag.FromErr(err)
+		}
+		// the existing branch is kept as is, the ref is only used to create a branch
+		log.Printf("[DEBUG] adopt existing gitlab branch %s for project %s", name, project)
+		diags, err := checkAdoptedGitlabBranchRef(ctx, client, project, name, ref)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("ref", ref)
+		d.SetId(buildTwoPartID(&project, &name))
+		return append(diags, resourceGitlabBranchRead(ctx, d, meta)...)
 	}
 	d.Set("ref", ref)
 	d.SetId(buildTwoPartID(&project, &name))
 	return resourceGitlabBranchRead(ctx, d, meta)
 }
 
+// checkAdoptedGitlabBranchRef returns a warning if the adopted branch doesn't point to the commit of the configured ref,
+// e.g. because it has been created from another ref or has new commits. The branch is not reset to the ref.
+func checkAdoptedGitlabBranchRef(ctx context.Context, client *gitlab.Client, project string, name string, ref string) (diag.Diagnostics, error) {
+	branch, _, err := client.Branches.GetBranch(project, name, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	commit, _, err := client.Commits.GetCommit(project, ref, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if branch.Commit == nil || branch.Commit.ID != commit.ID {
+		head := ""
+		if branch.Commit != nil {
+			head = branch.Commit.ID
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The adopted branch %q doesn't point to ref %q", name, ref),
+			Detail:   fmt.Sprintf("The existing branch points to commit %s, but ref %q points to commit %s. The branch is kept as is, it's not reset to the ref.", head, ref, commit.ID),
+		}}, nil
+	}
+	return nil, nil
+}
+
 func resourceGitlabBranchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, name, err := parseTwoPartID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -205,7 +244,7 @@ func resourceGitlabBranchRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabBranchDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, name, err := parseTwoPartID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
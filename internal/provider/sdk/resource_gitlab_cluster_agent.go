@@ -38,7 +38,7 @@ var _ = registerResource("gitlab_cluster_agent", func() *schema.Resource {
 })
 
 func resourceGitlabClusterAgentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	options := gitlab.RegisterAgentOptions{
@@ -56,7 +56,7 @@ func resourceGitlabClusterAgentCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabClusterAgentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, agentID, err := resourceGitlabClusterAgentParseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -81,7 +81,7 @@ func resourceGitlabClusterAgentRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabClusterAgentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, agentID, err := resourceGitlabClusterAgentParseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -34,7 +34,7 @@ var _ = registerResource("gitlab_cluster_agent_token", func() *schema.Resource {
 })
 
 func resourceGitlabClusterAgentTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	agentID := d.Get("agent_id").(int)
@@ -59,7 +59,7 @@ func resourceGitlabClusterAgentTokenCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabClusterAgentTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, agentID, tokenID, err := resourceGitlabClusterAgentTokenParseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -84,7 +84,7 @@ func resourceGitlabClusterAgentTokenRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabClusterAgentTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, agentID, tokenID, err := resourceGitlabClusterAgentTokenParseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
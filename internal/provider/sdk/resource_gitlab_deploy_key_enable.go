@@ -61,7 +61,7 @@ var _ = registerResource("gitlab_deploy_key_enable", func() *schema.Resource {
 })
 
 func resourceGitlabDeployKeyEnableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	key_id, err := strconv.Atoi(d.Get("key_id").(string))
@@ -90,7 +90,7 @@ func resourceGitlabDeployKeyEnableCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabDeployKeyEnableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project, deployKeyID, err := resourceGitLabDeployKeyEnableParseId(d.Id())
 	if err != nil {
@@ -119,7 +119,7 @@ func resourceGitlabDeployKeyEnableRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabDeployKeyEnableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project, deployKeyID, err := resourceGitLabDeployKeyEnableParseId(d.Id())
 	if err != nil {
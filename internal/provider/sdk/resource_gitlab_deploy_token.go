@@ -102,7 +102,7 @@ func expiresAtSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
 }
 
 func resourceGitlabDeployTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, isProject := d.GetOk("project")
 	group, isGroup := d.GetOk("group")
 
@@ -160,7 +160,7 @@ func resourceGitlabDeployTokenCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabDeployTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, isProject := d.GetOk("project")
 	group, isGroup := d.GetOk("group")
 	deployTokenID, err := strconv.Atoi(d.Id())
@@ -230,7 +230,7 @@ func resourceGitlabDeployTokenRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabDeployTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, isProject := d.GetOk("project")
 	group, isGroup := d.GetOk("group")
 	deployTokenID, err := strconv.Atoi(d.Id())
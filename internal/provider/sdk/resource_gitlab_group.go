@@ -176,7 +176,7 @@ var _ = registerResource("gitlab_group", func() *schema.Resource {
 })
 
 func resourceGitlabGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	options := &gitlab.CreateGroupOptions{
 		Name:                 gitlab.String(d.Get("name").(string)),
 		LFSEnabled:           gitlab.Bool(d.Get("lfs_enabled").(bool)),
@@ -297,7 +297,7 @@ func resourceGitlabGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] read gitlab group %s", d.Id())
 
 	group, _, err := client.Groups.GetGroup(d.Id(), nil, gitlab.WithContext(ctx))
@@ -346,7 +346,7 @@ func resourceGitlabGroupRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceGitlabGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	options := &gitlab.UpdateGroupOptions{}
 
@@ -480,7 +480,7 @@ func transferSubGroup(ctx context.Context, d *schema.ResourceData, client *gitla
 }
 
 func resourceGitlabGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] Delete gitlab group %s", d.Id())
 
 	_, err := client.Groups.DeleteGroup(d.Id(), gitlab.WithContext(ctx))
@@ -494,7 +494,7 @@ func resourceGitlabGroupDelete(ctx context.Context, d *schema.ResourceData, meta
 		Pending: []string{"Deleting"},
 		Target:  []string{"Deleted"},
 		Refresh: func() (interface{}, string, error) {
-			out, response, err := client.Groups.GetGroup(d.Id(), nil, gitlab.WithContext(ctx))
+			out, response, err := client.Groups.GetGroup(d.Id(), nil, gitlab.WithContext(pollingContext(ctx)))
 			if err != nil {
 				if response != nil && response.StatusCode == 404 {
 					return out, "Deleted", nil
@@ -123,7 +123,7 @@ var _ = registerResource("gitlab_group_cluster", func() *schema.Resource {
 })
 
 func resourceGitlabGroupClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 
 	pk := gitlab.AddGroupPlatformKubernetesOptions{
@@ -173,7 +173,7 @@ func resourceGitlabGroupClusterCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabGroupClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group, clusterId, err := groupIdAndClusterIdFromId(d.Id())
 	if err != nil {
@@ -215,7 +215,7 @@ func resourceGitlabGroupClusterRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabGroupClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group, clusterId, err := groupIdAndClusterIdFromId(d.Id())
 	if err != nil {
@@ -270,7 +270,7 @@ func resourceGitlabGroupClusterUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabGroupClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group, clusterId, err := groupIdAndClusterIdFromId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -26,7 +26,7 @@ var _ = registerResource("gitlab_group_label", func() *schema.Resource {
 			StateContext: resourceGitlabGroupLabelImporter,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: constructSchema(map[string]*schema.Schema{
 			"group": {
 				Description: "The name or id of the group to add the label to.",
 				Type:        schema.TypeString,
@@ -48,12 +48,12 @@ var _ = registerResource("gitlab_group_label", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
-		},
+		}, adoptExistingSchema()),
 	}
 })
 
 func resourceGitlabGroupLabelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 	options := &gitlab.CreateGroupLabelOptions{
 		Name:  gitlab.String(d.Get("name").(string)),
@@ -68,6 +68,11 @@ func resourceGitlabGroupLabelCreate(ctx context.Context, d *schema.ResourceData,
 
 	label, _, err := client.GroupLabels.CreateGroupLabel(group, options, gitlab.WithContext(ctx))
 	if err != nil {
+		if isAlreadyExistsError(err) && shouldAdoptExisting(d, meta) {
+			log.Printf("[DEBUG] adopt existing gitlab group label %s", *options.Name)
+			d.SetId(*options.Name)
+			return resourceGitlabGroupLabelUpdate(ctx, d, meta)
+		}
 		return diag.FromErr(err)
 	}
 
@@ -76,7 +81,7 @@ func resourceGitlabGroupLabelCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabGroupLabelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 	labelName := d.Id()
 	log.Printf("[DEBUG] read gitlab group label %s/%s", group, labelName)
@@ -97,7 +102,7 @@ func resourceGitlabGroupLabelRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGitlabGroupLabelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 	options := &gitlab.UpdateGroupLabelOptions{
 		Name:  gitlab.String(d.Get("name").(string)),
@@ -119,7 +124,7 @@ func resourceGitlabGroupLabelUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabGroupLabelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 	log.Printf("[DEBUG] Delete gitlab group label %s", d.Id())
 	options := &gitlab.DeleteGroupLabelOptions{
@@ -131,7 +136,7 @@ func resourceGitlabGroupLabelDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabGroupLabelImporter(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	parts := strings.SplitN(d.Id(), ":", 2)
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid label id (should be <group ID>:<label name>): %s", d.Id())
@@ -30,7 +30,7 @@ var _ = registerResource("gitlab_group_membership", func() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: constructSchema(map[string]*schema.Schema{
 			"group_id": {
 				Description: "The id of the group.",
 				Type:        schema.TypeString,
@@ -77,12 +77,12 @@ var _ = registerResource("gitlab_group_membership", func() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
-		},
+		}, adoptExistingSchema()),
 	}
 })
 
 func resourceGitlabGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var userId int
 
@@ -127,24 +127,30 @@ func resourceGitlabGroupMembershipCreate(ctx context.Context, d *schema.Resource
 
 	_, resp, err := client.GroupMembers.AddGroupMember(groupId, options, gitlab.WithContext(ctx))
 	if err != nil {
-		user, _, err := client.Users.CurrentUser()
-		if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusConflict {
 			return diag.FromErr(err)
 		}
 
-		// The user that creates the group is always added automatically as member
-		if resp != nil && resp.StatusCode == http.StatusConflict && user.ID == userId {
-			options := gitlab.EditGroupMemberOptions{
-				AccessLevel: &accessLevelId,
-				ExpiresAt:   &expiresAt,
+		// The user that creates the group is always added automatically as member,
+		// other existing members are only adopted if configured.
+		if !shouldAdoptExisting(d, meta) {
+			user, _, userErr := client.Users.CurrentUser()
+			if userErr != nil {
+				return diag.FromErr(userErr)
 			}
-			log.Printf("[DEBUG] update gitlab group membership %v for %s", userId, groupId)
-
-			_, _, err := client.GroupMembers.EditGroupMember(groupId, userId, &options)
-			if err != nil {
+			if user.ID != userId {
 				return diag.FromErr(err)
 			}
-		} else {
+		}
+
+		options := gitlab.EditGroupMemberOptions{
+			AccessLevel: &accessLevelId,
+			ExpiresAt:   &expiresAt,
+		}
+		log.Printf("[DEBUG] update gitlab group membership %v for %s", userId, groupId)
+
+		_, _, err := client.GroupMembers.EditGroupMember(groupId, userId, &options)
+		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -154,7 +160,7 @@ func resourceGitlabGroupMembershipCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	log.Printf("[DEBUG] read gitlab group groupMember %s", id)
 
@@ -190,7 +196,7 @@ func groupIdAndUserIdFromId(id string) (string, int, error) {
 }
 
 func resourceGitlabGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var userId int
 
@@ -241,7 +247,7 @@ func resourceGitlabGroupMembershipUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	id := d.Id()
 	groupId, userId, err := groupIdAndUserIdFromId(id)
@@ -57,7 +57,7 @@ var _ = registerResource("gitlab_group_saml_link", func() *schema.Resource {
 })
 
 func resourceGitlabGroupSamlLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group := d.Get("group").(string)
 	samlGroupName := d.Get("saml_group_name").(string)
@@ -79,7 +79,7 @@ func resourceGitlabGroupSamlLinkCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabGroupSamlLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group, samlGroupName, parse_err := parseTwoPartID(d.Id())
 	if parse_err != nil {
 		return diag.FromErr(parse_err)
@@ -105,7 +105,7 @@ func resourceGitlabGroupSamlLinkRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabGroupSamlLinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group, samlGroupName, parse_err := parseTwoPartID(d.Id())
 	if parse_err != nil {
 		return diag.FromErr(parse_err)
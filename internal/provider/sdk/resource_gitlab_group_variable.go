@@ -25,12 +25,13 @@ var _ = registerResource("gitlab_group_variable", func() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: gitlabGroupVariableGetSchema(),
+		Schema:        constructSchema(gitlabGroupVariableGetSchema(), adoptExistingSchema()),
+		CustomizeDiff: gitlabVariableDiff,
 	}
 })
 
 func resourceGitlabGroupVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group := d.Get("group").(string)
 	key := d.Get("key").(string)
@@ -50,19 +51,24 @@ func resourceGitlabGroupVariableCreate(ctx context.Context, d *schema.ResourceDa
 	}
 	log.Printf("[DEBUG] create gitlab group variable %s/%s", group, key)
 
+	keyScope := fmt.Sprintf("%s:%s", key, environmentScope)
+
 	_, _, err := client.GroupVariables.CreateVariable(group, &options, gitlab.WithContext(ctx))
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		if isAlreadyExistsError(err) && shouldAdoptExisting(d, meta) {
+			log.Printf("[DEBUG] adopt existing gitlab group variable %s/%s", group, key)
+			d.SetId(buildTwoPartID(&group, &keyScope))
+			return resourceGitlabGroupVariableUpdate(ctx, d, meta)
+		}
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
-	keyScope := fmt.Sprintf("%s:%s", key, environmentScope)
-
 	d.SetId(buildTwoPartID(&group, &keyScope))
 	return resourceGitlabGroupVariableRead(ctx, d, meta)
 }
 
 func resourceGitlabGroupVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group, key, err := parseTwoPartID(d.Id())
 	if err != nil {
@@ -90,7 +96,7 @@ func resourceGitlabGroupVariableRead(ctx context.Context, d *schema.ResourceData
 			d.SetId("")
 			return nil
 		}
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	stateMap := gitlabGroupVariableToStateMap(group, v)
@@ -101,7 +107,7 @@ func resourceGitlabGroupVariableRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabGroupVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	group := d.Get("group").(string)
 	key := d.Get("key").(string)
@@ -128,13 +134,13 @@ func resourceGitlabGroupVariableUpdate(ctx context.Context, d *schema.ResourceDa
 		withEnvironmentScopeFilter(ctx, environmentScope),
 	)
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 	return resourceGitlabGroupVariableRead(ctx, d, meta)
 }
 
 func resourceGitlabGroupVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	group := d.Get("group").(string)
 	key := d.Get("key").(string)
 	environmentScope := d.Get("environment_scope").(string)
@@ -147,7 +153,7 @@ func resourceGitlabGroupVariableDelete(ctx context.Context, d *schema.ResourceDa
 		withEnvironmentScopeFilter(ctx, environmentScope),
 	)
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	return nil
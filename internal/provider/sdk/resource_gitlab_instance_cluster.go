@@ -122,7 +122,7 @@ var _ = registerResource("gitlab_instance_cluster", func() *schema.Resource {
 })
 
 func resourceGitlabInstanceClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	pk := gitlab.AddPlatformKubernetesOptions{
 		APIURL: gitlab.String(d.Get("kubernetes_api_url").(string)),
@@ -171,7 +171,7 @@ func resourceGitlabInstanceClusterCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabInstanceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	clusterId, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -213,7 +213,7 @@ func resourceGitlabInstanceClusterRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabInstanceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	clusterId, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -272,7 +272,7 @@ func resourceGitlabInstanceClusterUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabInstanceClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	clusterId, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
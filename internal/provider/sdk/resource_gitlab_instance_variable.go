@@ -23,12 +23,13 @@ var _ = registerResource("gitlab_instance_variable", func() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: gitlabInstanceVariableGetSchema(),
+		Schema:        gitlabInstanceVariableGetSchema(),
+		CustomizeDiff: gitlabVariableDiff,
 	}
 })
 
 func resourceGitlabInstanceVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	key := d.Get("key").(string)
 	value := d.Get("value").(string)
@@ -47,7 +48,7 @@ func resourceGitlabInstanceVariableCreate(ctx context.Context, d *schema.Resourc
 
 	_, _, err := client.InstanceVariables.CreateVariable(&options, gitlab.WithContext(ctx))
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	d.SetId(key)
@@ -55,7 +56,7 @@ func resourceGitlabInstanceVariableCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceGitlabInstanceVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	key := d.Id()
 
@@ -68,7 +69,7 @@ func resourceGitlabInstanceVariableRead(ctx context.Context, d *schema.ResourceD
 			d.SetId("")
 			return nil
 		}
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	d.Set("key", v.Key)
@@ -85,7 +86,7 @@ func resourceGitlabInstanceVariableRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGitlabInstanceVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	key := d.Get("key").(string)
 	value := d.Get("value").(string)
@@ -103,19 +104,19 @@ func resourceGitlabInstanceVariableUpdate(ctx context.Context, d *schema.Resourc
 
 	_, _, err := client.InstanceVariables.UpdateVariable(key, options, gitlab.WithContext(ctx))
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 	return resourceGitlabInstanceVariableRead(ctx, d, meta)
 }
 
 func resourceGitlabInstanceVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	key := d.Get("key").(string)
 	log.Printf("[DEBUG] Delete gitlab instance level CI variable %s", key)
 
 	_, err := client.InstanceVariables.RemoveVariable(key, gitlab.WithContext(ctx))
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	return nil
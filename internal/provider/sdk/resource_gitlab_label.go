@@ -29,7 +29,7 @@ var _ = registerResource("gitlab_label", func() *schema.Resource {
 			StateContext: resourceGitlabLabelImporter,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: constructSchema(map[string]*schema.Schema{
 			"project": {
 				Description: "The name or id of the project to add the label to.",
 				Type:        schema.TypeString,
@@ -51,12 +51,12 @@ var _ = registerResource("gitlab_label", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
-		},
+		}, adoptExistingSchema()),
 	}
 })
 
 func resourceGitlabLabelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	options := &gitlab.CreateLabelOptions{
 		Name:  gitlab.String(d.Get("name").(string)),
@@ -71,6 +71,11 @@ func resourceGitlabLabelCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	label, _, err := client.Labels.CreateLabel(project, options, gitlab.WithContext(ctx))
 	if err != nil {
+		if isAlreadyExistsError(err) && shouldAdoptExisting(d, meta) {
+			log.Printf("[DEBUG] adopt existing gitlab label %s", *options.Name)
+			d.SetId(*options.Name)
+			return resourceGitlabLabelUpdate(ctx, d, meta)
+		}
 		return diag.FromErr(err)
 	}
 
@@ -80,7 +85,7 @@ func resourceGitlabLabelCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabLabelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	labelName := d.Id()
 	log.Printf("[DEBUG] read gitlab label %s/%s", project, labelName)
@@ -102,7 +107,7 @@ func resourceGitlabLabelRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceGitlabLabelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	options := &gitlab.UpdateLabelOptions{
 		Name:  gitlab.String(d.Get("name").(string)),
@@ -124,7 +129,7 @@ func resourceGitlabLabelUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabLabelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	log.Printf("[DEBUG] Delete gitlab label %s", d.Id())
 	options := &gitlab.DeleteLabelOptions{
@@ -140,7 +145,7 @@ func resourceGitlabLabelDelete(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabLabelImporter(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	parts := strings.SplitN(d.Id(), ":", 2)
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid label id (should be <project ID>.<label name>): %s", d.Id())
@@ -64,7 +64,7 @@ var _ = registerResource("gitlab_managed_license", func() *schema.Resource {
 })
 
 func resourceGitlabManagedLicenseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	approvalStatus, err := stringToApprovalStatus(ctx, client, d.Get("approval_status").(string))
@@ -91,7 +91,7 @@ func resourceGitlabManagedLicenseCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGitlabManagedLicenseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, licenseId, err := projectIdAndLicenseIdFromId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -107,7 +107,7 @@ func resourceGitlabManagedLicenseDelete(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGitlabManagedLicenseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, licenseId, err := projectIdAndLicenseIdFromId(d.Id())
 	if err != nil {
 		diag.FromErr(err)
@@ -132,7 +132,7 @@ func resourceGitlabManagedLicenseRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabManagedLicenseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, licenseId, err := projectIdAndLicenseIdFromId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -39,7 +39,7 @@ var _ = registerResource("gitlab_personal_access_token", func() *schema.Resource
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: map[string]*schema.Schema{
+		Schema: constructSchema(map[string]*schema.Schema{
 			"user_id": {
 				Description: "The id of the user.",
 				Type:        schema.TypeInt,
@@ -90,12 +90,13 @@ var _ = registerResource("gitlab_personal_access_token", func() *schema.Resource
 				Computed:    true,
 				Sensitive:   true,
 			},
-		},
+		}, sudoSchema(true)),
 	}
 })
 
 func resourceGitlabPersonalAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	currentUserAdmin, err := isCurrentUserAdmin(ctx, client)
 	if err != nil {
@@ -123,7 +124,7 @@ func resourceGitlabPersonalAccessTokenCreate(ctx context.Context, d *schema.Reso
 		options.ExpiresAt = parsedExpiresAt
 	}
 
-	personalAccessToken, _, err := client.Users.CreatePersonalAccessToken(userID, options, gitlab.WithContext(ctx))
+	personalAccessToken, _, err := client.Users.CreatePersonalAccessToken(userID, options, withContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -136,7 +137,8 @@ func resourceGitlabPersonalAccessTokenCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceGitlabPersonalAccessTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	userID, tokenID, err := resourceGitLabPersonalAccessTokenParseId(d.Id())
 	if err != nil {
@@ -174,7 +176,8 @@ func resourceGitlabPersonalAccessTokenRead(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabPersonalAccessTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	_, tokenID, err := resourceGitLabPersonalAccessTokenParseId(d.Id())
 	if err != nil {
@@ -182,7 +185,7 @@ func resourceGitlabPersonalAccessTokenDelete(ctx context.Context, d *schema.Reso
 	}
 
 	log.Printf("[DEBUG] Delete gitlab PersonalAccessToken %s", d.Id())
-	_, err = client.PersonalAccessTokens.RevokePersonalAccessToken(tokenID, gitlab.WithContext(ctx))
+	_, err = client.PersonalAccessTokens.RevokePersonalAccessToken(tokenID, withContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -205,7 +208,7 @@ func resourceGitlabPersonalAccessTokenFind(ctx context.Context, client *gitlab.C
 
 	page := 1
 	for page != 0 {
-		personalAccessTokens, response, err := client.PersonalAccessTokens.ListPersonalAccessTokens(&gitlab.ListPersonalAccessTokensOptions{UserID: &userId, ListOptions: gitlab.ListOptions{Page: page, PerPage: 100}}, gitlab.WithContext(ctx))
+		personalAccessTokens, response, err := client.PersonalAccessTokens.ListPersonalAccessTokens(&gitlab.ListPersonalAccessTokensOptions{UserID: &userId, ListOptions: gitlab.ListOptions{Page: page, PerPage: 100}}, withContext(ctx))
 		if err != nil {
 			return nil, err
 		}
@@ -709,7 +709,7 @@ branch using a ` + "`DELETE`" + ` request. Then define the desired branch protec
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: constructSchema(resourceGitLabProjectSchema, avatarableSchema(), map[string]*schema.Schema{
+		Schema: constructSchema(resourceGitLabProjectSchema, avatarableSchema(), sudoSchema(false), map[string]*schema.Schema{
 			"skip_wait_for_default_branch_protection": {
 				Description: `If ` + "`true`" + `, the default behavior to wait for the default branch protection to be created is skipped.
 This is necessary if the current user is not an admin and the default branch protection is disabled on an instance-level.
@@ -839,7 +839,8 @@ func resourceGitlabProjectSetToState(ctx context.Context, client *gitlab.Client,
 }
 
 func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	// Project that has either been created or forked
 	var project *gitlab.Project
@@ -1159,7 +1160,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 
 		log.Printf("[DEBUG] create gitlab project %q", *options.Name)
 
-		project, _, err = client.Projects.CreateProject(options, gitlab.WithContext(ctx))
+		project, _, err = client.Projects.CreateProject(options, withContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1189,7 +1190,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		//}
 
 		var err error
-		project, _, err = client.Projects.ForkProject(forkedFromProjectID, &options, gitlab.WithContext(ctx))
+		project, _, err = client.Projects.ForkProject(forkedFromProjectID, &options, withContext(ctx))
 		if err != nil {
 			return diag.Errorf("Unable to fork project %d: %v", forkedFromProjectID, err)
 		}
@@ -1208,7 +1209,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 			Target:  []string{"finished"},
 			Timeout: 10 * time.Minute,
 			Refresh: func() (interface{}, string, error) {
-				status, _, err := client.ProjectImportExport.ImportStatus(d.Id(), gitlab.WithContext(ctx))
+				status, _, err := client.ProjectImportExport.ImportStatus(d.Id(), withContext(pollingContext(ctx)))
 				if err != nil {
 					return nil, "", err
 				}
@@ -1223,7 +1224,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 
 		// Read the project again, so that we can detect the default branch.
 		var err error
-		project, _, err = client.Projects.GetProject(project.ID, nil, gitlab.WithContext(ctx))
+		project, _, err = client.Projects.GetProject(project.ID, nil, withContext(ctx))
 		if err != nil {
 			return diag.Errorf("Failed to get project %q after completing import: %s", d.Id(), err)
 		}
@@ -1231,7 +1232,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 
 	if d.Get("archived").(bool) {
 		// strange as it may seem, this project is created in archived state...
-		if _, _, err := client.Projects.ArchiveProject(d.Id(), gitlab.WithContext(ctx)); err != nil {
+		if _, _, err := client.Projects.ArchiveProject(d.Id(), withContext(ctx)); err != nil {
 			return diag.Errorf("new project %q could not be archived: %s", d.Id(), err)
 		}
 	}
@@ -1265,7 +1266,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 			_, _, err := client.Branches.CreateBranch(project.ID, &gitlab.CreateBranchOptions{
 				Branch: gitlab.String(newDefaultBranch),
 				Ref:    gitlab.String(oldDefaultBranch),
-			}, gitlab.WithContext(ctx))
+			}, withContext(ctx))
 			if err != nil {
 				return diag.Errorf("Failed to create branch %q for project %q: %s", newDefaultBranch, d.Id(), err)
 			}
@@ -1273,7 +1274,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 			log.Printf("[DEBUG] set new default branch to %q for project %q", newDefaultBranch, d.Id())
 			_, _, err = client.Projects.EditProject(project.ID, &gitlab.EditProjectOptions{
 				DefaultBranch: gitlab.String(newDefaultBranch),
-			}, gitlab.WithContext(ctx))
+			}, withContext(ctx))
 			if err != nil {
 				return diag.Errorf("Failed to set default branch to %q for project %q: %s", newDefaultBranch, d.Id(), err)
 			}
@@ -1281,13 +1282,13 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 			log.Printf("[DEBUG] protect new default branch %q for project %q", newDefaultBranch, d.Id())
 			_, _, err = client.ProtectedBranches.ProtectRepositoryBranches(project.ID, &gitlab.ProtectRepositoryBranchesOptions{
 				Name: gitlab.String(newDefaultBranch),
-			}, gitlab.WithContext(ctx))
+			}, withContext(ctx))
 			if err != nil {
 				return diag.Errorf("Failed to protect default branch %q for project %q: %s", newDefaultBranch, d.Id(), err)
 			}
 
 			log.Printf("[DEBUG] check for protection on old default branch %q for project %q", oldDefaultBranch, d.Id())
-			branch, _, err := client.ProtectedBranches.GetProtectedBranch(project.ID, oldDefaultBranch, gitlab.WithContext(ctx))
+			branch, _, err := client.ProtectedBranches.GetProtectedBranch(project.ID, oldDefaultBranch, withContext(ctx))
 			if err != nil && !is404(err) {
 				return diag.Errorf("Failed to check for protected default branch %q for project %q: %v", oldDefaultBranch, d.Id(), err)
 			}
@@ -1295,14 +1296,14 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 				log.Printf("[DEBUG] Default protected branch %q for project %q does not exist", oldDefaultBranch, d.Id())
 			} else {
 				log.Printf("[DEBUG] unprotect old default branch %q for project %q", oldDefaultBranch, d.Id())
-				_, err = client.ProtectedBranches.UnprotectRepositoryBranches(project.ID, oldDefaultBranch, gitlab.WithContext(ctx))
+				_, err = client.ProtectedBranches.UnprotectRepositoryBranches(project.ID, oldDefaultBranch, withContext(ctx))
 				if err != nil {
 					return diag.Errorf("Failed to unprotect undesired default branch %q for project %q: %v", oldDefaultBranch, d.Id(), err)
 				}
 			}
 
 			log.Printf("[DEBUG] delete old default branch %q for project %q", oldDefaultBranch, d.Id())
-			_, err = client.Branches.DeleteBranch(project.ID, oldDefaultBranch, gitlab.WithContext(ctx))
+			_, err = client.Branches.DeleteBranch(project.ID, oldDefaultBranch, withContext(ctx))
 			if err != nil {
 				return diag.Errorf("Failed to clean up undesired default branch %q for project %q: %s", oldDefaultBranch, d.Id(), err)
 			}
@@ -1331,7 +1332,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 				Target:  []string{"true"},
 				Timeout: 2 * time.Minute, //The async action usually completes very quickly, within seconds. Don't wait too long.
 				Refresh: func() (interface{}, string, error) {
-					branch, _, err := client.Branches.GetBranch(project.ID, project.DefaultBranch, gitlab.WithContext(ctx))
+					branch, _, err := client.Branches.GetBranch(project.ID, project.DefaultBranch, withContext(pollingContext(ctx)))
 					if err != nil {
 						if is404(err) {
 							// When we hit a 404 here, it means the default branch wasn't created at all as part of the project
@@ -1682,7 +1683,7 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	if (editProjectOptions != gitlab.EditProjectOptions{}) {
-		if _, _, err := client.Projects.EditProject(d.Id(), &editProjectOptions, gitlab.WithContext(ctx)); err != nil {
+		if _, _, err := client.Projects.EditProject(d.Id(), &editProjectOptions, withContext(ctx)); err != nil {
 			return diag.Errorf("Could not update project %q: %s", d.Id(), err)
 		}
 	}
@@ -1691,10 +1692,11 @@ func resourceGitlabProjectCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceGitlabProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] read gitlab project %s", d.Id())
 
-	project, _, err := client.Projects.GetProject(d.Id(), nil, gitlab.WithContext(ctx))
+	project, _, err := client.Projects.GetProject(d.Id(), nil, withContext(ctx))
 	if err != nil {
 		if is404(err) {
 			log.Printf("[DEBUG] gitlab project %s has already been deleted, removing from state", d.Id())
@@ -1715,7 +1717,7 @@ func resourceGitlabProjectRead(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] read gitlab project %q push rules", d.Id())
 
-	pushRules, _, err := client.Projects.GetProjectPushRules(d.Id(), gitlab.WithContext(ctx))
+	pushRules, _, err := client.Projects.GetProjectPushRules(d.Id(), withContext(ctx))
 	if is404(err) {
 		log.Printf("[DEBUG] Failed to get push rules for project %q: %v", d.Id(), err)
 	} else if err != nil {
@@ -1729,7 +1731,8 @@ func resourceGitlabProjectRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	// Always send the name field, to satisfy the requirement of having one
 	// of the project attributes listed below in the update call
@@ -2035,7 +2038,7 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	if *options != (gitlab.EditProjectOptions{}) {
 		log.Printf("[DEBUG] update gitlab project %s", d.Id())
-		_, _, err := client.Projects.EditProject(d.Id(), options, gitlab.WithContext(ctx))
+		_, _, err := client.Projects.EditProject(d.Id(), options, withContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -2059,7 +2062,7 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 		if removeRelation {
 			// Remove fork relation
-			if _, err := client.Projects.DeleteProjectForkRelation(d.Id(), gitlab.WithContext(ctx)); err != nil {
+			if _, err := client.Projects.DeleteProjectForkRelation(d.Id(), withContext(ctx)); err != nil {
 				return diag.Errorf("unable to remove fork relation from project %q: %v", d.Id(), err)
 			}
 		}
@@ -2067,7 +2070,7 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		// Add fork relationship
 		if createRelation {
 			// Add fork relation
-			if _, _, err := client.Projects.CreateProjectForkRelation(d.Id(), newValue, gitlab.WithContext(ctx)); err != nil {
+			if _, _, err := client.Projects.CreateProjectForkRelation(d.Id(), newValue, withContext(ctx)); err != nil {
 				return diag.Errorf("unable to add fork relation to project %q (to project %d): %v", d.Id(), newValue, err)
 			}
 		}
@@ -2075,7 +2078,7 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	if *transferOptions != (gitlab.TransferProjectOptions{}) {
 		log.Printf("[DEBUG] transferring project %s to namespace %d", d.Id(), transferOptions.Namespace)
-		_, _, err := client.Projects.TransferProject(d.Id(), transferOptions, gitlab.WithContext(ctx))
+		_, _, err := client.Projects.TransferProject(d.Id(), transferOptions, withContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -2083,11 +2086,11 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	if d.HasChange("archived") {
 		if d.Get("archived").(bool) {
-			if _, _, err := client.Projects.ArchiveProject(d.Id(), gitlab.WithContext(ctx)); err != nil {
+			if _, _, err := client.Projects.ArchiveProject(d.Id(), withContext(ctx)); err != nil {
 				return diag.Errorf("project %q could not be archived: %s", d.Id(), err)
 			}
 		} else {
-			if _, _, err := client.Projects.UnarchiveProject(d.Id(), gitlab.WithContext(ctx)); err != nil {
+			if _, _, err := client.Projects.UnarchiveProject(d.Id(), withContext(ctx)); err != nil {
 				return diag.Errorf("project %q could not be unarchived: %s", d.Id(), err)
 			}
 		}
@@ -2108,11 +2111,12 @@ func resourceGitlabProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceGitlabProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	if !d.Get("archive_on_destroy").(bool) {
 		log.Printf("[DEBUG] Delete gitlab project %s", d.Id())
-		_, err := client.Projects.DeleteProject(d.Id(), gitlab.WithContext(ctx))
+		_, err := client.Projects.DeleteProject(d.Id(), withContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -2123,7 +2127,7 @@ func resourceGitlabProjectDelete(ctx context.Context, d *schema.ResourceData, me
 			Pending: []string{"Deleting"},
 			Target:  []string{"Deleted"},
 			Refresh: func() (interface{}, string, error) {
-				out, _, err := client.Projects.GetProject(d.Id(), nil, gitlab.WithContext(ctx))
+				out, _, err := client.Projects.GetProject(d.Id(), nil, withContext(pollingContext(ctx)))
 				if err != nil {
 					if is404(err) {
 						return out, "Deleted", nil
@@ -2150,7 +2154,7 @@ func resourceGitlabProjectDelete(ctx context.Context, d *schema.ResourceData, me
 
 	} else {
 		log.Printf("[DEBUG] Archive gitlab project %s", d.Id())
-		_, _, err := client.Projects.ArchiveProject(d.Id(), gitlab.WithContext(ctx))
+		_, _, err := client.Projects.ArchiveProject(d.Id(), withContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -2162,12 +2166,12 @@ func resourceGitlabProjectDelete(ctx context.Context, d *schema.ResourceData, me
 func editOrAddPushRules(ctx context.Context, client *gitlab.Client, projectID string, d *schema.ResourceData) error {
 	log.Printf("[DEBUG] Editing push rules for project %q", projectID)
 
-	pushRules, _, err := client.Projects.GetProjectPushRules(d.Id(), gitlab.WithContext(ctx))
+	pushRules, _, err := client.Projects.GetProjectPushRules(d.Id(), withContext(ctx))
 	// NOTE: push rules id `0` indicates that there haven't been any push rules set.
 	if err != nil || pushRules.ID == 0 {
 		if addOptions := expandAddProjectPushRuleOptions(d); (gitlab.AddProjectPushRuleOptions{}) != addOptions {
 			log.Printf("[DEBUG] Creating new push rules for project %q", projectID)
-			_, _, err = client.Projects.AddProjectPushRule(projectID, &addOptions, gitlab.WithContext(ctx))
+			_, _, err = client.Projects.AddProjectPushRule(projectID, &addOptions, withContext(ctx))
 			if err != nil {
 				return err
 			}
@@ -2181,7 +2185,7 @@ func editOrAddPushRules(ctx context.Context, client *gitlab.Client, projectID st
 	editOptions := expandEditProjectPushRuleOptions(d, pushRules)
 	if (gitlab.EditProjectPushRuleOptions{}) != editOptions {
 		log.Printf("[DEBUG] Editing existing push rules for project %q", projectID)
-		_, _, err = client.Projects.EditProjectPushRule(projectID, &editOptions, gitlab.WithContext(ctx))
+		_, _, err = client.Projects.EditProjectPushRule(projectID, &editOptions, withContext(ctx))
 		if err != nil {
 			return err
 		}
@@ -2374,7 +2378,7 @@ func namespaceOrPathChanged(ctx context.Context, d *schema.ResourceDiff, meta in
 func expectDefaultBranchProtection(ctx context.Context, client *gitlab.Client, project *gitlab.Project) (bool, error) {
 	// If the project is part of a group it may have default branch protection disabled for its projects
 	if project.Namespace.Kind == "group" {
-		group, _, err := client.Groups.GetGroup(project.Namespace.ID, nil, gitlab.WithContext(ctx))
+		group, _, err := client.Groups.GetGroup(project.Namespace.ID, nil, withContext(ctx))
 		if err != nil {
 			return false, err
 		}
@@ -2389,7 +2393,7 @@ func expectDefaultBranchProtection(ctx context.Context, client *gitlab.Client, p
 
 	if isAdmin {
 		// If the project is not part of a group it may have default branch protection disabled because of the instance-wide application settings
-		settings, _, err := client.Settings.GetSettings(nil, gitlab.WithContext(ctx))
+		settings, _, err := client.Settings.GetSettings(nil, withContext(ctx))
 		if err != nil {
 			return false, err
 		}
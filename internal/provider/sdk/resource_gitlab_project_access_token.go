@@ -97,7 +97,7 @@ var _ = registerResource("gitlab_project_access_token", func() *schema.Resource
 })
 
 func resourceGitlabProjectAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	accessLevelId := accessLevelNameToValue[d.Get("access_level").(string)]
 	project := d.Get("project").(string)
 
@@ -136,7 +136,7 @@ func resourceGitlabProjectAccessTokenRead(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("Error parsing ID: %s", d.Id())
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	projectAccessTokenID, err := strconv.Atoi(PATstring)
 	if err != nil {
@@ -178,7 +178,7 @@ func resourceGitlabProjectAccessTokenDelete(ctx context.Context, d *schema.Resou
 		return diag.Errorf("Error parsing ID: %s", d.Id())
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	projectAccessTokenID, err := strconv.Atoi(patString)
 	if err != nil {
@@ -194,7 +194,7 @@ func resourceGitlabProjectAccessTokenDelete(ctx context.Context, d *schema.Resou
 	log.Printf("[DEBUG] Waiting for ProjectAccessToken %s to finish deleting", d.Id())
 
 	err = resource.RetryContext(ctx, 5*time.Minute, func() *resource.RetryError {
-		_, _, err := client.ProjectAccessTokens.GetProjectAccessToken(project, projectAccessTokenID, gitlab.WithContext(ctx))
+		_, _, err := client.ProjectAccessTokens.GetProjectAccessToken(project, projectAccessTokenID, gitlab.WithContext(pollingContext(ctx)))
 		if err != nil {
 			if is404(err) {
 				return nil
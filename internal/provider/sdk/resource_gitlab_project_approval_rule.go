@@ -98,7 +98,7 @@ func resourceGitlabProjectApprovalRuleCreate(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] Project %s create gitlab project-level rule %+v", project, options)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	rule, _, err := client.Projects.CreateProjectApprovalRule(project, &options, gitlab.WithContext(ctx))
 	if err != nil {
@@ -124,7 +124,7 @@ func resourceGitlabProjectApprovalRuleRead(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	rule, _, err := client.Projects.GetProjectApprovalRule(projectID, ruleID, gitlab.WithContext(ctx))
 	if err != nil {
@@ -177,7 +177,7 @@ func resourceGitlabProjectApprovalRuleUpdate(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] Project %s update gitlab project-level approval rule %s", projectID, *options.Name)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	_, _, err = client.Projects.UpdateProjectApprovalRule(projectID, ruleIDInt, &options, gitlab.WithContext(ctx))
 	if err != nil {
@@ -200,7 +200,7 @@ func resourceGitlabProjectApprovalRuleDelete(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] Project %s delete gitlab project-level approval rule %d", project, ruleIDInt)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	_, err = client.Projects.DeleteProjectApprovalRule(project, ruleIDInt, gitlab.WithContext(ctx))
 	if err != nil {
@@ -94,7 +94,7 @@ func resourceGitlabProjectEnvironmentCreate(ctx context.Context, d *schema.Resou
 
 	log.Printf("[DEBUG] Project %s create gitlab environment %q", project, *options.Name)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	environment, _, err := client.Environments.CreateEnvironment(project, &options, gitlab.WithContext(ctx))
 	if err != nil {
@@ -119,7 +119,7 @@ func resourceGitlabProjectEnvironmentRead(ctx context.Context, d *schema.Resourc
 
 	log.Printf("[DEBUG] Project %s read gitlab environment %d", project, environmentID)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	environment, _, err := client.Environments.GetEnvironment(project, environmentID, gitlab.WithContext(ctx))
 	if err != nil {
@@ -161,7 +161,7 @@ func resourceGitlabProjectEnvironmentUpdate(ctx context.Context, d *schema.Resou
 
 	log.Printf("[DEBUG] Project %s update gitlab environment %d", project, environmentID)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	if _, _, err := client.Environments.EditEnvironment(project, environmentID, options, gitlab.WithContext(ctx)); err != nil {
 		return diag.Errorf("error editing gitlab project %s environment %d: %v", project, environmentID, err)
@@ -171,7 +171,7 @@ func resourceGitlabProjectEnvironmentUpdate(ctx context.Context, d *schema.Resou
 }
 
 func resourceGitlabProjectEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, environmentID, err := resourceGitlabProjectEnvironmentParseID(d)
 	if err != nil {
 		return diag.FromErr(err)
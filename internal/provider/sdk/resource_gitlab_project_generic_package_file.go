@@ -0,0 +1,414 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/xanzy/go-gitlab"
+)
+
+var validGenericPackageStatusValues = []string{string(gitlab.PackageDefault), string(gitlab.PackageHidden)}
+
+// gitlabPackageFile is a package file with its SHA256 checksum, which the package file of go-gitlab doesn't contain yet.
+type gitlabPackageFile struct {
+	gitlab.PackageFile
+	FileSHA256 string `json:"file_sha256"`
+}
+
+var _ = registerResource("gitlab_project_generic_package_file", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_generic_package_file`" + ` resource allows to manage the lifecycle of a file in a generic package of a project.
+
+-> The file is uploaded again whenever its SHA256 checksum in GitLab differs from the one of the ` + "`source`" + ` or ` + "`content`" + `, e.g. because it has been replaced outside of Terraform. The ` + "`source`" + ` file must exist when the plan is created.
+   GitLab instances which don't return the checksum of package files are compared by the size of the file instead.
+
+-> During a terraform destroy this resource deletes the file, and the package as well once it has no files left.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/user/packages/generic_packages/)`,
+
+		CreateContext: resourceGitlabProjectGenericPackageFileCreate,
+		ReadContext:   resourceGitlabProjectGenericPackageFileRead,
+		UpdateContext: resourceGitlabProjectGenericPackageFileUpdate,
+		DeleteContext: resourceGitlabProjectGenericPackageFileDelete,
+		CustomizeDiff: resourceGitlabProjectGenericPackageFileDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The ID or full path of the project.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"package_name": {
+				Description: "The name of the package. It can contain letters, numbers, dots, dashes and underscores.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"package_version": {
+				Description: "The version of the package, e.g. `1.0.0`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"file_name": {
+				Description: "The name of the file in the package.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source": {
+				Description:  "A local path to the file to upload. **Note**: not available for imported resources.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source", "content"},
+			},
+			"content": {
+				Description:  "The content of the file to upload, e.g. a generated configuration. **Note**: not available for imported resources.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"source", "content"},
+			},
+			"status": {
+				Description:      fmt.Sprintf("The status of the package. A `hidden` package doesn't appear in the UI, but can still be downloaded. Valid values are %s.", renderValueListForDocs(validGenericPackageStatusValues)),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          string(gitlab.PackageDefault),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validGenericPackageStatusValues, false)),
+			},
+			"sha256": {
+				Description: "The SHA256 checksum of the file. Empty if the GitLab instance doesn't return it.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"size": {
+				Description: "The size of the file in bytes.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"package_id": {
+				Description: "The ID of the package.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"package_file_id": {
+				Description: "The ID of the file in the package.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "When the file was uploaded. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+})
+
+// resourceGitlabProjectGenericPackageFileDiff plans to upload the file again if its checksum differs from the one in the state,
+// or its size if GitLab doesn't return the checksum. The attributes of the uploaded file are only known after the upload,
+// because the `source` file may change between the plan and the apply.
+func resourceGitlabProjectGenericPackageFileDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") || !d.NewValueKnown("content") {
+		return setGenericPackageFileUploadComputed(d, "sha256", "size")
+	}
+	checksum, size, err := genericPackageFileSHA256(d.Get("source").(string), d.Get("content").(string))
+	if err != nil {
+		return err
+	}
+	changed := d.Get("sha256").(string) != checksum
+	if d.Id() != "" && d.Get("sha256").(string) == "" {
+		changed = int64(d.Get("size").(int)) != size
+	}
+	switch {
+	case changed:
+		return setGenericPackageFileUploadComputed(d, "sha256", "size")
+	case d.HasChange("status"):
+		return setGenericPackageFileUploadComputed(d)
+	}
+	return nil
+}
+
+// setGenericPackageFileUploadComputed marks the attributes of the file which change with the next upload as unknown.
+func setGenericPackageFileUploadComputed(d *schema.ResourceDiff, keys ...string) error {
+	for _, key := range append([]string{"package_file_id", "created_at"}, keys...) {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceGitlabProjectGenericPackageFileCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+	packageName := d.Get("package_name").(string)
+	packageVersion := d.Get("package_version").(string)
+	fileName := d.Get("file_name").(string)
+
+	if err := uploadGitlabGenericPackageFile(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(resourceGitlabProjectGenericPackageFileBuildID(project, packageName, packageVersion, fileName))
+
+	return resourceGitlabProjectGenericPackageFileRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectGenericPackageFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, packageName, packageVersion, fileName, err := resourceGitlabProjectGenericPackageFileParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] read generic package file project/package/version/file: %s/%s/%s/%s", project, packageName, packageVersion, fileName)
+	pkg, err := findGitlabGenericPackage(ctx, client, project, packageName, packageVersion)
+	if err != nil {
+		if is404(err) {
+			log.Printf("[WARN] project %s not found, removing generic package file %s from state", project, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if pkg == nil {
+		log.Printf("[WARN] generic package %s/%s in project %s not found, removing from state", packageName, packageVersion, project)
+		d.SetId("")
+		return nil
+	}
+
+	files, err := listAllGitlabPackageFiles(ctx, client, project, pkg.ID)
+	if err != nil {
+		if is404(err) {
+			log.Printf("[WARN] generic package %s/%s in project %s not found, removing from state", packageName, packageVersion, project)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	// GitLab keeps the former files with the same name and serves the latest one
+	var file *gitlabPackageFile
+	for _, f := range files {
+		if f.FileName == fileName && (file == nil || f.ID > file.ID) {
+			file = f
+		}
+	}
+	if file == nil {
+		log.Printf("[WARN] file %s of generic package %s/%s in project %s not found, removing from state", fileName, packageName, packageVersion, project)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("project", project)
+	d.Set("package_name", pkg.Name)
+	d.Set("package_version", pkg.Version)
+	d.Set("file_name", file.FileName)
+	d.Set("status", pkg.Status)
+	d.Set("sha256", file.FileSHA256)
+	d.Set("size", file.Size)
+	d.Set("package_id", pkg.ID)
+	d.Set("package_file_id", file.ID)
+	if file.CreatedAt != nil {
+		d.Set("created_at", file.CreatedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func resourceGitlabProjectGenericPackageFileUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// NOTE: GitLab has no endpoint to replace a package file or to change the status of a package,
+	//       the file is uploaded again and the former files with the same name are deleted afterwards.
+	if d.HasChanges("package_file_id", "status") {
+		if err := uploadGitlabGenericPackageFile(ctx, d, client); err != nil {
+			return diag.FromErr(err)
+		}
+		project := d.Get("project").(string)
+		packageID := d.Get("package_id").(int)
+		if _, err := deleteGitlabGenericPackageFiles(ctx, client, project, packageID, d.Get("file_name").(string), d.Get("package_file_id").(int)); err != nil {
+			return diag.Errorf("failed to delete the former files of package %d: %v", packageID, err)
+		}
+	}
+
+	return resourceGitlabProjectGenericPackageFileRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectGenericPackageFileDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+	packageID := d.Get("package_id").(int)
+
+	remaining, err := deleteGitlabGenericPackageFiles(ctx, client, project, packageID, d.Get("file_name").(string), 0)
+	if err != nil {
+		if is404(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// GitLab keeps a package without files, which is removed as well
+	if remaining == 0 {
+		log.Printf("[DEBUG] delete generic package without files project/package: %s/%d", project, packageID)
+		if _, err := client.Packages.DeleteProjectPackage(project, packageID, gitlab.WithContext(ctx)); err != nil && !is404(err) {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// deleteGitlabGenericPackageFiles deletes the files of the package with the name, except the one to keep,
+// and returns the number of files left in the package.
+func deleteGitlabGenericPackageFiles(ctx context.Context, client *gitlab.Client, project string, packageID int, fileName string, keepFileID int) (int, error) {
+	files, err := listAllGitlabPackageFiles(ctx, client, project, packageID)
+	if err != nil {
+		return 0, err
+	}
+	remaining := len(files)
+	for _, file := range files {
+		if file.FileName != fileName || file.ID == keepFileID {
+			continue
+		}
+		log.Printf("[DEBUG] delete generic package file project/package/file: %s/%d/%d", project, packageID, file.ID)
+		if _, err := client.Packages.DeletePackageFile(project, packageID, file.ID, gitlab.WithContext(ctx)); err != nil && !is404(err) {
+			return 0, err
+		}
+		remaining--
+	}
+	return remaining, nil
+}
+
+// uploadGitlabGenericPackageFile uploads the `source` or `content` to the package, which GitLab creates if it doesn't exist yet,
+// and stores the IDs of the package and the new file.
+func uploadGitlabGenericPackageFile(ctx context.Context, d *schema.ResourceData, client *gitlab.Client) error {
+	project := d.Get("project").(string)
+	packageName := d.Get("package_name").(string)
+	packageVersion := d.Get("package_version").(string)
+	fileName := d.Get("file_name").(string)
+
+	var content io.Reader
+	if source, ok := d.GetOk("source"); ok {
+		file, err := os.Open(source.(string))
+		if err != nil {
+			return fmt.Errorf("unable to open source file %s: %w", source, err)
+		}
+		defer file.Close()
+		content = file
+	} else {
+		content = strings.NewReader(d.Get("content").(string))
+	}
+
+	status := gitlab.GenericPackageStatusValue(d.Get("status").(string))
+	options := &gitlab.PublishPackageFileOptions{
+		Status: &status,
+		Select: gitlab.GenericPackageSelect(gitlab.SelectPackageFile),
+	}
+
+	log.Printf("[DEBUG] upload generic package file project/package/version/file: %s/%s/%s/%s", project, packageName, packageVersion, fileName)
+	file, _, err := client.GenericPackages.PublishPackageFile(project, packageName, packageVersion, fileName, content, options, gitlab.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to upload file %s to generic package %s/%s: %w", fileName, packageName, packageVersion, err)
+	}
+	d.Set("package_id", file.PackageID)
+	d.Set("package_file_id", file.ID)
+	return nil
+}
+
+// findGitlabGenericPackage returns the generic package with the name and version, or nil if it doesn't exist.
+// GitLab lists hidden packages only if they are requested explicitly. The packages are filtered by the version,
+// so that usually a single page is listed per status.
+func findGitlabGenericPackage(ctx context.Context, client *gitlab.Client, project string, packageName string, packageVersion string) (*gitlab.Package, error) {
+	for _, status := range validGenericPackageStatusValues {
+		options := listProjectPackagesOptions{
+			ListProjectPackagesOptions: gitlab.ListProjectPackagesOptions{
+				PackageType: gitlab.String("generic"),
+				PackageName: gitlab.String(packageName),
+				Status:      gitlab.String(status),
+			},
+			PackageVersion: gitlab.String(packageVersion),
+		}
+		packages, err := paginate(ctx, paginationOptions{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlab.Package, *gitlab.Response, error) {
+			options := options
+			options.ListOptions = listOptions
+			return listGitlabProjectPackages(client, project, &options, requestOptions...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		// GitLab matches the name partially and older versions ignore the version filter
+		for _, pkg := range packages {
+			if pkg.Name == packageName && pkg.Version == packageVersion {
+				return pkg, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// listAllGitlabPackageFiles lists all files of a package with their SHA256 checksums.
+func listAllGitlabPackageFiles(ctx context.Context, client *gitlab.Client, project string, packageID int) ([]*gitlabPackageFile, error) {
+	return paginate(ctx, paginationOptions{}, func(listOptions gitlab.ListOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlabPackageFile, *gitlab.Response, error) {
+		options := gitlab.ListPackageFilesOptions(listOptions)
+		return listGitlabPackageFiles(client, project, packageID, &options, requestOptions...)
+	})
+}
+
+// listGitlabPackageFiles lists a page of the files of a package with their SHA256 checksums.
+func listGitlabPackageFiles(client *gitlab.Client, project string, packageID int, options *gitlab.ListPackageFilesOptions, requestOptions ...gitlab.RequestOptionFunc) ([]*gitlabPackageFile, *gitlab.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("projects/%s/packages/%d/package_files", gitlab.PathEscape(project), packageID), options, requestOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	var files []*gitlabPackageFile
+	resp, err := client.Do(req, &files)
+	if err != nil {
+		return nil, resp, err
+	}
+	return files, resp, nil
+}
+
+// genericPackageFileSHA256 returns the SHA256 checksum and the size of the source file or of the content.
+func genericPackageFileSHA256(source string, content string) (string, int64, error) {
+	hash := sha256.New()
+	var size int64
+	if source != "" {
+		file, err := os.Open(source)
+		if err != nil {
+			return "", 0, fmt.Errorf("unable to open source file %s: %w", source, err)
+		}
+		defer file.Close()
+		if size, err = io.Copy(hash, file); err != nil {
+			return "", 0, fmt.Errorf("unable to read source file %s: %w", source, err)
+		}
+	} else {
+		n, _ := hash.Write([]byte(content))
+		size = int64(n)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+func resourceGitlabProjectGenericPackageFileBuildID(project string, packageName string, packageVersion string, fileName string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", project, packageName, packageVersion, fileName)
+}
+
+func resourceGitlabProjectGenericPackageFileParseID(id string) (string, string, string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 4 {
+		return "", "", "", "", fmt.Errorf("invalid generic package file id %q, expected format '{project}:{package_name}:{package_version}:{file_name}'", id)
+	}
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
@@ -0,0 +1,118 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccGitlabProjectGenericPackageFile_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	source := t.TempDir() + "/api.txt"
+	if err := os.WriteFile(source, []byte("second"), 0o600); err != nil {
+		t.Fatalf("failed to write the source file: %v", err)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		CheckDestroy:             testAccCheckGitlabProjectGenericPackageFileDestroy,
+		Steps: []resource.TestStep{
+			// upload inline content
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_project_generic_package_file" "this" {
+				  project         = %d
+				  package_name    = "api"
+				  package_version = "1.0.0"
+				  file_name       = "api.txt"
+				  content         = "first"
+				}
+				`, testProject.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_generic_package_file.this", "sha256", "a7937b64b8caa58f03721bb6bacf5c78cb235febe0e70b1b84cd99541461a08e"),
+					resource.TestCheckResourceAttr("gitlab_project_generic_package_file.this", "size", "5"),
+					resource.TestCheckResourceAttr("gitlab_project_generic_package_file.this", "status", "default"),
+					resource.TestCheckResourceAttrSet("gitlab_project_generic_package_file.this", "package_id"),
+					resource.TestCheckResourceAttrSet("gitlab_project_generic_package_file.this", "package_file_id"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_project_generic_package_file.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content", "source"},
+			},
+			// upload a local file to a hidden package
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_project_generic_package_file" "this" {
+				  project         = %d
+				  package_name    = "api"
+				  package_version = "1.0.0"
+				  file_name       = "api.txt"
+				  source          = "%s"
+				  status          = "hidden"
+				}
+				`, testProject.ID, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_generic_package_file.this", "sha256", "16367aacb67a4a017c8da8ab95682ccb390863780f7114dda0a0e0c55644c7c4"),
+					resource.TestCheckResourceAttr("gitlab_project_generic_package_file.this", "size", "6"),
+					resource.TestCheckResourceAttr("gitlab_project_generic_package_file.this", "status", "hidden"),
+					func(s *terraform.State) error {
+						pkg, err := findGitlabGenericPackage(context.Background(), testutil.TestGitlabClient, fmt.Sprint(testProject.ID), "api", "1.0.0")
+						if err != nil || pkg == nil {
+							return fmt.Errorf("expected the package to exist: %v", err)
+						}
+						files, _, err := testutil.TestGitlabClient.Packages.ListPackageFiles(testProject.ID, pkg.ID, nil)
+						if err != nil {
+							return err
+						}
+						if len(files) != 1 {
+							return fmt.Errorf("expected the former file to be deleted, got %d files", len(files))
+						}
+						return nil
+					},
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_project_generic_package_file.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content", "source"},
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabProjectGenericPackageFileDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gitlab_project_generic_package_file" {
+			continue
+		}
+
+		project, packageName, packageVersion, _, err := resourceGitlabProjectGenericPackageFileParseID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		pkg, err := findGitlabGenericPackage(context.Background(), testutil.TestGitlabClient, project, packageName, packageVersion)
+		if err != nil {
+			return err
+		}
+		if pkg != nil {
+			return fmt.Errorf("Generic package %s/%s still exists", packageName, packageVersion)
+		}
+	}
+	return nil
+}
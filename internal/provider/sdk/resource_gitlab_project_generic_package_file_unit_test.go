@@ -0,0 +1,199 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/pager"
+)
+
+func TestUnitGitlabProjectGenericPackageFile_fakeGitLab(t *testing.T) {
+	const sha256V1 = "3bfc269594ef649228e9a74bab00f042efc91d5acc6fbee31a382e80d42388fe"
+	// config writes the content to the source file of the package file
+	config := func(t *testing.T, content string, status string) map[string]interface{} {
+		t.Helper()
+		source := t.TempDir() + "/api.tar.gz"
+		if err := os.WriteFile(source, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write the source file: %v", err)
+		}
+		return map[string]interface{}{
+			"project":         "root/api",
+			"package_name":    "api",
+			"package_version": "1.0.0",
+			"file_name":       "api.tar.gz",
+			"source":          source,
+			"status":          status,
+		}
+	}
+	// newComputed returns whether the value of the attribute is only known after the apply
+	newComputed := func(diff *terraform.InstanceDiff, key string) bool {
+		return diff != nil && diff.Attributes[key] != nil && diff.Attributes[key].NewComputed
+	}
+
+	t.Run("create uploads the source file", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_project_generic_package_file")
+		d := f.create(t, config(t, "v1", "default"))
+
+		checkAttributes(t, d, map[string]interface{}{
+			"id":     fmt.Sprintf("%d:api:1.0.0:api.tar.gz", f.project.ID),
+			"sha256": sha256V1,
+			"size":   2,
+			"status": "default",
+		})
+		if d.Get("package_id") == 0 || d.Get("package_file_id") == 0 {
+			t.Errorf("expected the IDs of the package and the file in the state, got %v and %v", d.Get("package_id"), d.Get("package_file_id"))
+		}
+	})
+
+	t.Run("a file replaced outside of Terraform is uploaded again", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_project_generic_package_file")
+		cfg := config(t, "v1", "default")
+		d := f.create(t, cfg)
+		if _, _, err := f.fake.Client.GenericPackages.PublishPackageFile(f.project.ID, "api", "1.0.0", "api.tar.gz", strings.NewReader("v2"), nil); err != nil {
+			t.Fatalf("failed to publish package file: %v", err)
+		}
+
+		f.read(t, d)
+		// the checksum of the upload is only known after the apply, because the source file may change in the meantime
+		diff := f.diff(t, d, cfg)
+		checkValues(t, map[string]interface{}{
+			"sha256":          newComputed(diff, "sha256"),
+			"package_file_id": newComputed(diff, "package_file_id"),
+		}, map[string]interface{}{
+			"sha256":          true,
+			"package_file_id": true,
+		})
+	})
+
+	t.Run("a change of the status uploads the file again and deletes the former files", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_project_generic_package_file")
+		d := f.create(t, config(t, "v1", "default"))
+		packageID := d.Get("package_id").(int)
+		packageFileID := d.Get("package_file_id").(int)
+
+		cfg := config(t, "v1", "hidden")
+		d = f.update(t, d, cfg)
+		checkAttributes(t, d, map[string]interface{}{
+			"status":     "hidden",
+			"package_id": packageID,
+			"sha256":     sha256V1,
+		})
+		if d.Get("package_file_id") == packageFileID {
+			t.Errorf("expected a new package file, got %v", packageFileID)
+		}
+		files, _, err := f.fake.Client.Packages.ListPackageFiles(f.project.ID, packageID, nil)
+		if err != nil {
+			t.Fatalf("failed to list package files: %v", err)
+		}
+		if len(files) != 1 {
+			t.Fatalf("expected only the new package file, got %v", files)
+		}
+		checkValues(t, map[string]interface{}{
+			"id":   files[0].ID,
+			"size": files[0].Size,
+		}, map[string]interface{}{
+			"id":   d.Get("package_file_id"),
+			"size": 2,
+		})
+		if diff := f.diff(t, d, cfg); diff != nil && !diff.Empty() {
+			t.Errorf("expected no changes, got %v", diff)
+		}
+	})
+
+	t.Run("without the checksum of GitLab only the size is compared", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_project_generic_package_file")
+		cfg := config(t, "v1", "default")
+		d := f.create(t, cfg)
+		d.Set("sha256", "")
+
+		if diff := f.diff(t, d, cfg); diff != nil && !diff.Empty() {
+			t.Errorf("expected no changes for the same size, got %v", diff)
+		}
+		if err := os.WriteFile(cfg["source"].(string), []byte("v1.1"), 0o600); err != nil {
+			t.Fatalf("failed to write the source file: %v", err)
+		}
+		diff := f.diff(t, d, cfg)
+		checkValues(t, map[string]interface{}{"size": newComputed(diff, "size")}, map[string]interface{}{"size": true})
+		d = f.update(t, d, cfg)
+		checkAttributes(t, d, map[string]interface{}{"size": 4})
+	})
+
+	t.Run("the data source lists the packages with the version", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_project_generic_package_file")
+		f.create(t, config(t, "v1", "default"))
+		if _, _, err := f.fake.Client.GenericPackages.PublishPackageFile(f.project.ID, "api", "2.0.0", "api.tar.gz", strings.NewReader("v2"), nil); err != nil {
+			t.Fatalf("failed to publish package file: %v", err)
+		}
+
+		ds := f.readDataSource(t, "gitlab_project_packages", map[string]interface{}{"project": "root/api", "package_type": "generic", "package_name": "api", "package_version": "2.0.0"})
+		checkAttributes(t, ds, map[string]interface{}{
+			"packages.#":         1,
+			"packages.0.version": "2.0.0",
+			"packages.0.status":  "default",
+		})
+	})
+
+	t.Run("delete deletes the package with its last file", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_project_generic_package_file")
+		d := f.create(t, config(t, "v1", "default"))
+
+		f.delete(t, d)
+		if _, _, err := f.fake.Client.Packages.ListPackageFiles(f.project.ID, d.Get("package_id").(int), nil); !is404(err) {
+			t.Errorf("expected the package to be deleted, got %v", err)
+		}
+	})
+}
+
+func TestUnitFindGitlabGenericPackage_singleRequestPerStatus(t *testing.T) {
+	f := newFakeGitLabResource(t, "gitlab_project_generic_package_file")
+	fake, project := f.fake, f.project
+	// more versions than fit on a page, of packages which all match the name partially
+	for i := 0; i < pager.DefaultPerPage+1; i++ {
+		if _, _, err := fake.Client.GenericPackages.PublishPackageFile(project.ID, "api-client", fmt.Sprintf("0.%d.0", i), "api.tar.gz", strings.NewReader("v1"), nil); err != nil {
+			t.Fatalf("failed to publish package file: %v", err)
+		}
+	}
+	hidden := gitlab.GenericPackageStatusValue("hidden")
+	if _, _, err := fake.Client.GenericPackages.PublishPackageFile(project.ID, "api", "1.0.0", "api.tar.gz", strings.NewReader("v1"), &gitlab.PublishPackageFileOptions{Status: &hidden}); err != nil {
+		t.Fatalf("failed to publish package file: %v", err)
+	}
+
+	var requests []string
+	client, err := gitlab.NewClient(fake.Token, gitlab.WithBaseURL(fake.URL), gitlab.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/packages") {
+				requests = append(requests, req.URL.Query().Get("status"))
+			}
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	pkg, err := findGitlabGenericPackage(context.Background(), client, strconv.Itoa(project.ID), "api", "1.0.0")
+	if err != nil {
+		t.Fatalf("failed to find the package: %v", err)
+	}
+	if pkg == nil || pkg.Name != "api" || pkg.Version != "1.0.0" {
+		t.Fatalf("expected package api/1.0.0, got %v", pkg)
+	}
+	if strings.Join(requests, ",") != "default,hidden" {
+		t.Errorf("expected a single request per status, got the statuses %v", requests)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper to observe the requests of a client.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
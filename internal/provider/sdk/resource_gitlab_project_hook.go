@@ -25,12 +25,12 @@ var _ = registerResource("gitlab_project_hook", func() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceGitlabProjectHookStateImporter,
 		},
-		Schema: gitlabProjectHookSchema(),
+		Schema: constructSchema(gitlabProjectHookSchema(), adoptExistingSchema()),
 	}
 })
 
 func resourceGitlabProjectHookCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	options := &gitlab.AddProjectHookOptions{
 		URL:                      gitlab.String(d.Get("url").(string)),
@@ -54,6 +54,19 @@ func resourceGitlabProjectHookCreate(ctx context.Context, d *schema.ResourceData
 		options.Token = gitlab.String(v.(string))
 	}
 
+	// GitLab allows multiple hooks with the same URL, so an existing hook has to be looked up before it's created
+	if shouldAdoptExisting(d, meta) {
+		hook, err := findGitlabProjectHookByURL(ctx, client, project, *options.URL)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if hook != nil {
+			log.Printf("[DEBUG] adopt existing gitlab project hook %q", *options.URL)
+			d.SetId(fmt.Sprintf("%d", hook.ID))
+			return resourceGitlabProjectHookUpdate(ctx, d, meta)
+		}
+	}
+
 	log.Printf("[DEBUG] create gitlab project hook %q", *options.URL)
 
 	hook, _, err := client.Projects.AddProjectHook(project, options, gitlab.WithContext(ctx))
@@ -67,8 +80,26 @@ func resourceGitlabProjectHookCreate(ctx context.Context, d *schema.ResourceData
 	return resourceGitlabProjectHookRead(ctx, d, meta)
 }
 
+// findGitlabProjectHookByURL returns the first hook of the project with the given URL or nil if there is none.
+func findGitlabProjectHookByURL(ctx context.Context, client *gitlab.Client, project string, url string) (*gitlab.ProjectHook, error) {
+	options := &gitlab.ListProjectHooksOptions{Page: 1, PerPage: 100}
+	for options.Page != 0 {
+		hooks, resp, err := client.Projects.ListProjectHooks(project, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			if hook.URL == url {
+				return hook, nil
+			}
+		}
+		options.Page = resp.NextPage
+	}
+	return nil, nil
+}
+
 func resourceGitlabProjectHookRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	hookId, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -94,7 +125,7 @@ func resourceGitlabProjectHookRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabProjectHookUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	hookId, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -133,7 +164,7 @@ func resourceGitlabProjectHookUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabProjectHookDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	hookId, err := strconv.Atoi(d.Id())
 	if err != nil {
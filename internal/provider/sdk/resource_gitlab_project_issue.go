@@ -51,7 +51,7 @@ var _ = registerResource("gitlab_project_issue", func() *schema.Resource {
 })
 
 func resourceGitlabProjectIssueCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 
 	options := &gitlab.CreateIssueOptions{
@@ -127,7 +127,7 @@ func resourceGitlabProjectIssueCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabProjectIssueRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, issueIID, err := resourceGitLabProjectIssueParseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -151,7 +151,7 @@ func resourceGitlabProjectIssueRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabProjectIssueUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, issueIID, err := resourceGitLabProjectIssueParseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -212,7 +212,7 @@ func resourceGitlabProjectIssueUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGitlabProjectIssueDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, issueIID, err := resourceGitLabProjectIssueParseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
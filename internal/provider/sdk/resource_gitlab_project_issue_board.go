@@ -32,7 +32,7 @@ var _ = registerResource("gitlab_project_issue_board", func() *schema.Resource {
 })
 
 func resourceGitlabProjectIssueBoardCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	options := gitlab.CreateIssueBoardOptions{
@@ -80,7 +80,7 @@ func resourceGitlabProjectIssueBoardCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabProjectIssueBoardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, issueBoardID, err := resourceGitlabProjectIssueBoardParseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -105,7 +105,7 @@ func resourceGitlabProjectIssueBoardRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabProjectIssueBoardUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, issueBoardID, err := resourceGitlabProjectIssueBoardParseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -156,7 +156,7 @@ func resourceGitlabProjectIssueBoardUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabProjectIssueBoardDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, issueBoardID, err := resourceGitlabProjectIssueBoardParseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
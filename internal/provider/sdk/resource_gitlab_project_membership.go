@@ -29,7 +29,7 @@ var _ = registerResource("gitlab_project_membership", func() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: constructSchema(map[string]*schema.Schema{
 			"project_id": {
 				Description: "The id of the project.",
 				Type:        schema.TypeString,
@@ -54,12 +54,12 @@ var _ = registerResource("gitlab_project_membership", func() *schema.Resource {
 				ValidateFunc: validateDateFunc,
 				Optional:     true,
 			},
-		},
+		}, adoptExistingSchema()),
 	}
 })
 
 func resourceGitlabProjectMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userId := d.Get("user_id").(int)
 	projectId := d.Get("project_id").(string)
@@ -73,17 +73,22 @@ func resourceGitlabProjectMembershipCreate(ctx context.Context, d *schema.Resour
 	}
 	log.Printf("[DEBUG] create gitlab project membership for %d in %s", options.UserID, projectId)
 
+	userIdString := strconv.Itoa(userId)
 	_, _, err := client.ProjectMembers.AddProjectMember(projectId, options, gitlab.WithContext(ctx))
 	if err != nil {
+		if isAlreadyExistsError(err) && shouldAdoptExisting(d, meta) {
+			log.Printf("[DEBUG] adopt existing gitlab project membership for %d in %s", userId, projectId)
+			d.SetId(buildTwoPartID(&projectId, &userIdString))
+			return resourceGitlabProjectMembershipUpdate(ctx, d, meta)
+		}
 		return diag.FromErr(err)
 	}
-	userIdString := strconv.Itoa(userId)
 	d.SetId(buildTwoPartID(&projectId, &userIdString))
 	return resourceGitlabProjectMembershipRead(ctx, d, meta)
 }
 
 func resourceGitlabProjectMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	id := d.Id()
 	log.Printf("[DEBUG] read gitlab project projectMember %s", id)
 
@@ -119,7 +124,7 @@ func projectIdAndUserIdFromId(id string) (string, int, error) {
 }
 
 func resourceGitlabProjectMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	userId := d.Get("user_id").(int)
 	projectId := d.Get("project_id").(string)
@@ -140,7 +145,7 @@ func resourceGitlabProjectMembershipUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabProjectMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	id := d.Id()
 	projectId, userId, err := projectIdAndUserIdFromId(id)
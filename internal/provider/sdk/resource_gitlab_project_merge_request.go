@@ -0,0 +1,242 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+// mergeRequestAcceptTimeout is how long to wait for GitLab to accept a merge request to be merged,
+// which is rejected while GitLab still checks whether a new merge request can be merged.
+const mergeRequestAcceptTimeout = 1 * time.Minute
+
+var _ = registerResource("gitlab_project_merge_request", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_project_merge_request`" + ` resource allows to manage the lifecycle of a merge request within a project.
+
+-> During a terraform destroy this resource will close the merge request, unless it has already been merged. Set the delete_on_destroy flag to true to delete the merge request instead of closing it.
+
+**Upstream API**: [GitLab API docs](https://docs.gitlab.com/ee/api/merge_requests.html)`,
+
+		CreateContext: resourceGitlabProjectMergeRequestCreate,
+		ReadContext:   resourceGitlabProjectMergeRequestRead,
+		UpdateContext: resourceGitlabProjectMergeRequestUpdate,
+		DeleteContext: resourceGitlabProjectMergeRequestDelete,
+		CustomizeDiff: resourceGitlabProjectMergeRequestDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: constructSchema(
+			gitlabProjectMergeRequestGetSchema(),
+			map[string]*schema.Schema{
+				"merge_on_create": {
+					Description: "Merge the merge request right after it has been created, e.g. to apply changes which have been committed to the source branch. This attribute is only used during `create`.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"delete_on_destroy": {
+					Description: "Whether the merge request is deleted instead of closed during destroy.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+			},
+		),
+	}
+})
+
+// resourceGitlabProjectMergeRequestDiff validates that a new merge request which is merged on create isn't a draft.
+func resourceGitlabProjectMergeRequestDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" && d.Get("merge_on_create").(bool) && d.Get("draft").(bool) {
+		return errors.New("a draft merge request can't be merged, either unset `draft` or `merge_on_create`")
+	}
+	return nil
+}
+
+func resourceGitlabProjectMergeRequestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+
+	options := &gitlab.CreateMergeRequestOptions{
+		Title:              gitlab.String(gitlabProjectMergeRequestTitle(d.Get("title").(string), d.Get("draft").(bool))),
+		SourceBranch:       gitlab.String(d.Get("source_branch").(string)),
+		TargetBranch:       gitlab.String(d.Get("target_branch").(string)),
+		Squash:             gitlab.Bool(d.Get("squash").(bool)),
+		RemoveSourceBranch: gitlab.Bool(d.Get("remove_source_branch").(bool)),
+	}
+	if description, ok := d.GetOk("description"); ok {
+		options.Description = gitlab.String(description.(string))
+	}
+	if labels, ok := d.GetOk("labels"); ok {
+		gitlabLabels := gitlab.Labels(*stringSetToStringSlice(labels.(*schema.Set)))
+		options.Labels = &gitlabLabels
+	}
+	if assigneeIDs, ok := d.GetOk("assignee_ids"); ok {
+		options.AssigneeIDs = intSetToIntSlice(assigneeIDs.(*schema.Set))
+	}
+	if reviewerIDs, ok := d.GetOk("reviewer_ids"); ok {
+		options.ReviewerIDs = intSetToIntSlice(reviewerIDs.(*schema.Set))
+	}
+
+	mergeRequest, _, err := client.MergeRequests.CreateMergeRequest(project, options, gitlab.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(resourceGitLabProjectMergeRequestBuildId(project, mergeRequest.IID))
+
+	if d.Get("merge_on_create").(bool) {
+		log.Printf("[DEBUG] merge merge request %d in project %s right after creation", mergeRequest.IID, project)
+		acceptOptions := &gitlab.AcceptMergeRequestOptions{
+			ShouldRemoveSourceBranch: gitlab.Bool(d.Get("remove_source_branch").(bool)),
+		}
+		if _, err := acceptGitlabMergeRequest(ctx, client, project, mergeRequest.IID, acceptOptions); err != nil {
+			return diag.Errorf("failed to merge merge request %d in project %s right after creation: %v", mergeRequest.IID, project, err)
+		}
+	}
+
+	return resourceGitlabProjectMergeRequestRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectMergeRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, mergeRequestIID, err := resourceGitLabProjectMergeRequestParseId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mergeRequest, _, err := client.MergeRequests.GetMergeRequest(project, mergeRequestIID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		if is404(err) {
+			log.Printf("[WARN] merge request %d in project %s not found, removing from state", mergeRequestIID, project)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	stateMap := gitlabProjectMergeRequestToStateMap(project, mergeRequest)
+	if err = setStateMapInResourceData(stateMap, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceGitlabProjectMergeRequestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, mergeRequestIID, err := resourceGitLabProjectMergeRequestParseId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := &gitlab.UpdateMergeRequestOptions{}
+	if d.HasChanges("title", "draft") {
+		options.Title = gitlab.String(gitlabProjectMergeRequestTitle(d.Get("title").(string), d.Get("draft").(bool)))
+	}
+	if d.HasChange("target_branch") {
+		options.TargetBranch = gitlab.String(d.Get("target_branch").(string))
+	}
+	if d.HasChange("description") {
+		options.Description = gitlab.String(d.Get("description").(string))
+	}
+	if d.HasChange("labels") {
+		gitlabLabels := gitlab.Labels(*stringSetToStringSlice(d.Get("labels").(*schema.Set)))
+		options.Labels = &gitlabLabels
+	}
+	if d.HasChange("assignee_ids") {
+		options.AssigneeIDs = intSetToIntSlice(d.Get("assignee_ids").(*schema.Set))
+	}
+	if d.HasChange("reviewer_ids") {
+		options.ReviewerIDs = intSetToIntSlice(d.Get("reviewer_ids").(*schema.Set))
+	}
+	if d.HasChange("squash") {
+		options.Squash = gitlab.Bool(d.Get("squash").(bool))
+	}
+	if d.HasChange("remove_source_branch") {
+		options.RemoveSourceBranch = gitlab.Bool(d.Get("remove_source_branch").(bool))
+	}
+
+	if *options != (gitlab.UpdateMergeRequestOptions{}) {
+		_, _, err = client.MergeRequests.UpdateMergeRequest(project, mergeRequestIID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceGitlabProjectMergeRequestRead(ctx, d, meta)
+}
+
+func resourceGitlabProjectMergeRequestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, mergeRequestIID, err := resourceGitLabProjectMergeRequestParseId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("delete_on_destroy").(bool) {
+		log.Printf("[DEBUG] Deleting merge request %d in project %s for destroy", mergeRequestIID, project)
+		if _, err := client.MergeRequests.DeleteMergeRequest(project, mergeRequestIID, gitlab.WithContext(ctx)); err != nil {
+			return diag.Errorf("%s failed to delete merge request %d in project %s: %v", d.Id(), mergeRequestIID, project, err)
+		}
+		return nil
+	}
+
+	if state := d.Get("state").(string); state != "opened" && state != "locked" {
+		log.Printf("[DEBUG] merge request %d in project %s is already %s, nothing to close for destroy", mergeRequestIID, project, state)
+		return nil
+	}
+	log.Printf("[DEBUG] Closing merge request %d in project %s for destroy", mergeRequestIID, project)
+	if _, _, err := client.MergeRequests.UpdateMergeRequest(project, mergeRequestIID, &gitlab.UpdateMergeRequestOptions{StateEvent: gitlab.String("close")}, gitlab.WithContext(ctx)); err != nil {
+		return diag.Errorf("%s failed to close merge request %d in project %s: %v", d.Id(), mergeRequestIID, project, err)
+	}
+
+	return nil
+}
+
+// acceptGitlabMergeRequest merges the merge request or enables auto-merge, depending on the options.
+// GitLab rejects to merge a new merge request while it still checks whether it can be merged, which is retried.
+func acceptGitlabMergeRequest(ctx context.Context, client *gitlab.Client, project string, mergeRequestIID int, options *gitlab.AcceptMergeRequestOptions) (*gitlab.MergeRequest, error) {
+	var mergeRequest *gitlab.MergeRequest
+	err := resource.RetryContext(ctx, mergeRequestAcceptTimeout, func() *resource.RetryError {
+		var err error
+		mergeRequest, _, err = client.MergeRequests.AcceptMergeRequest(project, mergeRequestIID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			var httpErr *gitlab.ErrorResponse
+			if errors.As(err, &httpErr) && (httpErr.Response.StatusCode == http.StatusMethodNotAllowed || httpErr.Response.StatusCode == http.StatusNotAcceptable) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	return mergeRequest, err
+}
+
+func resourceGitLabProjectMergeRequestParseId(id string) (string, int, error) {
+	project, mergeRequest, err := parseTwoPartID(id)
+	if err != nil {
+		return "", 0, err
+	}
+
+	mergeRequestIID, err := strconv.Atoi(mergeRequest)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return project, mergeRequestIID, nil
+}
+
+func resourceGitLabProjectMergeRequestBuildId(project string, mergeRequestIID int) string {
+	stringMergeRequestIID := fmt.Sprintf("%d", mergeRequestIID)
+	return buildTwoPartID(&project, &stringMergeRequestIID)
+}
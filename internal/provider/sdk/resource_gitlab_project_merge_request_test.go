@@ -0,0 +1,145 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccGitlabProjectMergeRequest_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	testUser := testutil.CreateUsers(t, 1)[0]
+	testutil.AddProjectMembers(t, testProject.ID, []*gitlab.User{testUser})
+	testBranch := testutil.CreateBranches(t, testProject, 1)[0]
+	testutil.CreateProjectFile(t, testProject.ID, "bWVvdyBtZW93IG1lb3c=", "meow.txt", testBranch.Name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		CheckDestroy:             testAccCheckGitlabProjectMergeRequestDestroy,
+		Steps: []resource.TestStep{
+			// create a draft merge request with required values only
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_project_merge_request" "this" {
+				  project       = %d
+				  source_branch = "%s"
+				  target_branch = "%s"
+				  title         = "feature: cats"
+				  draft         = true
+				}
+				`, testProject.ID, testBranch.Name, testProject.DefaultBranch),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "iid", "1"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "title", "feature: cats"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "draft", "true"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "state", "opened"),
+					resource.TestCheckResourceAttrSet("gitlab_project_merge_request.this", "web_url"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_project_merge_request.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"merge_on_create", "delete_on_destroy"},
+			},
+			// update all merge request attributes
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_project_merge_request" "this" {
+				  project              = %d
+				  source_branch        = "%s"
+				  target_branch        = "%s"
+				  title                = "feature: more cats"
+				  description          = "meow meow meow"
+				  labels               = ["cats"]
+				  assignee_ids         = [%d]
+				  reviewer_ids         = [%d]
+				  squash               = true
+				  remove_source_branch = true
+				}
+				`, testProject.ID, testBranch.Name, testProject.DefaultBranch, testUser.ID, testUser.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "title", "feature: more cats"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "draft", "false"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "labels.#", "1"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "assignee_ids.#", "1"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "reviewer_ids.#", "1"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "squash", "true"),
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "remove_source_branch", "true"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_project_merge_request.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"merge_on_create", "delete_on_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccGitlabProjectMergeRequest_mergeOnCreate(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	testBranch := testutil.CreateBranches(t, testProject, 1)[0]
+	testutil.CreateProjectFile(t, testProject.ID, "bWVvdyBtZW93IG1lb3c=", "meow.txt", testBranch.Name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		CheckDestroy:             testAccCheckGitlabProjectMergeRequestDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_project_merge_request" "this" {
+				  project         = %d
+				  source_branch   = "%s"
+				  target_branch   = "%s"
+				  title           = "feature: cats"
+				  merge_on_create = true
+				}
+				`, testProject.ID, testBranch.Name, testProject.DefaultBranch),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_project_merge_request.this", "state", "merged"),
+					resource.TestCheckResourceAttrSet("gitlab_project_merge_request.this", "merged_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabProjectMergeRequestDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gitlab_project_merge_request" {
+			continue
+		}
+
+		project, mergeRequestIID, err := resourceGitLabProjectMergeRequestParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		mergeRequest, _, err := testutil.TestGitlabClient.MergeRequests.GetMergeRequest(project, mergeRequestIID, nil)
+		if err != nil {
+			if is404(err) {
+				continue
+			}
+			return err
+		}
+		if rs.Primary.Attributes["delete_on_destroy"] == "true" {
+			return fmt.Errorf("Merge request %d still exists", mergeRequestIID)
+		}
+		if mergeRequest.State != "closed" && mergeRequest.State != "merged" {
+			return fmt.Errorf("Merge request still in state %s (should be closed)", mergeRequest.State)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestUnitGitlabProjectMergeRequest_fakeGitLab(t *testing.T) {
+	// newMergeRequestResource returns the resource with the branches `feature` and `hotfix` in the project
+	newMergeRequestResource := func(t *testing.T) *fakeGitLabResource {
+		t.Helper()
+		f := newFakeGitLabResource(t, "gitlab_project_merge_request")
+		for _, branch := range []string{"feature", "hotfix"} {
+			if _, _, err := f.fake.Client.RepositoryFiles.CreateFile(f.project.ID, branch+".txt", &gitlab.CreateFileOptions{
+				Branch: gitlab.String(branch), StartBranch: gitlab.String("main"), Content: gitlab.String(branch), CommitMessage: gitlab.String("add " + branch),
+			}); err != nil {
+				t.Fatalf("failed to create file: %v", err)
+			}
+		}
+		return f
+	}
+	draft := func() map[string]interface{} {
+		return map[string]interface{}{
+			"project":       "root/api",
+			"source_branch": "feature",
+			"target_branch": "main",
+			"title":         "Add feature",
+			"labels":        []interface{}{"feature"},
+			"reviewer_ids":  []interface{}{1},
+			"draft":         true,
+		}
+	}
+	merged := func() map[string]interface{} {
+		return map[string]interface{}{
+			"project":              "root/api",
+			"source_branch":        "hotfix",
+			"target_branch":        "main",
+			"title":                "Fix",
+			"merge_on_create":      true,
+			"remove_source_branch": true,
+		}
+	}
+
+	t.Run("create a draft merge request", func(t *testing.T) {
+		f := newMergeRequestResource(t)
+		d := f.create(t, draft())
+
+		// the draft prefix isn't part of the title in the state
+		checkAttributes(t, d, map[string]interface{}{
+			"title":            "Add feature",
+			"draft":            true,
+			"state":            "opened",
+			"reviewer_ids.#":   1,
+			"references.short": "!1",
+		})
+		mergeRequest := f.mergeRequest(t, d.Get("iid").(int))
+		checkValues(t, map[string]interface{}{
+			"title": mergeRequest.Title,
+			"draft": mergeRequest.Draft,
+		}, map[string]interface{}{
+			"title": "Draft: Add feature",
+			"draft": true,
+		})
+	})
+
+	t.Run("a draft merge request is ready once the draft flag is removed", func(t *testing.T) {
+		f := newMergeRequestResource(t)
+		d := f.create(t, draft())
+
+		config := draft()
+		config["draft"] = false
+		d = f.update(t, d, config)
+		checkAttributes(t, d, map[string]interface{}{
+			"title": "Add feature",
+			"draft": false,
+		})
+		mergeRequest := f.mergeRequest(t, d.Get("iid").(int))
+		checkValues(t, map[string]interface{}{
+			"title": mergeRequest.Title,
+			"draft": mergeRequest.Draft,
+		}, map[string]interface{}{
+			"title": "Add feature",
+			"draft": false,
+		})
+	})
+
+	t.Run("a merge request which is merged on create can't be a draft", func(t *testing.T) {
+		f := newMergeRequestResource(t)
+		config := merged()
+		config["draft"] = true
+
+		if _, err := f.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), f.meta); err == nil {
+			t.Errorf("expected a draft merge request not to be merged on create")
+		}
+	})
+
+	t.Run("merge on create", func(t *testing.T) {
+		f := newMergeRequestResource(t)
+		d := f.create(t, merged())
+
+		checkAttributes(t, d, map[string]interface{}{"state": "merged"})
+		if d.Get("merge_commit_sha") == "" {
+			t.Errorf("expected the merge commit in the state")
+		}
+		if _, _, err := f.fake.Client.Branches.GetBranch(f.project.ID, "hotfix"); !is404(err) {
+			t.Errorf("expected the source branch to be removed, got %v", err)
+		}
+	})
+
+	t.Run("the data source filters the merge requests", func(t *testing.T) {
+		f := newMergeRequestResource(t)
+		d := f.create(t, draft())
+		f.create(t, merged())
+
+		ds := f.readDataSource(t, "gitlab_project_merge_requests", map[string]interface{}{"project": "root/api", "state": "opened", "labels": []interface{}{"feature"}, "author_username": "root"})
+		checkAttributes(t, ds, map[string]interface{}{
+			"merge_requests.#":               1,
+			"merge_requests.0.iid":           d.Get("iid"),
+			"merge_requests.0.source_branch": "feature",
+		})
+	})
+
+	t.Run("destroy closes the merge request", func(t *testing.T) {
+		f := newMergeRequestResource(t)
+		d := f.create(t, draft())
+
+		f.delete(t, d)
+		checkValues(t, map[string]interface{}{"state": f.mergeRequest(t, d.Get("iid").(int)).State}, map[string]interface{}{"state": "closed"})
+	})
+
+	t.Run("destroy keeps a merged merge request", func(t *testing.T) {
+		f := newMergeRequestResource(t)
+		d := f.create(t, merged())
+
+		f.delete(t, d)
+		checkValues(t, map[string]interface{}{"state": f.mergeRequest(t, d.Get("iid").(int)).State}, map[string]interface{}{"state": "merged"})
+	})
+}
+
+func TestUnitGitlabProjectMergeRequest_destroyWithoutResponse(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL), gitlab.WithCustomRetryMax(0))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	meta := &providerMeta{client: client}
+	r := New("unittest")().ResourcesMap["gitlab_project_merge_request"]
+
+	// the error of a request without a response is returned instead of a panic
+	for _, deleteOnDestroy := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project": "1", "delete_on_destroy": deleteOnDestroy})
+		d.SetId("1:1")
+		d.Set("state", "opened")
+		diags := r.DeleteContext(context.Background(), d, meta)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "connection refused") {
+			t.Fatalf("expected the connection error for delete_on_destroy %t, got %v", deleteOnDestroy, diags)
+		}
+	}
+}
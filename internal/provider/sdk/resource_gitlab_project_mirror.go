@@ -94,7 +94,7 @@ import_url, mirror, and mirror_trigger_builds properties on the gitlab_project r
 })
 
 func resourceGitlabProjectMirrorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	projectID := d.Get("project").(string)
 	URL := d.Get("url").(string)
@@ -123,7 +123,7 @@ func resourceGitlabProjectMirrorCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabProjectMirrorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	mirrorID := d.Get("mirror_id").(int)
 	projectID := d.Get("project").(string)
@@ -146,7 +146,7 @@ func resourceGitlabProjectMirrorUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabProjectMirrorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	mirrorID := d.Get("mirror_id").(int)
 	projectID := d.Get("project").(string)
@@ -178,7 +178,7 @@ func resourceGitlabProjectMirrorDelete(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabProjectMirrorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	ids := strings.Split(d.Id(), ":")
 	projectID := ids[0]
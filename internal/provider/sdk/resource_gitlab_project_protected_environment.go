@@ -110,7 +110,7 @@ func resourceGitlabProjectProtectedEnvironmentCreate(ctx context.Context, d *sch
 
 	log.Printf("[DEBUG] Project %s create gitlab protected environment %q", project, *options.Name)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	protectedEnvironment, _, err := client.ProtectedEnvironments.ProtectRepositoryEnvironments(project, options, gitlab.WithContext(ctx))
 	if err != nil {
@@ -136,7 +136,7 @@ func resourceGitlabProjectProtectedEnvironmentRead(ctx context.Context, d *schem
 
 	log.Printf("[DEBUG] Project %s read gitlab protected environment %q", project, environment)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	protectedEnvironment, _, err := client.ProtectedEnvironments.GetProtectedEnvironment(project, environment, gitlab.WithContext(ctx))
 	if err != nil {
@@ -164,7 +164,7 @@ func resourceGitlabProjectProtectedEnvironmentDelete(ctx context.Context, d *sch
 
 	log.Printf("[DEBUG] Project %s delete gitlab project-level protected environment %s", project, environmentName)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	_, err = client.ProtectedEnvironments.UnprotectEnvironment(project, environmentName, gitlab.WithContext(ctx))
 	if err != nil {
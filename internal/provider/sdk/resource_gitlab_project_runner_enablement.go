@@ -40,7 +40,7 @@ var _ = registerResource("gitlab_project_runner_enablement", func() *schema.Reso
 })
 
 func resourceGitlabProjectRunnerEnablementCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	projectID := d.Get("project").(string)
 	runnerID := d.Get("runner_id").(int)
 	options := &gitlab.EnableProjectRunnerOptions{
@@ -61,7 +61,7 @@ func resourceGitlabProjectRunnerEnablementCreate(ctx context.Context, d *schema.
 }
 
 func resourceGitlabProjectRunnerEnablementRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, runnerID, err := projectAndRunnerFromID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -119,7 +119,7 @@ func projectAndRunnerFromID(id string) (string, int, error) {
 }
 
 func resourceGitlabProjectRunnerEnablementDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	projectID, runnerID, err := projectAndRunnerFromID(d.Id())
 	if err != nil {
@@ -26,12 +26,13 @@ var _ = registerResource("gitlab_project_variable", func() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: gitlabProjectVariableGetSchema(),
+		Schema:        constructSchema(gitlabProjectVariableGetSchema(), adoptExistingSchema()),
+		CustomizeDiff: gitlabVariableDiff,
 	}
 })
 
 func resourceGitlabProjectVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	key := d.Get("key").(string)
@@ -56,7 +57,12 @@ func resourceGitlabProjectVariableCreate(ctx context.Context, d *schema.Resource
 
 	_, _, err := client.ProjectVariables.CreateVariable(project, &options, gitlab.WithContext(ctx))
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		if isAlreadyExistsError(err) && shouldAdoptExisting(d, meta) {
+			log.Printf("[DEBUG] adopt existing gitlab project variable %q", id)
+			d.SetId(id)
+			return resourceGitlabProjectVariableUpdate(ctx, d, meta)
+		}
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	d.SetId(id)
@@ -65,7 +71,7 @@ func resourceGitlabProjectVariableCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGitlabProjectVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	var (
 		project          string
@@ -98,7 +104,7 @@ func resourceGitlabProjectVariableRead(ctx context.Context, d *schema.ResourceDa
 			d.SetId("")
 			return nil
 		}
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	stateMap := gitlabProjectVariableToStateMap(project, variable)
@@ -109,7 +115,7 @@ func resourceGitlabProjectVariableRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGitlabProjectVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	key := d.Get("key").(string)
@@ -130,14 +136,14 @@ func resourceGitlabProjectVariableUpdate(ctx context.Context, d *schema.Resource
 
 	_, _, err := client.ProjectVariables.UpdateVariable(project, key, options, withEnvironmentScopeFilter(ctx, environmentScope))
 	if err != nil {
-		return augmentVariableClientError(d, err)
+		return augmentVariableClientError(ctx, client, d, err)
 	}
 
 	return resourceGitlabProjectVariableRead(ctx, d, meta)
 }
 
 func resourceGitlabProjectVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	key := d.Get("key").(string)
 	environmentScope := d.Get("environment_scope").(string)
@@ -148,5 +154,5 @@ func resourceGitlabProjectVariableDelete(ctx context.Context, d *schema.Resource
 	// destroying or updating scoped variables.
 	// ref: https://gitlab.com/gitlab-org/gitlab/-/merge_requests/39209
 	_, err := client.ProjectVariables.RemoveVariable(project, key, nil, withEnvironmentScopeFilter(ctx, environmentScope))
-	return augmentVariableClientError(d, err)
+	return augmentVariableClientError(ctx, client, d, err)
 }
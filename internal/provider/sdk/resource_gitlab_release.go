@@ -0,0 +1,286 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+var _ = registerResource("gitlab_release", func() *schema.Resource {
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_release`" + ` resource allows to manage the lifecycle of a release of a project.
+
+-> The ` + "`asset_link`" + ` blocks only manage the links they define. Other links of the release, e.g. the ones managed with the ` + "`gitlab_release_link`" + ` resource, are ignored. Don't manage the same link with both.
+
+-> During a terraform destroy this resource deletes the release, but not its tag, even if the tag has been created from the ` + "`ref`" + `.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/releases/)`,
+
+		CreateContext: resourceGitlabReleaseCreate,
+		ReadContext:   resourceGitlabReleaseRead,
+		UpdateContext: resourceGitlabReleaseUpdate,
+		DeleteContext: resourceGitlabReleaseDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: constructSchema(
+			gitlabReleaseGetSchema(),
+			map[string]*schema.Schema{
+				"ref": {
+					Description: "The commit SHA, branch or tag to create the tag from, if the tag doesn't exist yet. This attribute is only used during `create`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"tag_message": {
+					Description: "The message of the annotated tag, which is created from the `ref`. This attribute is only used during `create`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			},
+		),
+	}
+})
+
+func resourceGitlabReleaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+	tagName := d.Get("tag_name").(string)
+
+	options := &gitlab.CreateReleaseOptions{
+		TagName: gitlab.String(tagName),
+	}
+	if name, ok := d.GetOk("name"); ok {
+		options.Name = gitlab.String(name.(string))
+	}
+	if description, ok := d.GetOk("description"); ok {
+		options.Description = gitlab.String(description.(string))
+	}
+	if ref, ok := d.GetOk("ref"); ok {
+		options.Ref = gitlab.String(ref.(string))
+	}
+	if tagMessage, ok := d.GetOk("tag_message"); ok {
+		options.TagMessage = gitlab.String(tagMessage.(string))
+	}
+	if milestones, ok := d.GetOk("milestones"); ok {
+		options.Milestones = stringSetToStringSlice(milestones.(*schema.Set))
+	}
+	if releasedAt, ok := d.GetOk("released_at"); ok {
+		t, err := time.Parse(time.RFC3339, releasedAt.(string))
+		if err != nil {
+			return diag.Errorf("failed to parse released_at %q: %v", releasedAt, err)
+		}
+		options.ReleasedAt = &t
+	}
+	assetLinks := d.Get("asset_link").([]interface{})
+	if len(assetLinks) > 0 {
+		options.Assets = &gitlab.ReleaseAssetsOptions{}
+		for _, assetLink := range assetLinks {
+			assetLink := assetLink.(map[string]interface{})
+			linkType := gitlab.LinkTypeValue(assetLink["link_type"].(string))
+			linkOptions := &gitlab.ReleaseAssetLinkOptions{
+				Name:     gitlab.String(assetLink["name"].(string)),
+				URL:      gitlab.String(assetLink["url"].(string)),
+				LinkType: &linkType,
+			}
+			if filePath := assetLink["filepath"].(string); filePath != "" {
+				linkOptions.FilePath = gitlab.String(filePath)
+			}
+			options.Assets.Links = append(options.Assets.Links, linkOptions)
+		}
+	}
+
+	log.Printf("[DEBUG] create release project/tagName: %s/%s", project, tagName)
+	release, _, err := client.Releases.CreateRelease(project, options, gitlab.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(buildTwoPartID(&project, &tagName))
+
+	// all the links of a new release are the ones of the `asset_link` blocks
+	linkIDs := make(map[string]int)
+	for _, link := range release.Assets.Links {
+		linkIDs[link.Name] = link.ID
+	}
+	if err := setGitlabReleaseAssetLinkIDs(d, linkIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGitlabReleaseRead(ctx, d, meta)
+}
+
+func resourceGitlabReleaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, tagName, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] read release project/tagName: %s/%s", project, tagName)
+	release, err := getGitlabRelease(ctx, client, project, tagName)
+	if err != nil {
+		if is404(err) {
+			log.Printf("[WARN] release %s in project %s not found, removing from state", tagName, project)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// only the links of the `asset_link` blocks are managed by this resource,
+	// a link which has been deleted outside of Terraform is removed from the state to be created again.
+	var managedLinks []*gitlab.ReleaseLink
+	for _, assetLink := range d.Get("asset_link").([]interface{}) {
+		linkID := assetLink.(map[string]interface{})["link_id"].(int)
+		for _, link := range release.Assets.Links {
+			if link.ID == linkID {
+				managedLinks = append(managedLinks, link)
+			}
+		}
+	}
+
+	stateMap := gitlabReleaseToStateMap(project, release, managedLinks)
+	if err = setStateMapInResourceData(stateMap, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceGitlabReleaseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, tagName, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "description", "milestones", "released_at") {
+		// NOTE: GitLab clears the name and description if they are not given.
+		options := &gitlab.UpdateReleaseOptions{
+			Name:        gitlab.String(d.Get("name").(string)),
+			Description: gitlab.String(d.Get("description").(string)),
+		}
+		if d.HasChange("milestones") {
+			options.Milestones = stringSetToStringSlice(d.Get("milestones").(*schema.Set))
+		}
+		if releasedAt, ok := d.GetOk("released_at"); ok && d.HasChange("released_at") {
+			t, err := time.Parse(time.RFC3339, releasedAt.(string))
+			if err != nil {
+				return diag.Errorf("failed to parse released_at %q: %v", releasedAt, err)
+			}
+			options.ReleasedAt = &t
+		}
+
+		log.Printf("[DEBUG] update release project/tagName: %s/%s", project, tagName)
+		if _, _, err := client.Releases.UpdateRelease(project, tagName, options, gitlab.WithContext(ctx)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("asset_link") {
+		if err := updateGitlabReleaseAssetLinks(ctx, d, client, project, tagName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceGitlabReleaseRead(ctx, d, meta)
+}
+
+func resourceGitlabReleaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, tagName, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] delete release project/tagName: %s/%s", project, tagName)
+	if _, _, err := client.Releases.DeleteRelease(project, tagName, gitlab.WithContext(ctx)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// updateGitlabReleaseAssetLinks creates, updates and deletes the links of the release, which are managed in the
+// `asset_link` blocks. The links are matched by their name, which is unique within the release.
+func updateGitlabReleaseAssetLinks(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string, tagName string) error {
+	oldValue, newValue := d.GetChange("asset_link")
+	oldLinks := make(map[string]map[string]interface{})
+	for _, assetLink := range oldValue.([]interface{}) {
+		assetLink := assetLink.(map[string]interface{})
+		oldLinks[assetLink["name"].(string)] = assetLink
+	}
+	newLinks := make(map[string]map[string]interface{})
+	for _, assetLink := range newValue.([]interface{}) {
+		assetLink := assetLink.(map[string]interface{})
+		newLinks[assetLink["name"].(string)] = assetLink
+	}
+
+	// the removed links are deleted first, because the names and URLs of the links must be unique within the release
+	for name, oldLink := range oldLinks {
+		if _, ok := newLinks[name]; ok {
+			continue
+		}
+		linkID := oldLink["link_id"].(int)
+		log.Printf("[DEBUG] delete release link project/tagName/linkID: %s/%s/%d", project, tagName, linkID)
+		if _, _, err := client.ReleaseLinks.DeleteReleaseLink(project, tagName, linkID, gitlab.WithContext(ctx)); err != nil && !is404(err) {
+			return fmt.Errorf("failed to delete release link %q: %w", name, err)
+		}
+	}
+
+	linkIDs := make(map[string]int)
+	for name, newLink := range newLinks {
+		linkType := gitlab.LinkTypeValue(newLink["link_type"].(string))
+		oldLink, ok := oldLinks[name]
+		if !ok || oldLink["link_id"].(int) == 0 {
+			log.Printf("[DEBUG] create release link project/tagName/name: %s/%s/%s", project, tagName, name)
+			options := &gitlab.CreateReleaseLinkOptions{
+				Name:     gitlab.String(name),
+				URL:      gitlab.String(newLink["url"].(string)),
+				LinkType: &linkType,
+			}
+			if filePath := newLink["filepath"].(string); filePath != "" {
+				options.FilePath = gitlab.String(filePath)
+			}
+			link, _, err := client.ReleaseLinks.CreateReleaseLink(project, tagName, options, gitlab.WithContext(ctx))
+			if err != nil {
+				return fmt.Errorf("failed to create release link %q: %w", name, err)
+			}
+			linkIDs[name] = link.ID
+			continue
+		}
+
+		linkID := oldLink["link_id"].(int)
+		linkIDs[name] = linkID
+		if oldLink["url"] == newLink["url"] && oldLink["filepath"] == newLink["filepath"] && oldLink["link_type"] == newLink["link_type"] {
+			continue
+		}
+		log.Printf("[DEBUG] update release link project/tagName/linkID: %s/%s/%d", project, tagName, linkID)
+		options := &gitlab.UpdateReleaseLinkOptions{
+			URL:      gitlab.String(newLink["url"].(string)),
+			LinkType: &linkType,
+		}
+		if oldLink["filepath"] != newLink["filepath"] {
+			options.FilePath = gitlab.String(newLink["filepath"].(string))
+		}
+		if _, _, err := client.ReleaseLinks.UpdateReleaseLink(project, tagName, linkID, options, gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to update release link %q: %w", name, err)
+		}
+	}
+
+	return setGitlabReleaseAssetLinkIDs(d, linkIDs)
+}
+
+// setGitlabReleaseAssetLinkIDs stores the IDs of the links of the `asset_link` blocks by their name,
+// which identify the links managed by the resource when it's read.
+func setGitlabReleaseAssetLinkIDs(d *schema.ResourceData, linkIDs map[string]int) error {
+	assetLinks := d.Get("asset_link").([]interface{})
+	for _, assetLink := range assetLinks {
+		assetLink := assetLink.(map[string]interface{})
+		assetLink["link_id"] = linkIDs[assetLink["name"].(string)]
+	}
+	return d.Set("asset_link", assetLinks)
+}
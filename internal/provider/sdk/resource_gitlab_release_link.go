@@ -30,7 +30,7 @@ var _ = registerResource("gitlab_release_link", func() *schema.Resource {
 })
 
 func resourceGitlabReleaseLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Get("project").(string)
 	tagName := d.Get("tag_name").(string)
 	name := d.Get("name").(string)
@@ -60,7 +60,7 @@ func resourceGitlabReleaseLinkCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabReleaseLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, tagName, linkID, err := resourceGitLabReleaseLinkParseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -86,7 +86,7 @@ func resourceGitlabReleaseLinkRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabReleaseLinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, tagName, linkID, err := resourceGitLabReleaseLinkParseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -118,7 +118,7 @@ func resourceGitlabReleaseLinkUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabReleaseLinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, tagName, linkID, err := resourceGitLabReleaseLinkParseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
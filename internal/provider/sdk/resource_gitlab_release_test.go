@@ -0,0 +1,164 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccGitlabRelease_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	testMilestones := testutil.AddProjectMilestones(t, testProject, 2)
+	tagName := acctest.RandomWithPrefix("acctest")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		CheckDestroy:             testAccCheckGitlabReleaseDestroy,
+		Steps: []resource.TestStep{
+			// create a release and its tag with required values only
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_release" "this" {
+				  project  = %d
+				  tag_name = "%s"
+				  ref      = "%s"
+				}
+				`, testProject.ID, tagName, testProject.DefaultBranch),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_release.this", "name", tagName),
+					resource.TestCheckResourceAttr("gitlab_release.this", "upcoming_release", "false"),
+					resource.TestCheckResourceAttrSet("gitlab_release.this", "released_at"),
+					resource.TestCheckResourceAttrSet("gitlab_release.this", "commit_sha"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_release.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref", "tag_message"},
+			},
+			// update all release attributes, next to a link managed by another resource
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_release" "this" {
+				  project     = %d
+				  tag_name    = "%s"
+				  ref         = "%s"
+				  name        = "First release"
+				  description = "The first release"
+				  released_at = "2022-01-01T00:00:00Z"
+				  milestones  = ["%s", "%s"]
+
+				  asset_link {
+				    name     = "binary"
+				    url      = "https://example.com/binary"
+				    filepath = "/bin/binary"
+				  }
+
+				  asset_link {
+				    name      = "checksums"
+				    url       = "https://example.com/checksums"
+				    link_type = "other"
+				  }
+				}
+
+				resource "gitlab_release_link" "docs" {
+				  project  = gitlab_release.this.project
+				  tag_name = gitlab_release.this.tag_name
+				  name     = "docs"
+				  url      = "https://example.com/docs"
+				}
+				`, testProject.ID, tagName, testProject.DefaultBranch, testMilestones[0].Title, testMilestones[1].Title),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_release.this", "name", "First release"),
+					resource.TestCheckResourceAttr("gitlab_release.this", "released_at", "2022-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("gitlab_release.this", "milestones.#", "2"),
+					resource.TestCheckResourceAttr("gitlab_release.this", "asset_link.#", "2"),
+					resource.TestCheckResourceAttr("gitlab_release.this", "asset_link.0.filepath", "/bin/binary"),
+					resource.TestCheckResourceAttrSet("gitlab_release.this", "asset_link.0.link_id"),
+					resource.TestCheckResourceAttrSet("gitlab_release.this", "asset_link.0.direct_asset_url"),
+				),
+			},
+			// Verify import
+			{
+				ResourceName:            "gitlab_release.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref", "tag_message", "asset_link"},
+			},
+			// remove a link and the milestones
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_release" "this" {
+				  project     = %d
+				  tag_name    = "%s"
+				  ref         = "%s"
+				  name        = "First release"
+				  released_at = "2022-01-01T00:00:00Z"
+
+				  asset_link {
+				    name      = "checksums"
+				    url       = "https://example.com/sha256sums"
+				    link_type = "package"
+				  }
+				}
+
+				resource "gitlab_release_link" "docs" {
+				  project  = gitlab_release.this.project
+				  tag_name = gitlab_release.this.tag_name
+				  name     = "docs"
+				  url      = "https://example.com/docs"
+				}
+				`, testProject.ID, tagName, testProject.DefaultBranch),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_release.this", "description", ""),
+					resource.TestCheckResourceAttr("gitlab_release.this", "milestones.#", "0"),
+					resource.TestCheckResourceAttr("gitlab_release.this", "asset_link.#", "1"),
+					resource.TestCheckResourceAttr("gitlab_release.this", "asset_link.0.url", "https://example.com/sha256sums"),
+					resource.TestCheckResourceAttr("gitlab_release.this", "asset_link.0.link_type", "package"),
+					func(s *terraform.State) error {
+						release, _, err := testutil.TestGitlabClient.Releases.GetRelease(testProject.ID, tagName)
+						if err != nil {
+							return err
+						}
+						if len(release.Assets.Links) != 2 {
+							return fmt.Errorf("expected the release to have 2 links, got %d", len(release.Assets.Links))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabReleaseDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "gitlab_release" {
+			continue
+		}
+
+		project, tagName, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = testutil.TestGitlabClient.Releases.GetRelease(project, tagName)
+		if err == nil {
+			return fmt.Errorf("Release %s still exists", tagName)
+		}
+		if !is404(err) {
+			return err
+		}
+	}
+	return nil
+}
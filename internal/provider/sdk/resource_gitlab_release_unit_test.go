@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestUnitGitlabRelease_fakeGitLab(t *testing.T) {
+	// newReleaseResource returns the resource with the milestone `v1` in the project
+	newReleaseResource := func(t *testing.T) *fakeGitLabResource {
+		t.Helper()
+		f := newFakeGitLabResource(t, "gitlab_release")
+		if _, _, err := f.fake.Client.Milestones.CreateMilestone(f.project.ID, &gitlab.CreateMilestoneOptions{Title: gitlab.String("v1")}); err != nil {
+			t.Fatalf("failed to create milestone: %v", err)
+		}
+		return f
+	}
+	config := func(links ...map[string]interface{}) map[string]interface{} {
+		assetLinks := make([]interface{}, 0, len(links))
+		for _, link := range links {
+			assetLinks = append(assetLinks, link)
+		}
+		return map[string]interface{}{
+			"project":     "root/api",
+			"tag_name":    "v1.0.0",
+			"ref":         "main",
+			"description": "The first release",
+			"milestones":  []interface{}{"v1"},
+			"asset_link":  assetLinks,
+		}
+	}
+	binary := map[string]interface{}{"name": "binary", "url": "https://example.com/binary", "filepath": "/bin/api"}
+	checksums := map[string]interface{}{"name": "checksums", "url": "https://example.com/checksums", "link_type": "other"}
+	// createDocsLink creates a link of another resource
+	createDocsLink := func(t *testing.T, f *fakeGitLabResource) {
+		t.Helper()
+		if _, _, err := f.fake.Client.ReleaseLinks.CreateReleaseLink(f.project.ID, "v1.0.0", &gitlab.CreateReleaseLinkOptions{Name: gitlab.String("docs"), URL: gitlab.String("https://example.com/docs")}); err != nil {
+			t.Fatalf("failed to create release link: %v", err)
+		}
+	}
+
+	t.Run("create creates the tag from the ref", func(t *testing.T) {
+		f := newReleaseResource(t)
+		d := f.create(t, config(binary))
+
+		if _, _, err := f.fake.Client.Tags.GetTag(f.project.ID, "v1.0.0"); err != nil {
+			t.Errorf("expected the tag to be created from the ref: %v", err)
+		}
+		checkAttributes(t, d, map[string]interface{}{
+			"name":                  "v1.0.0",
+			"milestones.#":          1,
+			"asset_link.#":          1,
+			"asset_link.0.filepath": "/bin/api",
+			"evidences.#":           1,
+		})
+		if d.Get("asset_link.0.link_id") == 0 {
+			t.Errorf("expected the ID of the link in the state")
+		}
+	})
+
+	t.Run("the links of other resources are ignored", func(t *testing.T) {
+		f := newReleaseResource(t)
+		d := f.create(t, config(binary))
+		createDocsLink(t, f)
+
+		f.read(t, d)
+		checkAttributes(t, d, map[string]interface{}{
+			"asset_link.#":      1,
+			"asset_link.0.name": "binary",
+		})
+	})
+
+	t.Run("update updates, creates and deletes the links by their name", func(t *testing.T) {
+		f := newReleaseResource(t)
+		d := f.create(t, config(binary, map[string]interface{}{"name": "old", "url": "https://example.com/old"}))
+		createDocsLink(t, f)
+
+		updated := config(checksums, map[string]interface{}{"name": "binary", "url": "https://example.com/api", "filepath": "/bin/api", "link_type": "package"})
+		updated["name"] = "First release"
+		updated["milestones"] = []interface{}{}
+		d = f.update(t, d, updated)
+
+		checkAttributes(t, d, map[string]interface{}{
+			"name":              "First release",
+			"milestones.#":      0,
+			"asset_link.#":      2,
+			"asset_link.0.name": "checksums",
+			"asset_link.1.name": "binary",
+		})
+		release, _, err := f.fake.Client.Releases.GetRelease(f.project.ID, "v1.0.0")
+		if err != nil {
+			t.Fatalf("failed to get release: %v", err)
+		}
+		links := make(map[string]*gitlab.ReleaseLink)
+		for _, link := range release.Assets.Links {
+			links[link.Name] = link
+		}
+		checkValues(t, map[string]interface{}{
+			"name":        release.Name,
+			"description": release.Description,
+			"links":       len(links),
+		}, map[string]interface{}{
+			"name":        "First release",
+			"description": "The first release",
+			"links":       3,
+		})
+		if link := links["old"]; link != nil {
+			t.Errorf("expected the removed link to be deleted, got %+v", link)
+		}
+		if link := links["binary"]; link == nil {
+			t.Errorf("expected the binary link, got %v", links)
+		} else {
+			checkValues(t, map[string]interface{}{
+				"url":       link.URL,
+				"link_type": link.LinkType,
+			}, map[string]interface{}{
+				"url":       "https://example.com/api",
+				"link_type": gitlab.PackageLinkType,
+			})
+		}
+	})
+
+	t.Run("a link deleted outside of Terraform is removed from the state", func(t *testing.T) {
+		f := newReleaseResource(t)
+		d := f.create(t, config(checksums, binary))
+		if _, _, err := f.fake.Client.ReleaseLinks.DeleteReleaseLink(f.project.ID, "v1.0.0", d.Get("asset_link.0.link_id").(int)); err != nil {
+			t.Fatalf("failed to delete release link: %v", err)
+		}
+
+		// and created again on the next apply
+		f.read(t, d)
+		checkAttributes(t, d, map[string]interface{}{
+			"asset_link.#":      1,
+			"asset_link.0.name": "binary",
+		})
+	})
+
+	t.Run("the data sources return all links", func(t *testing.T) {
+		f := newReleaseResource(t)
+		f.create(t, config(binary))
+		createDocsLink(t, f)
+
+		release := f.readDataSource(t, "gitlab_release", map[string]interface{}{"project": "root/api", "tag_name": "v1.0.0"})
+		checkAttributes(t, release, map[string]interface{}{
+			"name":         "v1.0.0",
+			"asset_link.#": 2,
+		})
+		releases := f.readDataSource(t, "gitlab_releases", map[string]interface{}{"project": "root/api"})
+		checkAttributes(t, releases, map[string]interface{}{
+			"releases.#":             1,
+			"releases.0.tag_name":    "v1.0.0",
+			"releases.0.evidences.#": 1,
+		})
+	})
+
+	t.Run("delete keeps the tag", func(t *testing.T) {
+		f := newReleaseResource(t)
+		d := f.create(t, config(binary))
+
+		f.delete(t, d)
+		if _, _, err := f.fake.Client.Releases.GetRelease(f.project.ID, "v1.0.0"); !is404(err) {
+			t.Errorf("expected the release to be deleted, got %v", err)
+		}
+		if _, _, err := f.fake.Client.Tags.GetTag(f.project.ID, "v1.0.0"); err != nil {
+			t.Errorf("expected the tag to be kept: %v", err)
+		}
+	})
+}
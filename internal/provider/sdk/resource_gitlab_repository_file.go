@@ -2,7 +2,6 @@ package sdk
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -18,31 +17,20 @@ import (
 
 const encoding = "base64"
 
-// NOTE: this lock is a bit of a hack to prevent parallel calls to the GitLab Repository Files API.
-//
-//	If it is called concurrently, the API will return a 400 error along the lines of:
-//	```
-//	(400 Bad Request) DELETE https://gitlab.com/api/v4/projects/30716/repository/files/somefile.yaml: 400
-//	{message: 9:Could not update refs/heads/master. Please refresh and try again..}
-//	```
-//
-//	This lock only solves half of the problem, where the provider is responsible for
-//	the concurrency. The other half is if the API is called outside of terraform at the same time
-//	this resource makes calls to the API.
-//	To mitigate this, simple retries are used.
-var resourceGitlabRepositoryFileApiLock = newLock()
-
 var _ = registerResource("gitlab_repository_file", func() *schema.Resource {
 	return &schema.Resource{
 		Description: `The ` + "`gitlab_repository_file`" + ` resource allows to manage the lifecycle of a file within a repository.
 
 -> **Timeouts** Default timeout for *Create*, *Update* and *Delete* is one minute and can be configured in the ` + "`timeouts`" + ` block.
 
--> **Implementation Detail** GitLab is unable to handle concurrent calls to the GitLab repository files API for the same project.
-   Therefore, this resource queues every call to the repository files API no matter of the project, which may slow down the terraform
-   execution time for some configurations. In addition, retries are performed in case a refresh is required because another application
+-> **Implementation Detail** GitLab is unable to handle concurrent commits to the same branch of a project.
+   Therefore, this resource queues the calls to the repository files API for the same project and branch, which may slow down the terraform
+   execution time for configurations with many files on the same branch. In addition, retries are performed in case a refresh is required because another application
    changed the repository at the same time.
 
+-> **Merge Requests** With the ` + "`merge_request`" + ` block, the changes are proposed with a merge request instead of being committed
+   to the ` + "`branch`" + ` directly, e.g. for protected branches. While the merge request is open, the file is read from its source branch.
+
 **Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/repository_files.html)`,
 
 		CreateContext: resourceGitlabRepositoryFileCreate,
@@ -76,9 +64,10 @@ var _ = registerResource("gitlab_repository_file", func() *schema.Resource {
 					Required:    true,
 				},
 				"start_branch": {
-					Description: "Name of the branch to start the new commit from.",
-					Type:        schema.TypeString,
-					Optional:    true,
+					Description:   "Name of the branch to start the new commit from.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"merge_request"},
 				},
 				"author_email": {
 					Description: "Email of the commit author.",
@@ -90,6 +79,7 @@ var _ = registerResource("gitlab_repository_file", func() *schema.Resource {
 					Type:        schema.TypeString,
 					Optional:    true,
 				},
+				"merge_request": repositoryMergeRequestSchema(),
 			},
 			gitlabRepositoryFileGetSchema(),
 		),
@@ -99,25 +89,26 @@ var _ = registerResource("gitlab_repository_file", func() *schema.Resource {
 func resourceGitlabRepositoryFileCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	project := d.Get("project").(string)
 	filePath := d.Get("file_path").(string)
+	branch := d.Get("branch").(string)
 
 	log.Printf("[DEBUG] gitlab_repository_file: waiting for lock to create %s/%s", project, filePath)
-	if err := resourceGitlabRepositoryFileApiLock.lock(ctx); err != nil {
+	unlock, err := lockRepositoryBranch(ctx, project, branch)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	defer resourceGitlabRepositoryFileApiLock.unlock()
+	defer unlock()
 	log.Printf("[DEBUG] gitlab_repository_file: got lock to create %s/%s", project, filePath)
 
-	client := meta.(*gitlab.Client)
-	// NOTE: for backwards-compatibility reasons, we also support an already given base64 encoding,
-	//       otherwise we encode the `content` to base64.
-	content := d.Get("content").(string)
-	if _, err := base64.StdEncoding.DecodeString(content); err != nil {
-		log.Printf("[DEBUG] gitlab_repository_file: given content '%s' is not a valid base64 encoded string, encoding it ...", content)
-		content = base64.StdEncoding.EncodeToString([]byte(content))
+	client := meta.(*providerMeta).client
+	content := encodeRepositoryFileContent(d.Get("content").(string))
+
+	commitBranch, err := prepareRepositoryChange(ctx, d, client, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	options := &gitlab.CreateFileOptions{
-		Branch:        gitlab.String(d.Get("branch").(string)),
+		Branch:        gitlab.String(commitBranch),
 		Encoding:      gitlab.String(encoding),
 		AuthorEmail:   gitlab.String(d.Get("author_email").(string)),
 		AuthorName:    gitlab.String(d.Get("author_name").(string)),
@@ -183,12 +174,15 @@ func resourceGitlabRepositoryFileCreate(ctx context.Context, d *schema.ResourceD
 		}
 
 		if existingRepositoryFile != nil {
-			d.SetId(resourceGitLabRepositoryFileBuildId(project, *options.Branch, existingRepositoryFile.FilePath))
+			d.SetId(resourceGitLabRepositoryFileBuildId(project, branch, existingRepositoryFile.FilePath))
+			if err := finishRepositoryChange(ctx, d, client, project, branch, commitBranch, repositoryMergeRequestTitle(d)); err != nil {
+				return diag.FromErr(err)
+			}
 			return resourceGitlabRepositoryFileRead(ctx, d, meta)
 		}
 	}
 
-	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 		repositoryFile, _, err := client.RepositoryFiles.CreateFile(project, filePath, options, gitlab.WithContext(ctx))
 		if err != nil {
 			if isRefreshError(err) {
@@ -197,25 +191,34 @@ func resourceGitlabRepositoryFileCreate(ctx context.Context, d *schema.ResourceD
 			return resource.NonRetryableError(err)
 		}
 
-		d.SetId(resourceGitLabRepositoryFileBuildId(project, repositoryFile.Branch, repositoryFile.FilePath))
+		d.SetId(resourceGitLabRepositoryFileBuildId(project, branch, repositoryFile.FilePath))
 		return nil
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := finishRepositoryChange(ctx, d, client, project, branch, commitBranch, repositoryMergeRequestTitle(d)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceGitlabRepositoryFileRead(ctx, d, meta)
 }
 
 func resourceGitlabRepositoryFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project, branch, filePath, err := resourceGitLabRepositoryFileParseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// while the merge request is open, the file is read from its source branch
+	ref, err := readRepositoryMergeRequest(ctx, d, client, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	options := &gitlab.GetFileOptions{
-		Ref: gitlab.String(branch),
+		Ref: gitlab.String(ref),
 	}
 
 	repositoryFile, _, err := client.RepositoryFiles.GetFile(project, filePath, options, gitlab.WithContext(ctx))
@@ -230,18 +233,10 @@ func resourceGitlabRepositoryFileRead(ctx context.Context, d *schema.ResourceDat
 
 	configContent := d.Get("content").(string)
 	log.Printf("[DEBUG] gitlab_repository_file: comparing content of %s with %s", repositoryFile.Content, configContent)
-	// NOTE: for backwards-compatibility reasons, we also support an already given base64 encoding,
-	//       otherwise we encode the `content` to base64.
-	if _, err := base64.StdEncoding.DecodeString(configContent); err != nil {
-		// if `content` is config is not a base64 encoded string, we decode the one from the API, too
-		// in case it's base64 encoded, else we don't decode it.
-		if decodedContent, err := base64.StdEncoding.DecodeString(repositoryFile.Content); err == nil {
-			repositoryFile.Content = string(decodedContent)
-		}
-	}
+	repositoryFile.Content = decodeRepositoryFileContent(configContent, repositoryFile.Content)
 
 	d.SetId(resourceGitLabRepositoryFileBuildId(project, branch, repositoryFile.FilePath))
-	d.Set("branch", repositoryFile.Ref)
+	d.Set("branch", branch)
 	stateMap := gitlabRepositoryFileToStateMap(project, repositoryFile)
 	if err = setStateMapInResourceData(stateMap, d); err != nil {
 		return diag.FromErr(err)
@@ -257,28 +252,66 @@ func resourceGitlabRepositoryFileUpdate(ctx context.Context, d *schema.ResourceD
 	}
 
 	log.Printf("[DEBUG] gitlab_repository_file: waiting for lock to update %s/%s", project, filePath)
-	if err := resourceGitlabRepositoryFileApiLock.lock(ctx); err != nil {
+	unlock, err := lockRepositoryBranch(ctx, project, branch)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	defer resourceGitlabRepositoryFileApiLock.unlock()
+	defer unlock()
 	log.Printf("[DEBUG] gitlab_repository_file: got lock to update %s/%s", project, filePath)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
-	readOptions := &gitlab.GetFileOptions{
-		Ref: gitlab.String(branch),
+	// the changes of the open merge request are discarded when the `merge_request` block is removed,
+	// so the file is committed to the `branch` directly, where it may not exist yet
+	closed, err := closeRemovedRepositoryMergeRequest(ctx, d, client, project)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if closed {
+		if _, _, err := client.RepositoryFiles.GetFileMetaData(project, filePath, &gitlab.GetFileMetaDataOptions{Ref: gitlab.String(branch)}, gitlab.WithContext(ctx)); err != nil {
+			if !is404(err) {
+				return diag.FromErr(err)
+			}
+			log.Printf("[DEBUG] gitlab_repository_file: %s doesn't exist on %s after closing the merge request, creating it", filePath, branch)
+			options := &gitlab.CreateFileOptions{
+				Branch:        gitlab.String(branch),
+				Encoding:      gitlab.String(encoding),
+				AuthorEmail:   gitlab.String(d.Get("author_email").(string)),
+				AuthorName:    gitlab.String(d.Get("author_name").(string)),
+				Content:       gitlab.String(encodeRepositoryFileContent(d.Get("content").(string))),
+				CommitMessage: gitlab.String(d.Get("commit_message").(string)),
+			}
+			if executeFilemode, ok := d.GetOk("execute_filemode"); ok {
+				options.ExecuteFilemode = gitlab.Bool(executeFilemode.(bool))
+			}
+			if _, _, err := client.RepositoryFiles.CreateFile(project, filePath, options, gitlab.WithContext(ctx)); err != nil {
+				return diag.FromErr(err)
+			}
+			return resourceGitlabRepositoryFileRead(ctx, d, meta)
+		}
 	}
 
-	// NOTE: for backwards-compatibility reasons, we also support an already given base64 encoding,
-	//       otherwise we encode the `content` to base64.
-	content := d.Get("content").(string)
-	if _, err := base64.StdEncoding.DecodeString(content); err != nil {
-		log.Printf("[DEBUG] gitlab_repository_file: given content '%s' is not a valid base64 encoded string, encoding it ...", content)
-		content = base64.StdEncoding.EncodeToString([]byte(content))
+	// only the merge request needs to be updated if the file itself didn't change
+	if hasRepositoryMergeRequest(d) && !d.HasChanges("content", "execute_filemode") {
+		if err := finishRepositoryChange(ctx, d, client, project, branch, "", repositoryMergeRequestTitle(d)); err != nil {
+			return diag.FromErr(err)
+		}
+		return resourceGitlabRepositoryFileRead(ctx, d, meta)
+	}
+
+	commitBranch, err := prepareRepositoryChange(ctx, d, client, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	readOptions := &gitlab.GetFileOptions{
+		Ref: gitlab.String(commitBranch),
 	}
 
+	content := encodeRepositoryFileContent(d.Get("content").(string))
+
 	updateOptions := &gitlab.UpdateFileOptions{
-		Branch:        gitlab.String(branch),
+		Branch:        gitlab.String(commitBranch),
 		Encoding:      gitlab.String(encoding),
 		AuthorEmail:   gitlab.String(d.Get("author_email").(string)),
 		AuthorName:    gitlab.String(d.Get("author_name").(string)),
@@ -313,6 +346,9 @@ func resourceGitlabRepositoryFileUpdate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := finishRepositoryChange(ctx, d, client, project, branch, commitBranch, repositoryMergeRequestTitle(d)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceGitlabRepositoryFileRead(ctx, d, meta)
 }
@@ -324,19 +360,38 @@ func resourceGitlabRepositoryFileDelete(ctx context.Context, d *schema.ResourceD
 	}
 
 	log.Printf("[DEBUG] gitlab_repository_file: waiting for lock to delete %s/%s", project, filePath)
-	if err := resourceGitlabRepositoryFileApiLock.lock(ctx); err != nil {
+	unlock, err := lockRepositoryBranch(ctx, project, branch)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	defer resourceGitlabRepositoryFileApiLock.unlock()
+	defer unlock()
 	log.Printf("[DEBUG] gitlab_repository_file: got lock to delete %s/%s", project, filePath)
 
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
+
+	if hasRepositoryMergeRequest(d) {
+		// the changes of an open merge request are discarded, but a file which has already been merged must be deleted
+		if err := closeRepositoryMergeRequest(ctx, d, client, project); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, _, err := client.RepositoryFiles.GetFileMetaData(project, filePath, &gitlab.GetFileMetaDataOptions{Ref: gitlab.String(branch)}, gitlab.WithContext(ctx)); err != nil {
+			if is404(err) {
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+	}
+
+	commitBranch, err := prepareRepositoryChange(ctx, d, client, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	readOptions := &gitlab.GetFileOptions{
-		Ref: gitlab.String(branch),
+		Ref: gitlab.String(commitBranch),
 	}
 	deleteOptions := &gitlab.DeleteFileOptions{
-		Branch:        gitlab.String(d.Get("branch").(string)),
+		Branch:        gitlab.String(commitBranch),
 		AuthorEmail:   gitlab.String(d.Get("author_email").(string)),
 		AuthorName:    gitlab.String(d.Get("author_name").(string)),
 		CommitMessage: gitlab.String(fmt.Sprintf("[DELETE]: %s", d.Get("commit_message").(string))),
@@ -363,6 +418,12 @@ func resourceGitlabRepositoryFileDelete(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := finishRepositoryChange(ctx, d, client, project, branch, commitBranch, fmt.Sprintf("[DELETE]: %s", repositoryMergeRequestTitle(d))); err != nil {
+		return diag.FromErr(err)
+	}
+	if hasRepositoryMergeRequest(d) {
+		return repositoryDeletionMergeRequestWarning(d, branch)
+	}
 
 	return nil
 }
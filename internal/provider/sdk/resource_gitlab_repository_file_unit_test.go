@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestUnitGitlabRepositoryFileMergeRequest_fakeGitLab(t *testing.T) {
+	config := func(mergeRequest map[string]interface{}) map[string]interface{} {
+		config := map[string]interface{}{
+			"project":        "root/api",
+			"branch":         "main",
+			"file_path":      "a.txt",
+			"content":        "meow meow",
+			"commit_message": "feature: cats",
+		}
+		if mergeRequest != nil {
+			config["merge_request"] = []interface{}{mergeRequest}
+		}
+		return config
+	}
+
+	t.Run("create proposes the file with a merge request", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_file")
+		d := f.create(t, config(map[string]interface{}{"auto_merge": true}))
+
+		// the file is read from the source branch
+		checkAttributes(t, d, map[string]interface{}{
+			"id":      fmt.Sprintf("%d:main:a.txt", f.project.ID),
+			"branch":  "main",
+			"content": "meow meow",
+		})
+		mergeRequest := f.mergeRequest(t, d.Get("merge_request.0.iid").(int))
+		checkValues(t, map[string]interface{}{
+			"title":                        mergeRequest.Title,
+			"merge_when_pipeline_succeeds": mergeRequest.MergeWhenPipelineSucceeds,
+		}, map[string]interface{}{
+			"title":                        "feature: cats",
+			"merge_when_pipeline_succeeds": true,
+		})
+	})
+
+	t.Run("closed merge request removes the file from the state", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_file")
+		d := f.create(t, config(map[string]interface{}{}))
+		if _, _, err := f.fake.Client.MergeRequests.UpdateMergeRequest(f.project.ID, d.Get("merge_request.0.iid").(int), &gitlab.UpdateMergeRequestOptions{StateEvent: gitlab.String("close")}); err != nil {
+			t.Fatalf("failed to close merge request: %v", err)
+		}
+
+		// the file is created again
+		f.read(t, d)
+		checkAttributes(t, d, map[string]interface{}{"id": ""})
+	})
+
+	t.Run("removing the block closes the merge request and commits to the branch", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_file")
+		d := f.create(t, config(map[string]interface{}{}))
+		iid := d.Get("merge_request.0.iid").(int)
+
+		f.update(t, d, config(nil))
+		checkValues(t, map[string]interface{}{"state": f.mergeRequest(t, iid).State}, map[string]interface{}{"state": "closed"})
+		checkValues(t, f.repositoryFile(t, "main", "a.txt"), map[string]interface{}{"content": "meow meow"})
+	})
+
+	t.Run("delete proposes the deletion of the merged file with a merge request", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_file")
+		d := f.create(t, config(nil))
+		d = f.update(t, d, config(map[string]interface{}{}))
+
+		diags := f.delete(t, d)
+		// the deletion is reported with a warning
+		if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, d.Get("merge_request.0.web_url").(string)) {
+			t.Errorf("expected a warning with the URL of the merge request, got %v", diags)
+		}
+		// the file remains on the branch until the merge request is merged
+		checkValues(t, f.repositoryFile(t, "main", "a.txt"), map[string]interface{}{"content": "meow meow"})
+	})
+}
@@ -0,0 +1,459 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+var _ = registerResource("gitlab_repository_files", func() *schema.Resource {
+	fileSchema := gitlabRepositoryFileGetSchema()
+	return &schema.Resource{
+		Description: `The ` + "`gitlab_repository_files`" + ` resource allows to manage multiple files within a repository, which are written in a single commit.
+
+In contrast to the ` + "`gitlab_repository_file`" + ` resource, which creates a commit for each file, all changes to the files of this resource
+are applied in a single commit, e.g. to bootstrap a repository without triggering a pipeline for each file.
+
+-> **Drift Detection** Files which have been changed or deleted outside of Terraform since the ` + "`last_commit_id`" + ` are refreshed
+   and the changes are reverted on the next apply.
+
+-> **Merge Requests** With the ` + "`merge_request`" + ` block, the changes are proposed with a merge request instead of being committed
+   to the ` + "`branch`" + ` directly, e.g. for protected branches. While the merge request is open, the files are read from its source branch.
+
+**Upstream API**: [GitLab REST API docs](https://docs.gitlab.com/ee/api/commits.html#create-a-commit-with-multiple-files-and-actions)`,
+
+		CreateContext: resourceGitlabRepositoryFilesCreate,
+		ReadContext:   resourceGitlabRepositoryFilesRead,
+		UpdateContext: resourceGitlabRepositoryFilesUpdate,
+		DeleteContext: resourceGitlabRepositoryFilesDelete,
+		CustomizeDiff: resourceGitlabRepositoryFilesDiff,
+
+		Schema: map[string]*schema.Schema{
+			"project": fileSchema["project"],
+			"branch": {
+				Description: "Name of the branch to which to commit to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"start_branch": {
+				Description:   "Name of the branch to start the new commit from.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"merge_request"},
+			},
+			"commit_message": {
+				Description: "Commit message.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"author_email": {
+				Description: "Email of the commit author.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"author_name": {
+				Description: "Name of the commit author.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"files": {
+				Description: "The content of the files by their full path, which must be relative to the root of the project without a leading slash `/`. " +
+					"If the content is not yet base64 encoded, it will be encoded automatically. " +
+					"Files with the same content which are renamed at the same time are moved.",
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+			},
+			"execute_filemode": {
+				Description: "The paths of the `files` with the execute flag enabled. **Note**: requires GitLab 14.10 or newer.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+			},
+			"overwrite_on_create": {
+				Description: "Enable overwriting the `files` which already exist on the branch, defaults to `false`. " +
+					"This attribute is only used during `create` and must be used carefully, " +
+					"e.g. for the files of a project which is created in the same `apply`.",
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"merge_request": repositoryMergeRequestSchema(),
+			"last_commit_id": {
+				Description: "The ID of the last commit of this resource. Files which have been changed by another commit are compared with their configured content to detect drift.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+})
+
+// resourceGitlabRepositoryFilesDiff validates that the files with the execute flag are part of the files.
+func resourceGitlabRepositoryFilesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("files") || !d.NewValueKnown("execute_filemode") {
+		return nil
+	}
+	files := d.Get("files").(map[string]interface{})
+	for _, path := range d.Get("execute_filemode").(*schema.Set).List() {
+		if _, ok := files[path.(string)]; !ok {
+			return fmt.Errorf("the file %q of `execute_filemode` isn't one of the `files`", path)
+		}
+	}
+	return nil
+}
+
+func resourceGitlabRepositoryFilesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project := d.Get("project").(string)
+	branch := d.Get("branch").(string)
+
+	log.Printf("[DEBUG] gitlab_repository_files: waiting for lock to create the files of %s/%s", project, branch)
+	unlock, err := lockRepositoryBranch(ctx, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	existing := make(map[string]interface{})
+	if overwriteOnCreate, ok := d.GetOk("overwrite_on_create"); ok && overwriteOnCreate.(bool) {
+		ref := branch
+		if startBranch, ok := d.GetOk("start_branch"); ok {
+			ref = startBranch.(string)
+		}
+		files, err := readGitlabRepositoryFilesMetadata(ctx, client, project, ref, d.Get("files").(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for path := range files {
+			log.Printf("[DEBUG] %s already exists and overwrite_on_create is true. File will be overwritten.", path)
+			existing[path] = nil
+		}
+	}
+
+	actions := gitlabRepositoryFilesActions(existing, d.Get("files").(map[string]interface{}), nil, d.Get("execute_filemode").(*schema.Set))
+	if err := commitGitlabRepositoryFiles(ctx, d, client, project, branch, d.Get("commit_message").(string), repositoryMergeRequestTitle(d), actions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildTwoPartID(&project, &branch))
+	return resourceGitlabRepositoryFilesRead(ctx, d, meta)
+}
+
+func resourceGitlabRepositoryFilesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, branch, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, _, err := client.Branches.GetBranch(project, branch, gitlab.WithContext(ctx)); err != nil {
+		if is404(err) {
+			log.Printf("[DEBUG] gitlab_repository_files: branch %s of project %s not found, removing from state", branch, project)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// while the merge request is open, the files are read from its source branch
+	ref, err := readRepositoryMergeRequest(ctx, d, client, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stateFiles := d.Get("files").(map[string]interface{})
+	metadata, err := readGitlabRepositoryFilesMetadata(ctx, client, project, ref, stateFiles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lastCommitID := d.Get("last_commit_id").(string)
+	files := make(map[string]interface{}, len(metadata))
+	var executeFilemode []interface{}
+	for path, file := range metadata {
+		content := stateFiles[path].(string)
+		// the file has been changed since the last known commit, which is only a drift if the content is different
+		if file.LastCommitID != lastCommitID && file.SHA256 != gitlabRepositoryFileContentSHA256(content) {
+			log.Printf("[DEBUG] gitlab_repository_files: file %s has been changed in commit %s", path, file.LastCommitID)
+			changedFile, _, err := client.RepositoryFiles.GetFile(project, path, &gitlab.GetFileOptions{Ref: gitlab.String(ref)}, gitlab.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			content = decodeRepositoryFileContent(content, changedFile.Content)
+		}
+		files[path] = content
+		if file.ExecuteFilemode {
+			executeFilemode = append(executeFilemode, path)
+		}
+	}
+	if len(files) < len(stateFiles) {
+		log.Printf("[DEBUG] gitlab_repository_files: %d files of branch %s of project %s have been deleted", len(stateFiles)-len(files), branch, project)
+	}
+
+	d.Set("project", project)
+	d.Set("branch", branch)
+	d.Set("files", files)
+	d.Set("execute_filemode", executeFilemode)
+	return nil
+}
+
+func resourceGitlabRepositoryFilesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, branch, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] gitlab_repository_files: waiting for lock to update the files of %s/%s", project, branch)
+	unlock, err := lockRepositoryBranch(ctx, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	oldFiles, newFiles := d.GetChange("files")
+	oldExecuteFilemode, newExecuteFilemode := d.GetChange("execute_filemode")
+
+	// the changes of the open merge request are discarded when the `merge_request` block is removed,
+	// so the files are compared with the files on the `branch` instead
+	closed, err := closeRemovedRepositoryMergeRequest(ctx, d, client, project)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if closed {
+		files := make(map[string]interface{})
+		for _, v := range []interface{}{oldFiles, newFiles} {
+			for path, content := range v.(map[string]interface{}) {
+				files[path] = content
+			}
+		}
+		oldFiles, oldExecuteFilemode, err = readGitlabRepositoryFiles(ctx, client, project, branch, files)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	actions := gitlabRepositoryFilesActions(oldFiles.(map[string]interface{}), newFiles.(map[string]interface{}), oldExecuteFilemode.(*schema.Set), newExecuteFilemode.(*schema.Set))
+	if err := commitGitlabRepositoryFiles(ctx, d, client, project, branch, d.Get("commit_message").(string), repositoryMergeRequestTitle(d), actions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGitlabRepositoryFilesRead(ctx, d, meta)
+}
+
+func resourceGitlabRepositoryFilesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	project, branch, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] gitlab_repository_files: waiting for lock to delete the files of %s/%s", project, branch)
+	unlock, err := lockRepositoryBranch(ctx, project, branch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	// the changes of an open merge request are discarded, but the files which have already been merged must be deleted
+	if err := closeRepositoryMergeRequest(ctx, d, client, project); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only the files which still exist can be deleted
+	existing, err := readGitlabRepositoryFilesMetadata(ctx, client, project, branch, d.Get("files").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var actions []*gitlab.CommitActionOptions
+	for _, path := range sortedKeys(existing) {
+		actions = append(actions, &gitlab.CommitActionOptions{
+			Action:   gitlab.FileAction(gitlab.FileDelete),
+			FilePath: gitlab.String(path),
+		})
+	}
+	commitMessage := fmt.Sprintf("[DELETE]: %s", d.Get("commit_message").(string))
+	title := fmt.Sprintf("[DELETE]: %s", repositoryMergeRequestTitle(d))
+	if err := commitGitlabRepositoryFiles(ctx, d, client, project, branch, commitMessage, title, actions); err != nil {
+		return diag.FromErr(err)
+	}
+	if hasRepositoryMergeRequest(d) && len(actions) > 0 {
+		return repositoryDeletionMergeRequestWarning(d, branch)
+	}
+	return nil
+}
+
+// readGitlabRepositoryFilesMetadata returns the metadata of the given files which exist on the ref by their path.
+func readGitlabRepositoryFilesMetadata(ctx context.Context, client *gitlab.Client, project string, ref string, files map[string]interface{}) (map[string]*gitlab.File, error) {
+	metadata := make(map[string]*gitlab.File, len(files))
+	for path := range files {
+		file, _, err := client.RepositoryFiles.GetFileMetaData(project, path, &gitlab.GetFileMetaDataOptions{Ref: gitlab.String(ref)}, gitlab.WithContext(ctx))
+		if err != nil {
+			if is404(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		metadata[path] = file
+	}
+	return metadata, nil
+}
+
+// readGitlabRepositoryFiles returns the content and the execute flags of the given files which exist on the ref.
+// The content is only read for the files which differ from the given content.
+func readGitlabRepositoryFiles(ctx context.Context, client *gitlab.Client, project string, ref string, files map[string]interface{}) (map[string]interface{}, *schema.Set, error) {
+	metadata, err := readGitlabRepositoryFilesMetadata(ctx, client, project, ref, files)
+	if err != nil {
+		return nil, nil, err
+	}
+	existing := make(map[string]interface{}, len(metadata))
+	executeFilemode := schema.NewSet(schema.HashString, nil)
+	for path, file := range metadata {
+		content := files[path].(string)
+		if file.SHA256 != gitlabRepositoryFileContentSHA256(content) {
+			changedFile, _, err := client.RepositoryFiles.GetFile(project, path, &gitlab.GetFileOptions{Ref: gitlab.String(ref)}, gitlab.WithContext(ctx))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read file %s: %w", path, err)
+			}
+			content = decodeRepositoryFileContent(content, changedFile.Content)
+		}
+		existing[path] = content
+		if file.ExecuteFilemode {
+			executeFilemode.Add(path)
+		}
+	}
+	return existing, executeFilemode, nil
+}
+
+// gitlabRepositoryFilesActions returns the commit actions to change the old files to the new files.
+// A deleted file with the same content as a created file is moved instead.
+func gitlabRepositoryFilesActions(oldFiles map[string]interface{}, newFiles map[string]interface{}, oldExecuteFilemode *schema.Set, newExecuteFilemode *schema.Set) []*gitlab.CommitActionOptions {
+	isExecutable := func(executeFilemode *schema.Set, path string) bool {
+		return executeFilemode != nil && executeFilemode.Contains(path)
+	}
+
+	var deleted []string
+	for _, path := range sortedKeys(oldFiles) {
+		if _, ok := newFiles[path]; !ok {
+			deleted = append(deleted, path)
+		}
+	}
+
+	var actions []*gitlab.CommitActionOptions
+	for _, path := range sortedKeys(newFiles) {
+		content := newFiles[path].(string)
+		executable := isExecutable(newExecuteFilemode, path)
+		oldContent, exists := oldFiles[path]
+		switch {
+		case !exists:
+			action := &gitlab.CommitActionOptions{
+				Action:   gitlab.FileAction(gitlab.FileCreate),
+				FilePath: gitlab.String(path),
+				Content:  gitlab.String(encodeRepositoryFileContent(content)),
+				Encoding: gitlab.String(encoding),
+			}
+			for i, previousPath := range deleted {
+				if oldFiles[previousPath] == content {
+					action.Action = gitlab.FileAction(gitlab.FileMove)
+					action.PreviousPath = gitlab.String(previousPath)
+					deleted = append(deleted[:i], deleted[i+1:]...)
+					break
+				}
+			}
+			if executable || (action.PreviousPath != nil && isExecutable(oldExecuteFilemode, *action.PreviousPath)) {
+				action.ExecuteFilemode = gitlab.Bool(executable)
+			}
+			actions = append(actions, action)
+		case oldContent != content:
+			action := &gitlab.CommitActionOptions{
+				Action:   gitlab.FileAction(gitlab.FileUpdate),
+				FilePath: gitlab.String(path),
+				Content:  gitlab.String(encodeRepositoryFileContent(content)),
+				Encoding: gitlab.String(encoding),
+			}
+			if executable != isExecutable(oldExecuteFilemode, path) {
+				action.ExecuteFilemode = gitlab.Bool(executable)
+			}
+			actions = append(actions, action)
+		case executable != isExecutable(oldExecuteFilemode, path):
+			actions = append(actions, &gitlab.CommitActionOptions{
+				Action:          gitlab.FileAction(gitlab.FileChmod),
+				FilePath:        gitlab.String(path),
+				ExecuteFilemode: gitlab.Bool(executable),
+			})
+		}
+	}
+	for _, path := range deleted {
+		actions = append(actions, &gitlab.CommitActionOptions{
+			Action:   gitlab.FileAction(gitlab.FileDelete),
+			FilePath: gitlab.String(path),
+		})
+	}
+	return actions
+}
+
+// commitGitlabRepositoryFiles commits the actions to the branch or proposes them with a merge request with the title,
+// depending on the `merge_request` block. Without any actions, only the open merge request is updated.
+func commitGitlabRepositoryFiles(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string, branch string, commitMessage string, title string, actions []*gitlab.CommitActionOptions) error {
+	if len(actions) == 0 {
+		return finishRepositoryChange(ctx, d, client, project, branch, "", title)
+	}
+	commitBranch, err := prepareRepositoryChange(ctx, d, client, project, branch)
+	if err != nil {
+		return err
+	}
+	if err := createGitlabRepositoryFilesCommit(ctx, d, client, project, commitBranch, commitMessage, actions); err != nil {
+		return err
+	}
+	return finishRepositoryChange(ctx, d, client, project, branch, commitBranch, title)
+}
+
+// createGitlabRepositoryFilesCommit creates a single commit with the actions and stores its ID.
+func createGitlabRepositoryFilesCommit(ctx context.Context, d *schema.ResourceData, client *gitlab.Client, project string, branch string, commitMessage string, actions []*gitlab.CommitActionOptions) error {
+	options := &gitlab.CreateCommitOptions{
+		Branch:        gitlab.String(branch),
+		CommitMessage: gitlab.String(commitMessage),
+		Actions:       actions,
+	}
+	if startBranch, ok := d.GetOk("start_branch"); ok {
+		options.StartBranch = gitlab.String(startBranch.(string))
+	}
+	if authorEmail, ok := d.GetOk("author_email"); ok {
+		options.AuthorEmail = gitlab.String(authorEmail.(string))
+	}
+	if authorName, ok := d.GetOk("author_name"); ok {
+		options.AuthorName = gitlab.String(authorName.(string))
+	}
+
+	log.Printf("[DEBUG] gitlab_repository_files: commit %d actions to %s/%s", len(actions), project, branch)
+	commit, _, err := client.Commits.CreateCommit(project, options, gitlab.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to commit the files to branch %s of project %s: %w", branch, project, err)
+	}
+	d.Set("last_commit_id", commit.ID)
+	return nil
+}
+
+// gitlabRepositoryFileContentSHA256 returns the sha256 digest of the content of a file like the repository files API.
+func gitlabRepositoryFileContentSHA256(content string) string {
+	decoded, _ := base64.StdEncoding.DecodeString(encodeRepositoryFileContent(content))
+	digest := sha256.Sum256(decoded)
+	return hex.EncodeToString(digest[:])
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,194 @@
+//go:build acceptance
+// +build acceptance
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestAccGitlabRepositoryFiles_basic(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		CheckDestroy:             testAccCheckGitlabRepositoryFilesDestroy(testProject.ID, "meow.txt", "bin/purr.sh", "docs/meow.txt"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_repository_files" "this" {
+				  project          = %d
+				  branch           = "main"
+				  commit_message   = "feature: cats"
+				  files = {
+				    "meow.txt"    = "meow meow meow"
+				    "bin/purr.sh" = "echo purr purr"
+				  }
+				  execute_filemode = ["bin/purr.sh"]
+				}
+				`, testProject.ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabRepositoryFilesContent(testProject.ID, "meow.txt", "meow meow meow", false),
+					testAccCheckGitlabRepositoryFilesContent(testProject.ID, "bin/purr.sh", "echo purr purr", true),
+					testAccCheckGitlabRepositoryFilesLastCommit(testProject.ID, "gitlab_repository_files.this", "feature: cats"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_repository_files" "this" {
+				  project          = %d
+				  branch           = "main"
+				  commit_message   = "feature: more cats"
+				  files = {
+				    "docs/meow.txt" = "meow meow meow"
+				    "bin/purr.sh"   = "echo purr purr purr"
+				  }
+				}
+				`, testProject.ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGitlabRepositoryFilesContent(testProject.ID, "docs/meow.txt", "meow meow meow", false),
+					testAccCheckGitlabRepositoryFilesContent(testProject.ID, "bin/purr.sh", "echo purr purr purr", false),
+					testAccCheckGitlabRepositoryFilesLastCommit(testProject.ID, "gitlab_repository_files.this", "feature: more cats"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGitlabRepositoryFiles_drift(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+	config := fmt.Sprintf(`
+	resource "gitlab_repository_files" "this" {
+	  project        = %d
+	  branch         = "main"
+	  commit_message = "feature: cats"
+	  files = {
+	    "meow.txt" = "meow meow meow"
+	  }
+	}
+	`, testProject.ID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		CheckDestroy:             testAccCheckGitlabRepositoryFilesDestroy(testProject.ID, "meow.txt"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					if _, _, err := testutil.TestGitlabClient.RepositoryFiles.UpdateFile(testProject.ID, "meow.txt", &gitlab.UpdateFileOptions{
+						Branch:        gitlab.String("main"),
+						Content:       gitlab.String("woof woof woof"),
+						CommitMessage: gitlab.String("feature: dogs"),
+					}); err != nil {
+						t.Fatalf("failed to update file: %v", err)
+					}
+				},
+				Config: config,
+				Check:  testAccCheckGitlabRepositoryFilesContent(testProject.ID, "meow.txt", "meow meow meow", false),
+			},
+		},
+	})
+}
+
+func TestAccGitlabRepositoryFiles_mergeRequest(t *testing.T) {
+	testProject := testutil.CreateProject(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactoriesV6,
+		CheckDestroy:             testAccCheckGitlabRepositoryFilesDestroy(testProject.ID, "meow.txt"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "gitlab_repository_files" "this" {
+				  project        = %d
+				  branch         = "main"
+				  commit_message = "feature: cats"
+				  files = {
+				    "meow.txt" = "meow meow meow"
+				  }
+
+				  merge_request {
+				    title       = "Add cats"
+				    description = "Proposed by Terraform"
+				    labels      = ["terraform"]
+				  }
+				}
+				`, testProject.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gitlab_repository_files.this", "merge_request.0.iid", "1"),
+					resource.TestCheckResourceAttr("gitlab_repository_files.this", "merge_request.0.state", "opened"),
+					resource.TestCheckResourceAttrSet("gitlab_repository_files.this", "merge_request.0.source_branch"),
+					resource.TestCheckResourceAttrSet("gitlab_repository_files.this", "merge_request.0.web_url"),
+					testAccCheckGitlabRepositoryFilesDestroy(testProject.ID, "meow.txt"),
+					func(s *terraform.State) error {
+						mergeRequest, _, err := testutil.TestGitlabClient.MergeRequests.GetMergeRequest(testProject.ID, 1, nil)
+						if err != nil {
+							return err
+						}
+						if mergeRequest.Title != "Add cats" || mergeRequest.TargetBranch != "main" || len(mergeRequest.Labels) != 1 {
+							return fmt.Errorf("unexpected merge request: %+v", mergeRequest)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckGitlabRepositoryFilesContent(projectID int, path string, content string, executable bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		raw, _, err := testutil.TestGitlabClient.RepositoryFiles.GetRawFile(projectID, path, &gitlab.GetRawFileOptions{Ref: gitlab.String("main")})
+		if err != nil {
+			return fmt.Errorf("failed to get file %s: %w", path, err)
+		}
+		if string(raw) != content {
+			return fmt.Errorf("expected %s to contain %q, got %q", path, content, raw)
+		}
+		file, _, err := testutil.TestGitlabClient.RepositoryFiles.GetFileMetaData(projectID, path, &gitlab.GetFileMetaDataOptions{Ref: gitlab.String("main")})
+		if err != nil {
+			return fmt.Errorf("failed to get file %s: %w", path, err)
+		}
+		if file.ExecuteFilemode != executable {
+			return fmt.Errorf("expected the execute flag of %s to be %t", path, executable)
+		}
+		return nil
+	}
+}
+
+func testAccCheckGitlabRepositoryFilesLastCommit(projectID int, resourceName string, message string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		branch, _, err := testutil.TestGitlabClient.Branches.GetBranch(projectID, "main")
+		if err != nil {
+			return err
+		}
+		if branch.Commit.Title != message {
+			return fmt.Errorf("expected the last commit to be %q, got %q", message, branch.Commit.Title)
+		}
+		return resource.TestCheckResourceAttr(resourceName, "last_commit_id", branch.Commit.ID)(s)
+	}
+}
+
+func testAccCheckGitlabRepositoryFilesDestroy(projectID int, paths ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, path := range paths {
+			_, _, err := testutil.TestGitlabClient.RepositoryFiles.GetFileMetaData(projectID, path, &gitlab.GetFileMetaDataOptions{Ref: gitlab.String("main")})
+			if err == nil {
+				return fmt.Errorf("file %s still exists", path)
+			}
+			if !is404(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,288 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+// TestUnitGitlabRepositoryFiles_fakeGitLab runs the CRUD functions directly, to test the commits in detail.
+func TestUnitGitlabRepositoryFiles_fakeGitLab(t *testing.T) {
+	config := func() map[string]interface{} {
+		return map[string]interface{}{
+			"project":          "root/api",
+			"branch":           "main",
+			"commit_message":   "bootstrap",
+			"files":            map[string]interface{}{"a.txt": "a", "b.txt": "b", "build.sh": "make all"},
+			"execute_filemode": []interface{}{"build.sh"},
+		}
+	}
+
+	t.Run("create commits all files at once", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config())
+
+		branch, _, err := f.fake.Client.Branches.GetBranch(f.project.ID, "main")
+		if err != nil {
+			t.Fatalf("failed to get branch: %v", err)
+		}
+		checkValues(t, map[string]interface{}{
+			"commit":  branch.Commit.ID,
+			"message": branch.Commit.Message,
+			"parents": len(branch.Commit.ParentIDs),
+		}, map[string]interface{}{
+			"commit":  d.Get("last_commit_id"),
+			"message": "bootstrap",
+			"parents": 1,
+		})
+		checkValues(t, f.repositoryFile(t, "main", "a.txt"), map[string]interface{}{"content": "a", "execute_filemode": false})
+		checkValues(t, f.repositoryFile(t, "main", "build.sh"), map[string]interface{}{"content": "make all", "execute_filemode": true})
+	})
+
+	t.Run("changes outside of Terraform are detected", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config())
+		if _, _, err := f.fake.Client.RepositoryFiles.UpdateFile(f.project.ID, "a.txt", &gitlab.UpdateFileOptions{Branch: gitlab.String("main"), Content: gitlab.String("changed"), CommitMessage: gitlab.String("change")}); err != nil {
+			t.Fatalf("failed to update file: %v", err)
+		}
+		if _, err := f.fake.Client.RepositoryFiles.DeleteFile(f.project.ID, "b.txt", &gitlab.DeleteFileOptions{Branch: gitlab.String("main"), CommitMessage: gitlab.String("delete")}); err != nil {
+			t.Fatalf("failed to delete file: %v", err)
+		}
+
+		f.read(t, d)
+		checkAttributes(t, d, map[string]interface{}{
+			"files": map[string]interface{}{"a.txt": "changed", "build.sh": "make all"},
+		})
+	})
+
+	t.Run("update creates, updates, moves, chmods and deletes files at once", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config())
+		previousCommitID := d.Get("last_commit_id")
+
+		d = f.update(t, d, map[string]interface{}{
+			"project":          "root/api",
+			"branch":           "main",
+			"commit_message":   "update",
+			"files":            map[string]interface{}{"a.txt": "a", "c.txt": "c", "scripts/build.sh": "make all", "docs/index.md": "# API"},
+			"execute_filemode": []interface{}{"scripts/build.sh", "c.txt"},
+		})
+		branch, _, err := f.fake.Client.Branches.GetBranch(f.project.ID, "main")
+		if err != nil {
+			t.Fatalf("failed to get branch: %v", err)
+		}
+		checkValues(t, map[string]interface{}{
+			"commit":  branch.Commit.ID,
+			"message": branch.Commit.Message,
+			"parents": branch.Commit.ParentIDs,
+		}, map[string]interface{}{
+			"commit":  d.Get("last_commit_id"),
+			"message": "update",
+			"parents": []string{previousCommitID.(string)},
+		})
+		checkValues(t, f.repositoryFile(t, "main", "a.txt"), map[string]interface{}{"content": "a", "execute_filemode": false})
+		checkValues(t, f.repositoryFile(t, "main", "c.txt"), map[string]interface{}{"content": "c", "execute_filemode": true})
+		checkValues(t, f.repositoryFile(t, "main", "scripts/build.sh"), map[string]interface{}{"content": "make all", "execute_filemode": true})
+		checkValues(t, f.repositoryFile(t, "main", "docs/index.md"), map[string]interface{}{"content": "# API", "execute_filemode": false})
+		for _, path := range []string{"b.txt", "build.sh"} {
+			if file := f.repositoryFile(t, "main", path); file != nil {
+				t.Errorf("expected %s to be deleted, got %v", path, file)
+			}
+		}
+	})
+
+	t.Run("delete deletes all files", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config())
+
+		f.delete(t, d)
+		for _, path := range []string{"a.txt", "b.txt", "build.sh"} {
+			if file := f.repositoryFile(t, "main", path); file != nil {
+				t.Errorf("expected %s to be deleted, got %v", path, file)
+			}
+		}
+	})
+}
+
+func TestUnitGitlabRepositoryFilesMergeRequest_fakeGitLab(t *testing.T) {
+	config := func(content string) map[string]interface{} {
+		return map[string]interface{}{
+			"project":        "root/api",
+			"branch":         "main",
+			"commit_message": "bootstrap",
+			"files":          map[string]interface{}{"a.txt": content},
+			"merge_request": []interface{}{map[string]interface{}{
+				"title":        "Bootstrap the API",
+				"labels":       []interface{}{"terraform"},
+				"assignee_ids": []interface{}{1},
+			}},
+		}
+	}
+
+	t.Run("create proposes the files with a merge request", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config("a"))
+
+		checkAttributes(t, d, map[string]interface{}{
+			"merge_request.0.iid":   1,
+			"merge_request.0.state": "opened",
+			// the files are read from the source branch
+			"files": map[string]interface{}{"a.txt": "a"},
+		})
+		mergeRequest := f.mergeRequest(t, 1)
+		checkValues(t, map[string]interface{}{
+			"title":                      mergeRequest.Title,
+			"source_branch":              mergeRequest.SourceBranch,
+			"target_branch":              mergeRequest.TargetBranch,
+			"labels":                     []string(mergeRequest.Labels),
+			"assignees":                  len(mergeRequest.Assignees),
+			"force_remove_source_branch": mergeRequest.ForceRemoveSourceBranch,
+		}, map[string]interface{}{
+			"title":                      "Bootstrap the API",
+			"source_branch":              d.Get("merge_request.0.source_branch"),
+			"target_branch":              "main",
+			"labels":                     []string{"terraform"},
+			"assignees":                  1,
+			"force_remove_source_branch": true,
+		})
+		sourceBranch := d.Get("merge_request.0.source_branch").(string)
+		if !strings.HasPrefix(sourceBranch, "terraform/main-") {
+			t.Errorf("expected a generated source branch, got %q", sourceBranch)
+		}
+		if file := f.repositoryFile(t, "main", "a.txt"); file != nil {
+			t.Errorf("expected a.txt not to exist on main, got %v", file)
+		}
+		checkValues(t, f.repositoryFile(t, sourceBranch, "a.txt"), map[string]interface{}{"content": "a"})
+	})
+
+	t.Run("update commits to the source branch of the open merge request", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config("a"))
+
+		d = f.update(t, d, config("b"))
+		checkAttributes(t, d, map[string]interface{}{
+			"merge_request.0.iid":   1,
+			"merge_request.0.state": "opened",
+		})
+		checkValues(t, f.repositoryFile(t, d.Get("merge_request.0.source_branch").(string), "a.txt"), map[string]interface{}{"content": "b"})
+	})
+
+	t.Run("closed merge request is proposed again", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config("a"))
+		if _, _, err := f.fake.Client.MergeRequests.UpdateMergeRequest(f.project.ID, 1, &gitlab.UpdateMergeRequestOptions{StateEvent: gitlab.String("close")}); err != nil {
+			t.Fatalf("failed to close merge request: %v", err)
+		}
+
+		// the files are read from the target branch
+		f.read(t, d)
+		checkAttributes(t, d, map[string]interface{}{
+			"merge_request.0.iid":   1,
+			"merge_request.0.state": "closed",
+			"files":                 map[string]interface{}{},
+		})
+
+		d = f.update(t, d, config("a"))
+		checkAttributes(t, d, map[string]interface{}{
+			"merge_request.0.iid":   2,
+			"merge_request.0.state": "opened",
+		})
+	})
+
+	t.Run("merged files are read from the target branch", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config("a"))
+		if _, _, err := f.fake.Client.MergeRequests.AcceptMergeRequest(f.project.ID, 1, nil); err != nil {
+			t.Fatalf("failed to merge merge request: %v", err)
+		}
+
+		f.read(t, d)
+		checkAttributes(t, d, map[string]interface{}{
+			"merge_request.0.iid":   1,
+			"merge_request.0.state": "merged",
+			"files":                 map[string]interface{}{"a.txt": "a"},
+		})
+		checkValues(t, f.repositoryFile(t, "main", "a.txt"), map[string]interface{}{"content": "a"})
+	})
+
+	t.Run("delete proposes the deletion of merged files with a merge request", func(t *testing.T) {
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		d := f.create(t, config("a"))
+		if _, _, err := f.fake.Client.MergeRequests.AcceptMergeRequest(f.project.ID, 1, nil); err != nil {
+			t.Fatalf("failed to merge merge request: %v", err)
+		}
+		f.read(t, d)
+
+		diags := f.delete(t, d)
+		checkAttributes(t, d, map[string]interface{}{
+			"merge_request.0.iid":   2,
+			"merge_request.0.state": "opened",
+		})
+		mergeRequest := f.mergeRequest(t, 2)
+		checkValues(t, map[string]interface{}{"title": mergeRequest.Title}, map[string]interface{}{"title": "[DELETE]: Bootstrap the API"})
+		// the deletion is reported with a warning
+		if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, mergeRequest.WebURL) {
+			t.Errorf("expected a warning with the URL of the merge request, got %v", diags)
+		}
+		checkValues(t, f.repositoryFile(t, "main", "a.txt"), map[string]interface{}{"content": "a"})
+		if file := f.repositoryFile(t, mergeRequest.SourceBranch, "a.txt"); file != nil {
+			t.Errorf("expected a.txt to be deleted on %s, got %v", mergeRequest.SourceBranch, file)
+		}
+	})
+}
+
+func TestUnitGitlabRepositoryFilesMergeRequest_fakeGitLabRemovedBlock(t *testing.T) {
+	config := func() map[string]interface{} {
+		return map[string]interface{}{
+			"project":             "root/api",
+			"branch":              "main",
+			"commit_message":      "bootstrap",
+			"files":               map[string]interface{}{"a.txt": "a", "b.txt": "b"},
+			"overwrite_on_create": true,
+			"merge_request":       []interface{}{map[string]interface{}{"title": "Bootstrap the API"}},
+		}
+	}
+	// removeBlock creates the files with a merge request and removes the merge request block afterwards
+	removeBlock := func(t *testing.T) (*fakeGitLabResource, *schema.ResourceData, string) {
+		t.Helper()
+		f := newFakeGitLabResource(t, "gitlab_repository_files")
+		if _, _, err := f.fake.Client.RepositoryFiles.CreateFile(f.project.ID, "b.txt", &gitlab.CreateFileOptions{Branch: gitlab.String("main"), Content: gitlab.String("b"), CommitMessage: gitlab.String("add b")}); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		d := f.create(t, config())
+		sourceBranch := d.Get("merge_request.0.source_branch").(string)
+
+		withoutMergeRequest := config()
+		delete(withoutMergeRequest, "merge_request")
+		return f, f.update(t, d, withoutMergeRequest), sourceBranch
+	}
+
+	t.Run("the open merge request is closed and all changes are committed to the branch", func(t *testing.T) {
+		f, d, sourceBranch := removeBlock(t)
+
+		checkValues(t, map[string]interface{}{"state": f.mergeRequest(t, 1).State}, map[string]interface{}{"state": "closed"})
+		if _, _, err := f.fake.Client.Branches.GetBranch(f.project.ID, sourceBranch); !is404(err) {
+			t.Errorf("expected the source branch to be deleted, got %v", err)
+		}
+		checkValues(t, f.repositoryFile(t, "main", "a.txt"), map[string]interface{}{"content": "a"})
+		checkValues(t, f.repositoryFile(t, "main", "b.txt"), map[string]interface{}{"content": "b"})
+		checkAttributes(t, d, map[string]interface{}{
+			"files":           map[string]interface{}{"a.txt": "a", "b.txt": "b"},
+			"merge_request.#": 0,
+		})
+	})
+
+	t.Run("the files are deleted directly", func(t *testing.T) {
+		f, d, _ := removeBlock(t)
+
+		if diags := f.delete(t, d); len(diags) != 0 {
+			t.Errorf("expected no warnings, got %v", diags)
+		}
+		if file := f.repositoryFile(t, "main", "a.txt"); file != nil {
+			t.Errorf("expected a.txt to be deleted, got %v", file)
+		}
+	})
+}
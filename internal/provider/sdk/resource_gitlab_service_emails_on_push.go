@@ -101,7 +101,7 @@ var _ = registerResource("gitlab_service_emails_on_push", func() *schema.Resourc
 })
 
 func resourceGitlabServiceEmailsOnPushCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	options := &gitlab.SetEmailsOnPushServiceOptions{
 		Recipients: gitlab.String(d.Get("recipients").(string)),
@@ -135,7 +135,7 @@ func resourceGitlabServiceEmailsOnPushCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceGitlabServiceEmailsOnPushRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Id()
 
 	log.Printf("[DEBUG] read gitlab emails on push service for project %s", project)
@@ -169,7 +169,7 @@ func resourceGitlabServiceEmailsOnPushRead(ctx context.Context, d *schema.Resour
 }
 
 func resourceGitlabServiceEmailsOnPushDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Id()
 
 	log.Printf("[DEBUG] delete gitlab emails on push service for project %s", project)
@@ -146,7 +146,7 @@ var _ = registerResource("gitlab_service_jira", func() *schema.Resource {
 })
 
 func resourceGitlabServiceJiraCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 
@@ -167,7 +167,7 @@ func resourceGitlabServiceJiraCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabServiceJiraRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	project := d.Id()
 
 	log.Printf("[DEBUG] Read Gitlab Jira service %s", project)
@@ -217,7 +217,7 @@ func resourceGitlabServiceJiraUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceGitlabServiceJiraDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	project := d.Get("project").(string)
 
@@ -60,7 +60,7 @@ var _ = registerResource("gitlab_topic", func() *schema.Resource {
 })
 
 func resourceGitlabTopicCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	if err := resourceGitlabTopicEnsureTitleSupport(ctx, client, d); err != nil {
 		return diag.FromErr(err)
 	}
@@ -100,7 +100,7 @@ func resourceGitlabTopicCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabTopicRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	topicID, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -127,7 +127,7 @@ func resourceGitlabTopicRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceGitlabTopicUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	options := &gitlab.UpdateTopicOptions{}
 	if err := resourceGitlabTopicEnsureTitleSupport(ctx, client, d); err != nil {
 		return diag.FromErr(err)
@@ -170,7 +170,7 @@ func resourceGitlabTopicUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabTopicDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	topicID, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return diag.Errorf("Failed to convert topic id %s to int: %s", d.Id(), err)
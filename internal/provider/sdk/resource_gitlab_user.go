@@ -141,7 +141,7 @@ func resourceGitlabUserSetToState(d *schema.ResourceData, user *gitlab.User) {
 }
 
 func resourceGitlabUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	options := &gitlab.CreateUserOptions{
 		Email:            gitlab.String(d.Get("email").(string)),
 		Password:         gitlab.String(d.Get("password").(string)),
@@ -187,7 +187,7 @@ func resourceGitlabUserCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] import -- read gitlab user %s", d.Id())
 
 	id, _ := strconv.Atoi(d.Id())
@@ -207,7 +207,7 @@ func resourceGitlabUserRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceGitlabUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 
 	options := &gitlab.ModifyUserOptions{}
 
@@ -288,7 +288,7 @@ func resourceGitlabUserUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceGitlabUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	client := meta.(*providerMeta).client
 	log.Printf("[DEBUG] Delete gitlab user %s", d.Id())
 
 	id, _ := strconv.Atoi(d.Id())
@@ -301,7 +301,7 @@ func resourceGitlabUserDelete(ctx context.Context, d *schema.ResourceData, meta
 		Timeout: 5 * time.Minute,
 		Target:  []string{"Deleted"},
 		Refresh: func() (interface{}, string, error) {
-			user, resp, err := client.Users.GetUser(id, gitlab.GetUsersOptions{}, gitlab.WithContext(ctx))
+			user, resp, err := client.Users.GetUser(id, gitlab.GetUsersOptions{}, gitlab.WithContext(pollingContext(ctx)))
 			if resp != nil && resp.StatusCode == 404 {
 				return user, "Deleted", nil
 			}
@@ -28,7 +28,7 @@ var _ = registerResource("gitlab_user_gpgkey", func() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: constructSchema(map[string]*schema.Schema{
 			"user_id": {
 				Description: "The ID of the user to add the GPG key to. If this field is omitted, this resource manages a GPG key for the current user. Otherwise, this resource manages a GPG key for the speicifed user, and an admin token is required.",
 				Type:        schema.TypeInt,
@@ -56,12 +56,13 @@ var _ = registerResource("gitlab_user_gpgkey", func() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-		},
+		}, sudoSchema(true)),
 	}
 })
 
 func resourceGitlabUserGPGKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	options := &gitlab.AddGPGKeyOptions{
 		Key: gitlab.String(strings.TrimSpace(d.Get("key").(string))),
@@ -79,9 +80,9 @@ func resourceGitlabUserGPGKeyCreate(ctx context.Context, d *schema.ResourceData,
 		if !isAdmin {
 			return diag.Errorf("current user needs to be admin for configuring GPG keys for a user")
 		}
-		key, _, err = client.Users.AddGPGKeyForUser(userID.(int), options, gitlab.WithContext(ctx))
+		key, _, err = client.Users.AddGPGKeyForUser(userID.(int), options, withContext(ctx))
 	} else {
-		key, _, err = client.Users.AddGPGKey(options, gitlab.WithContext(ctx))
+		key, _, err = client.Users.AddGPGKey(options, withContext(ctx))
 	}
 	if err != nil {
 		return diag.FromErr(err)
@@ -98,7 +99,8 @@ func resourceGitlabUserGPGKeyCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabUserGPGKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	userID, keyID, err := resourceGitlabUserGPGKeyParseID(d.Id())
 	if err != nil {
@@ -107,9 +109,9 @@ func resourceGitlabUserGPGKeyRead(ctx context.Context, d *schema.ResourceData, m
 
 	var key *gitlab.GPGKey
 	if userID != 0 {
-		key, _, err = client.Users.GetGPGKeyForUser(userID, keyID, gitlab.WithContext(ctx))
+		key, _, err = client.Users.GetGPGKeyForUser(userID, keyID, withContext(ctx))
 	} else {
-		key, _, err = client.Users.GetGPGKey(keyID, gitlab.WithContext(ctx))
+		key, _, err = client.Users.GetGPGKey(keyID, withContext(ctx))
 	}
 	if err != nil {
 		if is404(err) {
@@ -130,7 +132,8 @@ func resourceGitlabUserGPGKeyRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGitlabUserGPGKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	var isAdmin bool
 	_, keyID, err := resourceGitlabUserGPGKeyParseID(d.Id())
@@ -146,9 +149,9 @@ func resourceGitlabUserGPGKeyDelete(ctx context.Context, d *schema.ResourceData,
 		if !isAdmin {
 			return diag.Errorf("current user needs to be admin for configuring GPG keys for a user")
 		}
-		_, err = client.Users.DeleteGPGKeyForUser(userID.(int), keyID, gitlab.WithContext(ctx))
+		_, err = client.Users.DeleteGPGKeyForUser(userID.(int), keyID, withContext(ctx))
 	} else {
-		_, err = client.Users.DeleteGPGKey(keyID, gitlab.WithContext(ctx))
+		_, err = client.Users.DeleteGPGKey(keyID, withContext(ctx))
 	}
 	if err != nil {
 		return diag.FromErr(err)
@@ -24,12 +24,13 @@ var _ = registerResource("gitlab_user_sshkey", func() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: gitlabUserSSHKeySchema(),
+		Schema: constructSchema(gitlabUserSSHKeySchema(), sudoSchema(true)),
 	}
 })
 
 func resourceGitlabUserSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 	userID := d.Get("user_id").(int)
 
 	options := &gitlab.AddSSHKeyOptions{
@@ -46,7 +47,7 @@ func resourceGitlabUserSSHKeyCreate(ctx context.Context, d *schema.ResourceData,
 		options.ExpiresAt = &gitlabExpiresAt
 	}
 
-	key, _, err := client.Users.AddSSHKeyForUser(userID, options, gitlab.WithContext(ctx))
+	key, _, err := client.Users.AddSSHKeyForUser(userID, options, withContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -58,7 +59,8 @@ func resourceGitlabUserSSHKeyCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGitlabUserSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	userID, keyID, err := resourceGitlabUserSSHKeyParseID(d.Id())
 	if err != nil {
@@ -72,7 +74,7 @@ func resourceGitlabUserSSHKeyRead(ctx context.Context, d *schema.ResourceData, m
 
 	var key *gitlab.SSHKey
 	for options.Page != 0 && key == nil {
-		keys, resp, err := client.Users.ListSSHKeysForUser(userID, options, gitlab.WithContext(ctx))
+		keys, resp, err := client.Users.ListSSHKeysForUser(userID, options, withContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -107,14 +109,15 @@ func resourceGitlabUserSSHKeyRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGitlabUserSSHKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*gitlab.Client)
+	ctx = withSudo(ctx, d)
+	client := meta.(*providerMeta).client
 
 	userID, keyID, err := resourceGitlabUserSSHKeyParseID(d.Id())
 	if err != nil {
 		return diag.Errorf("unable to parse user ssh key resource id: %s: %v", d.Id(), err)
 	}
 
-	if _, err := client.Users.DeleteSSHKeyForUser(userID, keyID, gitlab.WithContext(ctx)); err != nil {
+	if _, err := client.Users.DeleteSSHKeyForUser(userID, keyID, withContext(ctx)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -49,10 +49,11 @@ func gitlabGroupVariableGetSchema() map[string]*schema.Schema {
 			Default:     false,
 		},
 		"environment_scope": {
-			Description: "The environment scope of the variable. Defaults to all environment (`*`). Note that in Community Editions of Gitlab, values other than `*` will cause inconsistent plans.",
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "*",
+			Description:      "The environment scope of the variable. Defaults to all environment (`*`). Note that in Community Editions of Gitlab, values other than `*` will cause inconsistent plans.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "*",
+			ValidateDiagFunc: validateGitlabVariableEnvironmentScope,
 			// Versions of GitLab prior to 13.4 cannot update environment_scope.
 			ForceNew: true,
 		},
@@ -0,0 +1,243 @@
+package sdk
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+// draftMergeRequestTitlePrefix marks a merge request as draft. GitLab doesn't support to set the draft status directly.
+const draftMergeRequestTitlePrefix = "Draft: "
+
+func gitlabProjectMergeRequestGetSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project": {
+			Description: "The name or ID of the project.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"iid": {
+			Description: "The internal ID of the project's merge request.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"merge_request_id": {
+			Description: "The instance-wide ID of the merge request.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"source_branch": {
+			Description: "The source branch of the merge request.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"target_branch": {
+			Description: "The target branch of the merge request.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"title": {
+			Description: "The title of the merge request, without the draft prefix.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"description": {
+			Description: "The description of the merge request. Limited to 1,048,576 characters.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"labels": {
+			Description: "The labels of the merge request.",
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+		},
+		"assignee_ids": {
+			Description: "The IDs of the users to assign the merge request to.",
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Optional:    true,
+		},
+		"reviewer_ids": {
+			Description: "The IDs of the users to request a review of the merge request from.",
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Optional:    true,
+		},
+		"squash": {
+			Description: "Squash the commits of the merge request when it's merged.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"remove_source_branch": {
+			Description: "Remove the source branch when the merge request is merged.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"draft": {
+			Description: "Mark the merge request as draft, which prevents it from being merged. GitLab prefixes the title with `Draft: `.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"state": {
+			Description: "The state of the merge request, i.e. `opened`, `closed`, `locked` or `merged`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		// NOTE: to keep things simple, users of this resource should use the `gitlab_user` data source to
+		//       get more information about the author if desired.
+		"author_id": {
+			Description: "The ID of the author of the merge request. Use `gitlab_user` data source to get more information about the user.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"merged_by_user_id": {
+			Description: "The ID of the user that merged the merge request. Use `gitlab_user` data source to get more information about the user.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"merge_status": {
+			Description: "Whether the merge request can be merged, e.g. `can_be_merged` or `cannot_be_merged`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"merge_when_pipeline_succeeds": {
+			Description: "Whether the merge request is merged automatically when the pipeline succeeds.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"sha": {
+			Description: "The SHA of the head commit of the source branch.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"merge_commit_sha": {
+			Description: "The SHA of the merge commit.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"squash_commit_sha": {
+			Description: "The SHA of the squash commit.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"created_at": {
+			Description: "When the merge request was created. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"updated_at": {
+			Description: "When the merge request was updated. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"merged_at": {
+			Description: "When the merge request was merged. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"closed_at": {
+			Description: "When the merge request was closed. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"web_url": {
+			Description: "The web URL of the merge request.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"references": {
+			Description: "The references of the merge request.",
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+		},
+	}
+}
+
+func gitlabProjectMergeRequestToStateMap(project string, mergeRequest *gitlab.MergeRequest) map[string]interface{} {
+	stateMap := make(map[string]interface{})
+	stateMap["project"] = project
+	stateMap["iid"] = mergeRequest.IID
+	stateMap["merge_request_id"] = mergeRequest.ID
+	stateMap["source_branch"] = mergeRequest.SourceBranch
+	stateMap["target_branch"] = mergeRequest.TargetBranch
+	stateMap["title"] = strings.TrimPrefix(mergeRequest.Title, draftMergeRequestTitlePrefix)
+	stateMap["description"] = mergeRequest.Description
+	stateMap["labels"] = mergeRequest.Labels
+	stateMap["assignee_ids"] = flattenBasicUserIds(mergeRequest.Assignees)
+	stateMap["reviewer_ids"] = flattenBasicUserIds(mergeRequest.Reviewers)
+	stateMap["squash"] = mergeRequest.Squash
+	stateMap["remove_source_branch"] = mergeRequest.ForceRemoveSourceBranch
+	stateMap["draft"] = mergeRequest.Draft
+	stateMap["state"] = mergeRequest.State
+	if mergeRequest.Author != nil {
+		stateMap["author_id"] = mergeRequest.Author.ID
+	} else {
+		stateMap["author_id"] = nil
+	}
+	if mergeRequest.MergedBy != nil {
+		stateMap["merged_by_user_id"] = mergeRequest.MergedBy.ID
+	} else {
+		stateMap["merged_by_user_id"] = nil
+	}
+	stateMap["merge_status"] = mergeRequest.MergeStatus
+	stateMap["merge_when_pipeline_succeeds"] = mergeRequest.MergeWhenPipelineSucceeds
+	stateMap["sha"] = mergeRequest.SHA
+	stateMap["merge_commit_sha"] = mergeRequest.MergeCommitSHA
+	stateMap["squash_commit_sha"] = mergeRequest.SquashCommitSHA
+	if mergeRequest.CreatedAt != nil {
+		stateMap["created_at"] = mergeRequest.CreatedAt.Format(time.RFC3339)
+	} else {
+		stateMap["created_at"] = nil
+	}
+	if mergeRequest.UpdatedAt != nil {
+		stateMap["updated_at"] = mergeRequest.UpdatedAt.Format(time.RFC3339)
+	} else {
+		stateMap["updated_at"] = nil
+	}
+	if mergeRequest.MergedAt != nil {
+		stateMap["merged_at"] = mergeRequest.MergedAt.Format(time.RFC3339)
+	} else {
+		stateMap["merged_at"] = nil
+	}
+	if mergeRequest.ClosedAt != nil {
+		stateMap["closed_at"] = mergeRequest.ClosedAt.Format(time.RFC3339)
+	} else {
+		stateMap["closed_at"] = nil
+	}
+	stateMap["web_url"] = mergeRequest.WebURL
+	if mergeRequest.References != nil {
+		stateMap["references"] = flattenIssueReferences(mergeRequest.References)
+	} else {
+		stateMap["references"] = nil
+	}
+
+	return stateMap
+}
+
+func flattenBasicUserIds(users []*gitlab.BasicUser) (result []int) {
+	if users == nil {
+		return
+	}
+
+	for _, user := range users {
+		result = append(result, user.ID)
+	}
+	return result
+}
+
+// gitlabProjectMergeRequestTitle returns the title of the merge request with the draft prefix, if it's a draft.
+func gitlabProjectMergeRequestTitle(title string, draft bool) string {
+	if draft {
+		return draftMergeRequestTitlePrefix + title
+	}
+	return title
+}
@@ -0,0 +1,208 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/xanzy/go-gitlab"
+)
+
+// gitlabRelease is a release with the milestones and evidences, which the release of go-gitlab doesn't contain yet.
+type gitlabRelease struct {
+	gitlab.Release
+	Milestones []struct {
+		Title string `json:"title"`
+	} `json:"milestones"`
+	Evidences []struct {
+		SHA         string     `json:"sha"`
+		Filepath    string     `json:"filepath"`
+		CollectedAt *time.Time `json:"collected_at"`
+	} `json:"evidences"`
+}
+
+func gitlabReleaseGetSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project": {
+			Description: "The ID or full path of the project.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"tag_name": {
+			Description: "The tag of the release.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The name of the release. Defaults to the tag name.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"description": {
+			Description: "The description of the release. You can use Markdown.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"released_at": {
+			Description: "When the release is ready. Defaults to the time of creation. A date in the future makes the release an upcoming release. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			// NOTE: since RFC3339 is pretty much a subset of ISO8601 and actually expected by GitLab,
+			//       we use it here to avoid having to parse the string ourselves.
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			// GitLab returns the time in UTC
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				oldTime, err := time.Parse(time.RFC3339, old)
+				if err != nil {
+					return false
+				}
+				newTime, err := time.Parse(time.RFC3339, new)
+				if err != nil {
+					return false
+				}
+				return oldTime.Equal(newTime)
+			},
+		},
+		"milestones": {
+			Description: "The titles of the milestones the release is associated with.",
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+		},
+		"asset_link": {
+			Description: "The links of the assets of the release.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: excludeElementsFromSchema(gitlabReleaseLinkGetSchema(), []string{"project", "tag_name"}),
+			},
+		},
+		"created_at": {
+			Description: "When the release was created. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"upcoming_release": {
+			Description: "Whether the release is an upcoming release, because it's released in the future.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		// NOTE: to keep things simple, users of this resource should use the `gitlab_user` data source to
+		//       get more information about the author if desired.
+		"author_id": {
+			Description: "The ID of the author of the release. Use `gitlab_user` data source to get more information about the user.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"commit_sha": {
+			Description: "The SHA of the commit the tag of the release points to.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"tag_path": {
+			Description: "The path of the tag of the release.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"evidences": {
+			Description: "The evidences GitLab collected for the release, e.g. its milestones and issues at the time of the release.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"sha": {
+						Description: "The SHA of the evidence.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"filepath": {
+						Description: "The URL of the evidence file.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"collected_at": {
+						Description: "When the evidence was collected. Date time string, ISO 8601 formatted, for example 2016-03-11T03:45:40Z.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// gitlabReleaseToStateMap returns the state of the release with the given asset links,
+// which are either all links of the release or only the ones managed in the `asset_link` blocks.
+func gitlabReleaseToStateMap(project string, release *gitlabRelease, links []*gitlab.ReleaseLink) map[string]interface{} {
+	stateMap := make(map[string]interface{})
+	stateMap["project"] = project
+	stateMap["tag_name"] = release.TagName
+	stateMap["name"] = release.Name
+	stateMap["description"] = release.Description
+	if release.ReleasedAt != nil {
+		stateMap["released_at"] = release.ReleasedAt.Format(time.RFC3339)
+	} else {
+		stateMap["released_at"] = nil
+	}
+	milestones := make([]string, 0, len(release.Milestones))
+	for _, milestone := range release.Milestones {
+		milestones = append(milestones, milestone.Title)
+	}
+	stateMap["milestones"] = milestones
+	stateMap["asset_link"] = flattenGitlabReleaseAssetLinks(project, release.TagName, links)
+	if release.CreatedAt != nil {
+		stateMap["created_at"] = release.CreatedAt.Format(time.RFC3339)
+	} else {
+		stateMap["created_at"] = nil
+	}
+	stateMap["upcoming_release"] = release.UpcomingRelease
+	stateMap["author_id"] = release.Author.ID
+	stateMap["commit_sha"] = release.Commit.ID
+	stateMap["tag_path"] = release.TagPath
+	evidences := make([]map[string]interface{}, 0, len(release.Evidences))
+	for _, evidence := range release.Evidences {
+		values := map[string]interface{}{
+			"sha":          evidence.SHA,
+			"filepath":     evidence.Filepath,
+			"collected_at": nil,
+		}
+		if evidence.CollectedAt != nil {
+			values["collected_at"] = evidence.CollectedAt.Format(time.RFC3339)
+		}
+		evidences = append(evidences, values)
+	}
+	stateMap["evidences"] = evidences
+
+	return stateMap
+}
+
+func flattenGitlabReleaseAssetLinks(project string, tagName string, links []*gitlab.ReleaseLink) []map[string]interface{} {
+	values := make([]map[string]interface{}, 0, len(links))
+	for _, link := range links {
+		value := gitlabReleaseLinkToStateMap(project, tagName, link)
+		delete(value, "project")
+		delete(value, "tag_name")
+		values = append(values, value)
+	}
+	return values
+}
+
+// getGitlabRelease gets a release with its milestones and evidences.
+func getGitlabRelease(ctx context.Context, client *gitlab.Client, project string, tagName string) (*gitlabRelease, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("projects/%s/releases/%s", gitlab.PathEscape(project), gitlab.PathEscape(tagName)), nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	release := new(gitlabRelease)
+	if _, err := client.Do(req, release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
@@ -1,6 +1,8 @@
 package sdk
 
 import (
+	"encoding/base64"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/xanzy/go-gitlab"
 )
@@ -77,6 +79,27 @@ func gitlabRepositoryFileGetSchema() map[string]*schema.Schema {
 	}
 }
 
+// encodeRepositoryFileContent encodes the configured content of a file to base64.
+// NOTE: for backwards-compatibility reasons, we also support an already given base64 encoding.
+func encodeRepositoryFileContent(content string) string {
+	if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+		return base64.StdEncoding.EncodeToString([]byte(content))
+	}
+	return content
+}
+
+// decodeRepositoryFileContent decodes the base64 encoded content of a file from the API,
+// unless the configured content is base64 encoded, too.
+func decodeRepositoryFileContent(configContent string, content string) string {
+	if _, err := base64.StdEncoding.DecodeString(configContent); err == nil {
+		return content
+	}
+	if decodedContent, err := base64.StdEncoding.DecodeString(content); err == nil {
+		return string(decodedContent)
+	}
+	return content
+}
+
 func gitlabRepositoryFileToStateMap(project string, repositoryFile *gitlab.File) map[string]interface{} {
 	stateMap := make(map[string]interface{})
 	stateMap["project"] = project
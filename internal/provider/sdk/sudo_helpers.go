@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/xanzy/go-gitlab"
+)
+
+type sudoContextKey struct{}
+
+// sudoSchema returns a resource schema with the attribute required to impersonate a user for all requests of the resource.
+// Resources without an update must force a new resource when the impersonated user changes.
+func sudoSchema(forceNew bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"sudo": {
+			Description: "The username or ID of the user to impersonate for all API requests of this resource. Overrides the `sudo` provider attribute. Requires an administrator token with the `sudo` scope. **Note**: not available for imported resources.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    forceNew,
+		},
+	}
+}
+
+// withSudo returns a context which impersonates the user configured in the `sudo` attribute of the resource.
+// It must be used to properly support the `sudoSchema` attributes in a resource Schema,
+// together with `withContext` for all requests the resource makes.
+func withSudo(ctx context.Context, d *schema.ResourceData) context.Context {
+	if sudo, ok := d.GetOk("sudo"); ok {
+		return context.WithValue(ctx, sudoContextKey{}, sudo.(string))
+	}
+	return ctx
+}
+
+// withContext is like `gitlab.WithContext`, but also impersonates the user set with `withSudo` for the request.
+func withContext(ctx context.Context) gitlab.RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		if err := gitlab.WithContext(ctx)(req); err != nil {
+			return err
+		}
+		if sudo, ok := ctx.Value(sudoContextKey{}).(string); ok {
+			return gitlab.WithSudo(sudo)(req)
+		}
+		return nil
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/xanzy/go-gitlab"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
 )
 
 // extractIIDFromGlobalID extracts the internal model ID from a global GraphQL ID.
@@ -59,6 +62,14 @@ var validateURLFunc = func(v interface{}, k string) (s []string, errors []error)
 	return
 }
 
+var validateDurationFunc = func(v interface{}, k string) (s []string, errors []error) {
+	value := v.(string)
+	if _, err := time.ParseDuration(value); err != nil {
+		errors = append(errors, fmt.Errorf("%s is not a valid duration for %s, use values like `500ms`, `1s` or `1m`", value, k))
+	}
+	return
+}
+
 func stringToVisibilityLevel(s string) *gitlab.VisibilityValue {
 	lookup := map[string]gitlab.VisibilityValue{
 		"private":  gitlab.PrivateVisibility,
@@ -168,7 +179,7 @@ var StringIsGitlabVariableName = func(v interface{}, k string) (s []string, es [
 		es = append(es, fmt.Errorf("expected length of %s to be in the range (%d - %d), got %s", k, 1, 255, v))
 	}
 
-	match, _ := regexp.MatchString("[a-zA-Z0-9_]+", value)
+	match, _ := regexp.MatchString("^[a-zA-Z0-9_]+$", value)
 	if !match {
 		es = append(es, fmt.Errorf("%s is an invalid value for argument %s. Only A-Z, a-z, 0-9, and _ are allowed", value, k))
 	}
@@ -226,6 +237,14 @@ func stringListToStringSlice(stringList []interface{}) *[]string {
 	return &ret
 }
 
+func stringMapToStringMap(stringMap map[string]interface{}) map[string]string {
+	ret := make(map[string]string, len(stringMap))
+	for k, v := range stringMap {
+		ret[k] = fmt.Sprint(v)
+	}
+	return ret
+}
+
 func stringSetToStringSlice(stringSet *schema.Set) *[]string {
 	ret := []string{}
 	if stringSet == nil {
@@ -301,6 +320,12 @@ func isCurrentUserAdmin(ctx context.Context, client *gitlab.Client) (bool, error
 	return currentUser.IsAdmin, nil
 }
 
+// pollingContext returns the context for requests which wait for a state change in GitLab.
+// These requests must never be served from the read cache.
+func pollingContext(ctx context.Context) context.Context {
+	return client.WithoutReadCache(ctx)
+}
+
 // ISO 8601 date format
 const iso8601 = "2006-01-02"
 
@@ -456,3 +481,85 @@ func (c lock) lock(ctx context.Context) error {
 func (c lock) unlock() {
 	<-c
 }
+
+// keyedLock is a set of `lock`s identified by a key, e.g. the project and branch of a repository
+// to serialize the commits to the same branch, while commits to different branches run in parallel.
+// The locks are created on demand and removed once they are no longer in use.
+type keyedLock struct {
+	mutex sync.Mutex
+	locks map[string]*keyedLockEntry
+}
+
+type keyedLockEntry struct {
+	lock lock
+	// references is the number of callers holding or waiting for the lock
+	references int
+}
+
+func newKeyedLock() *keyedLock {
+	return &keyedLock{locks: make(map[string]*keyedLockEntry)}
+}
+
+// lock locks the lock of the given key, like `lock.lock` it respects cancelling and timeouts of the context.
+func (l *keyedLock) lock(ctx context.Context, key string) error {
+	l.mutex.Lock()
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &keyedLockEntry{lock: newLock()}
+		l.locks[key] = entry
+	}
+	entry.references++
+	l.mutex.Unlock()
+
+	if err := entry.lock.lock(ctx); err != nil {
+		l.release(key, entry)
+		return err
+	}
+	return nil
+}
+
+// unlock unlocks the lock of the given key, which must have been locked before.
+func (l *keyedLock) unlock(key string) {
+	l.mutex.Lock()
+	entry := l.locks[key]
+	l.mutex.Unlock()
+
+	entry.lock.unlock()
+	l.release(key, entry)
+}
+
+func (l *keyedLock) release(key string, entry *keyedLockEntry) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entry.references--
+	if entry.references == 0 {
+		delete(l.locks, key)
+	}
+}
+
+// repositoryBranchLock serializes the commits the provider creates on the same branch of a repository.
+// GitLab rejects concurrent commits to the same branch, with a 400 error along the lines of:
+//
+//	```
+//	(400 Bad Request) DELETE https://gitlab.com/api/v4/projects/30716/repository/files/somefile.yaml: 400
+//	{message: 9:Could not update refs/heads/master. Please refresh and try again..}
+//	```
+//
+// All resources which create commits must lock the branch with `lockRepositoryBranch`.
+//
+// NOTE: this lock only solves half of the problem, where the provider is responsible for
+// the concurrency. The other half is if the API is called outside of terraform at the same time
+// the resources make calls to the API. To mitigate this, simple retries are used.
+var repositoryBranchLock = newKeyedLock()
+
+// lockRepositoryBranch locks the given branch of the project for a commit and returns the function to unlock it.
+// The resources which commit resolve their project reference to the numeric ID, see `namespaceReferences`,
+// therefore the same project referenced once by ID and once by path is locked with the same key.
+func lockRepositoryBranch(ctx context.Context, project string, branch string) (func(), error) {
+	key := project + ":" + branch
+	if err := repositoryBranchLock.lock(ctx, key); err != nil {
+		return nil, err
+	}
+	return func() { repositoryBranchLock.unlock(key) }, nil
+}
@@ -1,7 +1,10 @@
 package sdk
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/xanzy/go-gitlab"
 )
@@ -119,3 +122,40 @@ func TestValidateURLFunc(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyedLock(t *testing.T) {
+	locks := newKeyedLock()
+	ctx := context.Background()
+
+	if err := locks.lock(ctx, "project:main"); err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+
+	// a different key is not blocked
+	if err := locks.lock(ctx, "project:develop"); err != nil {
+		t.Fatalf("failed to lock a different key: %v", err)
+	}
+	locks.unlock("project:develop")
+
+	// the same key is blocked until the context is done
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := locks.lock(timeoutCtx, "project:main"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the lock to time out, got %v", err)
+	}
+
+	// the same key is acquired once it's unlocked
+	acquired := make(chan error)
+	go func() {
+		acquired <- locks.lock(ctx, "project:main")
+	}()
+	locks.unlock("project:main")
+	if err := <-acquired; err != nil {
+		t.Fatalf("failed to lock after unlock: %v", err)
+	}
+	locks.unlock("project:main")
+
+	if len(locks.locks) != 0 {
+		t.Fatalf("expected all unused locks to be removed, got %d", len(locks.locks))
+	}
+}
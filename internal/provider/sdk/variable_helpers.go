@@ -1,26 +1,97 @@
 package sdk
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/xanzy/go-gitlab"
 )
 
-func augmentVariableClientError(d *schema.ResourceData, err error) diag.Diagnostics {
+const (
+	// gitlabMaskedVariableMinLength is the minimum length of the value of a masked variable.
+	gitlabMaskedVariableMinLength = 8
+	// gitlabMaskedVariableChars are the characters which GitLab is able to mask, see `Ci::Maskable`.
+	gitlabMaskedVariableChars = "a-zA-Z0-9_+=/@:.~-"
+)
+
+var gitlabMaskedVariableCharRegexp = regexp.MustCompile("^[" + gitlabMaskedVariableChars + "]$")
+
+// validateGitlabVariableEnvironmentScope validates the characters of an environment scope,
+// where `*` is the only wildcard, e.g. `review/*`.
+var validateGitlabVariableEnvironmentScope = validation.ToDiagFunc(validation.All(
+	validation.StringLenBetween(1, 255),
+	validation.StringMatch(
+		regexp.MustCompile(`^[a-zA-Z0-9_/${}. *-]+$`),
+		"Only letters, digits, spaces, `-`, `_`, `/`, `$`, `{`, `}`, `.` and the wildcard `*` are allowed",
+	),
+))
+
+// gitlabVariableDiff validates the value of a masked variable at plan time, instead of failing in the middle of an apply.
+// The errors only describe the violated rules, because the value is secret.
+func gitlabVariableDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// existing variables were accepted by GitLab, which may be less strict than these rules
+	if d.Id() != "" && !d.HasChanges("value", "masked") {
+		return nil
+	}
+	if !d.NewValueKnown("masked") || !d.NewValueKnown("value") || !d.Get("masked").(bool) {
+		return nil
+	}
+
+	problems := maskedVariableValueProblems(d.Get("value").(string))
+	if len(problems) == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("the value of the masked variable %q can't be masked by GitLab: %s", d.Get("key").(string), strings.Join(problems, ", "))
+	if d.Get("variable_type").(string) == "file" {
+		message += ". Multi-line files, like certificates, need to be either unmasked or base64 encoded"
+	}
+	return fmt.Errorf("%s. Check the masked variable requirements: https://docs.gitlab.com/ee/ci/variables/#masked-variable-requirements", message)
+}
+
+// maskedVariableValueProblems returns the masking rules of GitLab the value violates, without revealing the value.
+func maskedVariableValueProblems(value string) []string {
+	var problems []string
+	if strings.ContainsAny(value, "\r\n") {
+		problems = append(problems, "it must be a single line")
+	}
+	if length := len([]rune(value)); length < gitlabMaskedVariableMinLength {
+		problems = append(problems, fmt.Sprintf("it must be at least %d characters long, but has %d", gitlabMaskedVariableMinLength, length))
+	}
+	invalid := 0
+	for _, c := range value {
+		if c != '\r' && c != '\n' && !gitlabMaskedVariableCharRegexp.MatchString(string(c)) {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		problems = append(problems, fmt.Sprintf("it contains %d characters other than letters, digits and `_+=/@:.~-`", invalid))
+	}
+	return problems
+}
+
+func augmentVariableClientError(ctx context.Context, client *gitlab.Client, d *schema.ResourceData, err error) diag.Diagnostics {
 	// Masked values will commonly error due to their strict requirements, and the error message from the GitLab API is not very informative,
 	// so we return a custom error message in this case.
 	if d.Get("masked").(bool) && isInvalidValueError(err) {
 		log.Printf("[ERROR] %v", err)
-		return diag.Errorf("Invalid value for a masked variable. Check the masked variable requirements: https://docs.gitlab.com/ee/ci/variables/#masked-variable-requirements")
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid value for a masked variable. Check the masked variable requirements: https://docs.gitlab.com/ee/ci/variables/#masked-variable-requirements",
+			AttributePath: cty.GetAttrPath("value"),
+		}}
 	}
 
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(ctx, client, d, err)
 	}
 
 	return nil
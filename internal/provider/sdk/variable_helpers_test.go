@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/testutil"
+)
+
+func TestMaskedVariableValueProblems(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		problems int
+	}{
+		{"s3cr3t-v4lue_+=/@:.~", 0},
+		{"c2VjcmV0IHZhbHVl", 0},
+		{"short", 1},
+		{"with spaces", 1},
+		{"line\nbreak", 1},
+		{"ünïcödé", 2},
+		{"a\nb", 2},
+	} {
+		if problems := maskedVariableValueProblems(tc.value); len(problems) != tc.problems {
+			t.Errorf("expected %d problems for %q, got %v", tc.problems, tc.value, problems)
+		}
+	}
+}
+
+func TestGitlabVariableDiff(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client}
+	secret := "not a valid secret"
+
+	for resourceType, reference := range map[string]map[string]interface{}{
+		"gitlab_project_variable":  {"project": "acme/api"},
+		"gitlab_group_variable":    {"group": "acme"},
+		"gitlab_instance_variable": {},
+	} {
+		r := New("unittest")().ResourcesMap[resourceType]
+		config := map[string]interface{}{"key": "FOO", "value": secret, "masked": true}
+		for k, v := range reference {
+			config[k] = v
+		}
+
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+		if err == nil {
+			t.Fatalf("expected %s to reject the value of the masked variable", resourceType)
+		}
+		if strings.Contains(err.Error(), secret) {
+			t.Fatalf("expected the error of %s not to contain the value: %v", resourceType, err)
+		}
+
+		config["masked"] = false
+		if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta); err != nil {
+			t.Fatalf("expected %s to accept the value of an unmasked variable: %v", resourceType, err)
+		}
+	}
+}
+
+func TestGitlabVariableDiff_messages(t *testing.T) {
+	fake := testutil.NewFakeGitLab(t)
+	meta := &providerMeta{client: fake.Client}
+	r := New("unittest")().ResourcesMap["gitlab_project_variable"]
+	config := func(value string, variableType string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project":       "acme/api",
+			"key":           "FOO",
+			"value":         value,
+			"masked":        true,
+			"variable_type": variableType,
+		})
+	}
+
+	for _, tc := range []struct {
+		value, variableType string
+		expected            []string
+	}{
+		{value: "s3cr3t-v4lue", variableType: "env_var"},
+		{value: "short", variableType: "env_var", expected: []string{`the value of the masked variable "FOO" can't be masked by GitLab: it must be at least 8 characters long, but has 5`, "masked-variable-requirements"}},
+		{value: "-----BEGIN-----\nc2VjcmV0\n-----END-----", variableType: "file", expected: []string{"it must be a single line", "Multi-line files, like certificates, need to be either unmasked or base64 encoded"}},
+	} {
+		_, err := r.Diff(context.Background(), nil, config(tc.value, tc.variableType), meta)
+		if len(tc.expected) == 0 {
+			if err != nil {
+				t.Errorf("expected the value %q to be accepted, got %v", tc.value, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("expected the value %q to be rejected", tc.value)
+		}
+		for _, expected := range tc.expected {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("expected the error for %q to contain %q, got %v", tc.value, expected, err)
+			}
+		}
+	}
+
+	// existing variables which GitLab accepted are only checked if the value changes
+	state := &terraform.InstanceState{ID: "1:FOO:*", Attributes: map[string]string{
+		"id":                 "1:FOO:*",
+		"project":            "acme/api",
+		"numeric_project_id": "1",
+		"key":                "FOO",
+		"value":              "short",
+		"masked":             "true",
+		"variable_type":      "env_var",
+		"environment_scope":  "*",
+		"protected":          "false",
+	}}
+	if _, err := r.Diff(context.Background(), state, config("short", "env_var"), meta); err != nil {
+		t.Errorf("expected the unchanged value of an existing variable to be accepted, got %v", err)
+	}
+	if _, err := r.Diff(context.Background(), state, config("shorter", "env_var"), meta); err == nil {
+		t.Errorf("expected the changed value of an existing variable to be rejected")
+	}
+}
+
+func TestGitlabVariableSchemaValidation(t *testing.T) {
+	r := New("unittest")().ResourcesMap["gitlab_project_variable"]
+	for _, tc := range []struct {
+		key, environmentScope string
+		valid                 bool
+	}{
+		{"FOO_BAR", "*", true},
+		{"FOO", "review/*", true},
+		{"FOO", "${CI_ENVIRONMENT_NAME}", true},
+		{"FOO-BAR", "*", false},
+		{"FOO", "review/?", false},
+		{"FOO", "", false},
+	} {
+		diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project":           "acme/api",
+			"key":               tc.key,
+			"value":             "bar",
+			"environment_scope": tc.environmentScope,
+		}))
+		if diags.HasError() == tc.valid {
+			t.Errorf("expected key %q and environment scope %q to be valid: %t, got %v", tc.key, tc.environmentScope, tc.valid, diags)
+		}
+	}
+}
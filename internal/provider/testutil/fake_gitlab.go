@@ -0,0 +1,477 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// FakeGitLab is an in-memory fake of the core endpoints of the GitLab REST API,
+// to run the CRUD functions of resources in unit tests without a GitLab instance.
+// It emulates projects, groups, users, members, CI/CD variables, branches, protected branches,
+// hooks, repository files, commits, merge requests, labels, milestones, tags, releases, release links and generic packages. It's stateful, paginates the list endpoints like GitLab,
+// with offset and keyset pagination, and returns the errors GitLab returns for common mistakes,
+// like missing parameters, validation errors, conflicts and unknown resources.
+//
+// All requests are authenticated as the administrator `root` with the token of the fake.
+//
+// Use it with `resource.UnitTest` and the `ProviderConfig` in the test configuration:
+//
+//	fake := testutil.NewFakeGitLab(t)
+//	resource.UnitTest(t, resource.TestCase{
+//		ProtoV6ProviderFactories: ...,
+//		Steps: []resource.TestStep{{
+//			Config: fake.ProviderConfig() + `resource "gitlab_project" "this" { name = "foo" }`,
+//		}},
+//	})
+type FakeGitLab struct {
+	// URL is the base URL of the fake GitLab API, like `http://127.0.0.1:1234/api/v4/`.
+	URL string
+	// Token is the personal access token accepted by the fake GitLab API.
+	Token string
+	// Client is a GitLab client for the fake GitLab API, e.g. to prepare or check its state.
+	Client *gitlab.Client
+
+	server *httptest.Server
+	routes []fakeRoute
+
+	lock        sync.Mutex
+	lastID      int
+	commits     int
+	collections map[string][]fakeObject
+	// repositories are the branches of the projects with the files in them, by the project ID
+	repositories map[int]*fakeRepository
+}
+
+// fakeObject is a resource of the fake GitLab API, as it's returned in JSON.
+type fakeObject map[string]interface{}
+
+type fakeRepository struct {
+	branches map[string]*fakeBranch
+	// tags are the commits of the tags by their name
+	tags map[string]fakeObject
+}
+
+type fakeBranch struct {
+	commit fakeObject
+	// files are the files on the branch by their path
+	files map[string]fakeObject
+}
+
+type fakeRoute struct {
+	method   string
+	segments []string
+	handler  func(w http.ResponseWriter, r *fakeRequest)
+}
+
+// fakeRequest is a request to the fake GitLab API with the path variables of the route
+// and the parameters of the query and the JSON body combined.
+type fakeRequest struct {
+	*http.Request
+	vars   map[string]string
+	params fakeObject
+	// body is the raw body of a request which isn't JSON, like the upload of a package file
+	body []byte
+}
+
+const (
+	fakeGitLabToken = "glpat-fake-gitlab-token"
+	fakeRootUserID  = 1
+)
+
+// NewFakeGitLab starts a fake GitLab API server, which is stopped at the end of the test.
+func NewFakeGitLab(t *testing.T) *FakeGitLab {
+	t.Helper()
+
+	f := &FakeGitLab{
+		Token:        fakeGitLabToken,
+		collections:  make(map[string][]fakeObject),
+		repositories: make(map[int]*fakeRepository),
+	}
+	f.registerRoutes()
+	f.collections["users"] = []fakeObject{{
+		"id":                 f.newID(),
+		"username":           "root",
+		"name":               "Administrator",
+		"email":              "admin@example.com",
+		"state":              "active",
+		"is_admin":           true,
+		"can_create_group":   true,
+		"can_create_project": true,
+		"projects_limit":     100000,
+		"namespace_id":       f.newID(),
+		"created_at":         fakeTimestamp(),
+	}}
+
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+	f.URL = f.server.URL + "/api/v4/"
+
+	client, err := gitlab.NewClient(f.Token, gitlab.WithBaseURL(f.URL))
+	if err != nil {
+		t.Fatalf("failed to create the client for the fake GitLab: %v", err)
+	}
+	f.Client = client
+	return f
+}
+
+// ProviderConfig returns the configuration of the provider for the fake GitLab API.
+func (f *FakeGitLab) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "gitlab" {
+  base_url = %q
+  token    = %q
+}
+`, f.URL, f.Token)
+}
+
+func (f *FakeGitLab) route(method string, pattern string, handler func(w http.ResponseWriter, r *fakeRequest)) {
+	f.routes = append(f.routes, fakeRoute{method: method, segments: strings.Split(pattern, "/"), handler: handler})
+}
+
+func (f *FakeGitLab) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("PRIVATE-TOKEN") != f.Token && req.Header.Get("Authorization") != "Bearer "+f.Token {
+		writeFakeError(w, http.StatusUnauthorized, "401 Unauthorized")
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.EscapedPath(), "/api/v4/")
+	if path == req.URL.EscapedPath() {
+		writeFakeError(w, http.StatusNotFound, "404 Not Found")
+		return
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, route := range f.routes {
+		if route.method != req.Method || len(route.segments) != len(segments) {
+			continue
+		}
+		vars, ok := matchFakeRoute(route.segments, segments)
+		if !ok {
+			continue
+		}
+
+		params, body, err := fakeRequestParams(req)
+		if err != nil {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "The provided content-type is not supported."})
+			return
+		}
+
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		route.handler(w, &fakeRequest{Request: req, vars: vars, params: params, body: body})
+		return
+	}
+
+	writeFakeJSON(w, http.StatusNotFound, fakeObject{"error": "404 Not Found"})
+}
+
+func matchFakeRoute(pattern []string, segments []string) (map[string]string, bool) {
+	vars := make(map[string]string)
+	for i, segment := range pattern {
+		if strings.HasPrefix(segment, ":") {
+			value, err := url.PathUnescape(segments[i])
+			if err != nil {
+				return nil, false
+			}
+			vars[segment[1:]] = value
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return vars, true
+}
+
+// fakeRequestParams returns the parameters of the request, the client sends them in the query
+// for GET, DELETE and PATCH requests and as JSON body for POST and PUT requests.
+// The body of a request without JSON content, like an upload, is returned as is.
+func fakeRequestParams(req *http.Request) (fakeObject, []byte, error) {
+	params := make(fakeObject)
+	for name, values := range req.URL.Query() {
+		if strings.HasSuffix(name, "[]") {
+			params[strings.TrimSuffix(name, "[]")] = values
+			continue
+		}
+		params[name] = values[len(values)-1]
+	}
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		return params, data, nil
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return params, nil, nil
+	}
+	var body fakeObject
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, nil, err
+	}
+	for name, value := range body {
+		params[name] = value
+	}
+	return params, nil, nil
+}
+
+func writeFakeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeFakeError writes an error like GitLab, e.g. `{"message": "404 Project Not Found"}`.
+// The message may also be a map of the validation errors per field.
+func writeFakeError(w http.ResponseWriter, statusCode int, message interface{}) {
+	writeFakeJSON(w, statusCode, fakeObject{"message": message})
+}
+
+// requireFakeParams writes the error GitLab returns for missing parameters, like `{"error": "name is missing"}`,
+// and reports whether all the parameters are given.
+func requireFakeParams(w http.ResponseWriter, r *fakeRequest, names ...string) bool {
+	var missing []string
+	for _, name := range names {
+		if value, ok := r.params[name]; !ok || value == nil || value == "" {
+			missing = append(missing, name+" is missing")
+		}
+	}
+	if len(missing) > 0 {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": strings.Join(missing, ", ")})
+		return false
+	}
+	return true
+}
+
+// writeFakePage writes the page of the objects requested with the `page` and `per_page` parameters,
+// with the pagination headers of GitLab. If keyset pagination is requested and supported by the endpoint,
+// the objects must be sorted by their `id` and the `Link` header contains the link to the next page.
+func writeFakePage(w http.ResponseWriter, r *fakeRequest, objects []fakeObject, keyset bool) {
+	perPage := fakeIntParam(r.params, "per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	if keyset && r.params["pagination"] == "keyset" {
+		first := 0
+		if idAfter := fakeIntParam(r.params, "id_after", 0); idAfter > 0 {
+			for first < len(objects) && fakeInt(objects[first]["id"]) <= idAfter {
+				first++
+			}
+		}
+		last := first + perPage
+		if last > len(objects) {
+			last = len(objects)
+		}
+		if last < len(objects) {
+			next := *r.URL
+			query := next.Query()
+			query.Set("id_after", strconv.Itoa(fakeInt(objects[last-1]["id"])))
+			next.RawQuery = query.Encode()
+			next.Scheme, next.Host = "http", r.Host
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+		}
+		writeFakeJSON(w, http.StatusOK, nonNilFakeObjects(objects[first:last]))
+		return
+	}
+
+	page := fakeIntParam(r.params, "page", 1)
+	if page < 1 {
+		page = 1
+	}
+	totalPages := (len(objects) + perPage - 1) / perPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	header := w.Header()
+	header.Set("X-Page", strconv.Itoa(page))
+	header.Set("X-Per-Page", strconv.Itoa(perPage))
+	header.Set("X-Total", strconv.Itoa(len(objects)))
+	header.Set("X-Total-Pages", strconv.Itoa(totalPages))
+	header.Set("X-Next-Page", "")
+	header.Set("X-Prev-Page", "")
+	if page < totalPages {
+		header.Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	if page > 1 {
+		header.Set("X-Prev-Page", strconv.Itoa(page-1))
+	}
+
+	first := (page - 1) * perPage
+	if first > len(objects) {
+		first = len(objects)
+	}
+	last := first + perPage
+	if last > len(objects) {
+		last = len(objects)
+	}
+	writeFakeJSON(w, http.StatusOK, nonNilFakeObjects(objects[first:last]))
+}
+
+func nonNilFakeObjects(objects []fakeObject) []fakeObject {
+	if objects == nil {
+		return []fakeObject{}
+	}
+	return objects
+}
+
+// sortFakeObjects sorts the objects by the `order_by` and `sort` parameters, by ID by default.
+func sortFakeObjects(r *fakeRequest, objects []fakeObject, defaultSort string) []fakeObject {
+	sorted := append([]fakeObject(nil), objects...)
+	orderBy, _ := r.params["order_by"].(string)
+	if orderBy == "" || orderBy == "created_at" {
+		orderBy = "id"
+	}
+	descending := defaultSort == "desc"
+	if s, ok := r.params["sort"].(string); ok {
+		descending = s == "desc"
+	}
+	// keyset pagination always follows the order of the IDs
+	if r.params["pagination"] == "keyset" {
+		orderBy = "id"
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		var less bool
+		if orderBy == "id" {
+			less = fakeInt(sorted[i]["id"]) < fakeInt(sorted[j]["id"])
+		} else {
+			less = fmt.Sprint(sorted[i][orderBy]) < fmt.Sprint(sorted[j][orderBy])
+		}
+		if descending {
+			return !less
+		}
+		return less
+	})
+	if descending && r.params["pagination"] == "keyset" {
+		// the fake only supports ascending keyset pagination
+		sort.SliceStable(sorted, func(i, j int) bool { return fakeInt(sorted[i]["id"]) < fakeInt(sorted[j]["id"]) })
+	}
+	return sorted
+}
+
+func (f *FakeGitLab) newID() int {
+	f.lastID++
+	return f.lastID
+}
+
+func fakeTimestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// fakeInt returns the integer of a JSON number or a query parameter, or 0.
+func fakeInt(v interface{}) int {
+	switch v := v.(type) {
+	case int:
+		return v
+	case json.Number:
+		i, _ := v.Int64()
+		return int(i)
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
+
+func fakeIntParam(params fakeObject, name string, defaultValue int) int {
+	if v, ok := params[name]; ok && v != "" {
+		return fakeInt(v)
+	}
+	return defaultValue
+}
+
+// fakeBool returns the boolean of a JSON boolean or a query parameter.
+func fakeBool(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+func fakeString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// updateFakeObject sets the given parameters on the object, except the ones that can't be changed.
+func updateFakeObject(object fakeObject, params fakeObject, except ...string) {
+	for name, value := range params {
+		if name == "id" || name == "page" || name == "per_page" {
+			continue
+		}
+		skip := false
+		for _, e := range except {
+			if name == e {
+				skip = true
+			}
+		}
+		if !skip {
+			object[name] = value
+		}
+	}
+}
+
+// findFakeObject returns the index of the first object of the collection which matches.
+func (f *FakeGitLab) findFakeObject(collection string, match func(fakeObject) bool) (int, fakeObject) {
+	for i, object := range f.collections[collection] {
+		if match(object) {
+			return i, object
+		}
+	}
+	return -1, nil
+}
+
+func (f *FakeGitLab) deleteFakeObject(collection string, index int) {
+	objects := f.collections[collection]
+	f.collections[collection] = append(objects[:index:index], objects[index+1:]...)
+}
+
+// filterFakeObjects returns the objects which match.
+func filterFakeObjects(objects []fakeObject, match func(fakeObject) bool) []fakeObject {
+	var filtered []fakeObject
+	for _, object := range objects {
+		if match(object) {
+			filtered = append(filtered, object)
+		}
+	}
+	return filtered
+}
+
+// byIDOrPath matches an object by its ID or its path in the given field, like a project by `path_with_namespace`.
+func byIDOrPath(id string, pathField string) func(fakeObject) bool {
+	return func(object fakeObject) bool {
+		if n, err := strconv.Atoi(id); err == nil {
+			return fakeInt(object["id"]) == n
+		}
+		return strings.EqualFold(fakeString(object[pathField]), id)
+	}
+}
@@ -0,0 +1,2616 @@
+package testutil
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The access levels of GitLab, see https://docs.gitlab.com/ee/api/members.html#valid-access-levels
+var fakeAccessLevels = map[int]bool{0: true, 5: true, 10: true, 20: true, 30: true, 40: true, 50: true}
+
+var (
+	fakeVariableKeyPattern  = regexp.MustCompile(`^[a-zA-Z0-9_]{1,255}$`)
+	fakeMaskedValuePattern  = regexp.MustCompile(`^[a-zA-Z0-9_+=/@:.~\-]{8,}$`)
+	fakeLabelColorPattern   = regexp.MustCompile(`^#[0-9a-fA-F]{3}([0-9a-fA-F]{3})?$`)
+	fakeBranchNamePattern   = regexp.MustCompile(`^[^\s~^:?*\[\\]+$`)
+	fakeNamespacePathFormat = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.\-]*$`)
+	fakePackagePathPattern  = regexp.MustCompile(`^[a-zA-Z0-9._+\-]+$`)
+)
+
+func (f *FakeGitLab) registerRoutes() {
+	f.route(http.MethodGet, "", f.getRoot)
+	f.route(http.MethodGet, "version", f.getVersion)
+	f.route(http.MethodGet, "metadata", f.getVersion)
+	f.route(http.MethodGet, "user", f.getCurrentUser)
+	f.route(http.MethodGet, "application/settings", f.getSettings)
+
+	f.route(http.MethodGet, "users", f.listUsers)
+	f.route(http.MethodPost, "users", f.createUser)
+	f.route(http.MethodGet, "users/:user", f.getUser)
+	f.route(http.MethodPut, "users/:user", f.updateUser)
+	f.route(http.MethodDelete, "users/:user", f.deleteUser)
+
+	f.route(http.MethodGet, "groups", f.listGroups)
+	f.route(http.MethodPost, "groups", f.createGroup)
+	f.route(http.MethodGet, "groups/:group", f.getGroup)
+	f.route(http.MethodPut, "groups/:group", f.updateGroup)
+	f.route(http.MethodDelete, "groups/:group", f.deleteGroup)
+	f.route(http.MethodGet, "groups/:group/subgroups", f.listSubgroups)
+	f.route(http.MethodGet, "groups/:group/projects", f.listGroupProjects)
+
+	f.route(http.MethodGet, "projects", f.listProjects)
+	f.route(http.MethodPost, "projects", f.createProject)
+	f.route(http.MethodGet, "projects/:project", f.getProject)
+	f.route(http.MethodPut, "projects/:project", f.updateProject)
+	f.route(http.MethodDelete, "projects/:project", f.deleteProject)
+
+	for _, kind := range []string{"projects", "groups"} {
+		kind := kind
+		prefix := kind + "/:" + strings.TrimSuffix(kind, "s")
+
+		f.route(http.MethodGet, prefix+"/members", f.listMembers(kind, false))
+		f.route(http.MethodGet, prefix+"/members/all", f.listMembers(kind, true))
+		f.route(http.MethodPost, prefix+"/members", f.addMember(kind))
+		f.route(http.MethodGet, prefix+"/members/:user", f.getMember(kind, false))
+		f.route(http.MethodGet, prefix+"/members/all/:user", f.getMember(kind, true))
+		f.route(http.MethodPut, prefix+"/members/:user", f.updateMember(kind))
+		f.route(http.MethodDelete, prefix+"/members/:user", f.removeMember(kind))
+
+		f.route(http.MethodGet, prefix+"/variables", f.listVariables(kind))
+		f.route(http.MethodPost, prefix+"/variables", f.createVariable(kind))
+		f.route(http.MethodGet, prefix+"/variables/:key", f.getVariable(kind))
+		f.route(http.MethodPut, prefix+"/variables/:key", f.updateVariable(kind))
+		f.route(http.MethodDelete, prefix+"/variables/:key", f.removeVariable(kind))
+
+		f.route(http.MethodGet, prefix+"/hooks", f.listHooks(kind))
+		f.route(http.MethodPost, prefix+"/hooks", f.addHook(kind))
+		f.route(http.MethodGet, prefix+"/hooks/:hook", f.getHook(kind))
+		f.route(http.MethodPut, prefix+"/hooks/:hook", f.updateHook(kind))
+		f.route(http.MethodDelete, prefix+"/hooks/:hook", f.deleteHook(kind))
+
+		f.route(http.MethodGet, prefix+"/labels", f.listLabels(kind))
+		f.route(http.MethodPost, prefix+"/labels", f.createLabel(kind))
+		f.route(http.MethodPut, prefix+"/labels", f.updateLabel(kind))
+		f.route(http.MethodDelete, prefix+"/labels", f.deleteLabel(kind))
+		f.route(http.MethodGet, prefix+"/labels/:label", f.getLabel(kind))
+		f.route(http.MethodPut, prefix+"/labels/:label", f.updateLabel(kind))
+		f.route(http.MethodDelete, prefix+"/labels/:label", f.deleteLabel(kind))
+	}
+
+	f.route(http.MethodGet, "projects/:project/repository/branches", f.listBranches)
+	f.route(http.MethodPost, "projects/:project/repository/branches", f.createBranch)
+	f.route(http.MethodGet, "projects/:project/repository/branches/:branch", f.getBranch)
+	f.route(http.MethodDelete, "projects/:project/repository/branches/:branch", f.deleteBranch)
+
+	f.route(http.MethodGet, "projects/:project/protected_branches", f.listProtectedBranches)
+	f.route(http.MethodPost, "projects/:project/protected_branches", f.protectBranch)
+	f.route(http.MethodGet, "projects/:project/protected_branches/:branch", f.getProtectedBranch)
+	f.route(http.MethodPatch, "projects/:project/protected_branches/:branch", f.updateProtectedBranch)
+	f.route(http.MethodDelete, "projects/:project/protected_branches/:branch", f.unprotectBranch)
+
+	f.route(http.MethodPost, "projects/:project/repository/commits", f.createCommit)
+	f.route(http.MethodGet, "projects/:project/repository/commits/:sha", f.getCommit)
+
+	f.route(http.MethodGet, "projects/:project/merge_requests", f.listMergeRequests)
+	f.route(http.MethodPost, "projects/:project/merge_requests", f.createMergeRequest)
+	f.route(http.MethodGet, "projects/:project/merge_requests/:merge_request", f.getMergeRequest)
+	f.route(http.MethodPut, "projects/:project/merge_requests/:merge_request", f.updateMergeRequest)
+	f.route(http.MethodDelete, "projects/:project/merge_requests/:merge_request", f.deleteMergeRequest)
+	f.route(http.MethodPut, "projects/:project/merge_requests/:merge_request/merge", f.acceptMergeRequest)
+	f.route(http.MethodPost, "projects/:project/merge_requests/:merge_request/cancel_merge_when_pipeline_succeeds", f.cancelMergeWhenPipelineSucceeds)
+
+	f.route(http.MethodGet, "projects/:project/milestones", f.listMilestones)
+	f.route(http.MethodPost, "projects/:project/milestones", f.createMilestone)
+
+	f.route(http.MethodGet, "projects/:project/repository/tags/:tag", f.getTag)
+
+	f.route(http.MethodGet, "projects/:project/releases", f.listReleases)
+	f.route(http.MethodPost, "projects/:project/releases", f.createRelease)
+	f.route(http.MethodGet, "projects/:project/releases/:tag", f.getRelease)
+	f.route(http.MethodPut, "projects/:project/releases/:tag", f.updateRelease)
+	f.route(http.MethodDelete, "projects/:project/releases/:tag", f.deleteRelease)
+	f.route(http.MethodGet, "projects/:project/releases/:tag/assets/links", f.listReleaseLinks)
+	f.route(http.MethodPost, "projects/:project/releases/:tag/assets/links", f.createReleaseLink)
+	f.route(http.MethodGet, "projects/:project/releases/:tag/assets/links/:link", f.getReleaseLink)
+	f.route(http.MethodPut, "projects/:project/releases/:tag/assets/links/:link", f.updateReleaseLink)
+	f.route(http.MethodDelete, "projects/:project/releases/:tag/assets/links/:link", f.deleteReleaseLink)
+
+	f.route(http.MethodGet, "projects/:project/repository/files/:file", f.getFile)
+	f.route(http.MethodHead, "projects/:project/repository/files/:file", f.getFileMetadata)
+	f.route(http.MethodGet, "projects/:project/repository/files/:file/raw", f.getRawFile)
+	f.route(http.MethodPost, "projects/:project/repository/files/:file", f.createFile)
+	f.route(http.MethodPut, "projects/:project/repository/files/:file", f.updateFile)
+	f.route(http.MethodDelete, "projects/:project/repository/files/:file", f.deleteFile)
+
+	f.route(http.MethodPut, "projects/:project/packages/generic/:name/:version/:file", f.uploadGenericPackageFile)
+	f.route(http.MethodGet, "projects/:project/packages", f.listPackages)
+	f.route(http.MethodGet, "projects/:project/packages/:package", f.getPackage)
+	f.route(http.MethodDelete, "projects/:project/packages/:package", f.deletePackage)
+	f.route(http.MethodGet, "projects/:project/packages/:package/package_files", f.listPackageFiles)
+	f.route(http.MethodDelete, "projects/:project/packages/:package/package_files/:file", f.deletePackageFile)
+}
+
+func (f *FakeGitLab) getRoot(w http.ResponseWriter, r *fakeRequest) {
+	// the client probes the rate limit of the instance with this request
+	writeFakeJSON(w, http.StatusOK, fakeObject{})
+}
+
+func (f *FakeGitLab) getVersion(w http.ResponseWriter, r *fakeRequest) {
+	writeFakeJSON(w, http.StatusOK, fakeObject{"version": "16.0.0-ee", "revision": "fake", "enterprise": true})
+}
+
+func (f *FakeGitLab) getSettings(w http.ResponseWriter, r *fakeRequest) {
+	writeFakeJSON(w, http.StatusOK, fakeObject{"default_branch_protection": 2, "default_branch_name": "main"})
+}
+
+// users
+
+func (f *FakeGitLab) getCurrentUser(w http.ResponseWriter, r *fakeRequest) {
+	_, user := f.findFakeObject("users", byIDOrPath(strconv.Itoa(fakeRootUserID), "username"))
+	writeFakeJSON(w, http.StatusOK, user)
+}
+
+func (f *FakeGitLab) findUser(w http.ResponseWriter, id string) (int, fakeObject) {
+	index, user := f.findFakeObject("users", byIDOrPath(id, "username"))
+	if user == nil {
+		writeFakeError(w, http.StatusNotFound, "404 User Not Found")
+	}
+	return index, user
+}
+
+func (f *FakeGitLab) listUsers(w http.ResponseWriter, r *fakeRequest) {
+	users := filterFakeObjects(f.collections["users"], func(user fakeObject) bool {
+		if username := fakeString(r.params["username"]); username != "" && !strings.EqualFold(fakeString(user["username"]), username) {
+			return false
+		}
+		search := strings.ToLower(fakeString(r.params["search"]))
+		return search == "" ||
+			strings.Contains(strings.ToLower(fakeString(user["username"])), search) ||
+			strings.Contains(strings.ToLower(fakeString(user["name"])), search) ||
+			strings.EqualFold(fakeString(user["email"]), search)
+	})
+	writeFakePage(w, r, sortFakeObjects(r, users, "desc"), r.params["order_by"] == "id")
+}
+
+func (f *FakeGitLab) createUser(w http.ResponseWriter, r *fakeRequest) {
+	if !requireFakeParams(w, r, "email", "name", "username") {
+		return
+	}
+	if _, existing := f.findFakeObject("users", func(user fakeObject) bool {
+		return strings.EqualFold(fakeString(user["email"]), fakeString(r.params["email"]))
+	}); existing != nil {
+		writeFakeError(w, http.StatusConflict, "Email has already been taken")
+		return
+	}
+	if _, existing := f.findFakeObject("users", byIDOrPath(fakeString(r.params["username"]), "username")); existing != nil {
+		writeFakeError(w, http.StatusConflict, "Username has already been taken")
+		return
+	}
+
+	user := fakeObject{
+		"id":                 f.newID(),
+		"state":              "active",
+		"is_admin":           false,
+		"can_create_group":   true,
+		"can_create_project": true,
+		"projects_limit":     100000,
+		"namespace_id":       f.newID(),
+		"created_at":         fakeTimestamp(),
+	}
+	updateFakeObject(user, r.params, "password", "reset_password", "force_random_password", "admin")
+	user["is_admin"] = fakeBool(r.params["admin"])
+	f.collections["users"] = append(f.collections["users"], user)
+	writeFakeJSON(w, http.StatusCreated, user)
+}
+
+func (f *FakeGitLab) getUser(w http.ResponseWriter, r *fakeRequest) {
+	if _, user := f.findUser(w, r.vars["user"]); user != nil {
+		writeFakeJSON(w, http.StatusOK, user)
+	}
+}
+
+func (f *FakeGitLab) updateUser(w http.ResponseWriter, r *fakeRequest) {
+	_, user := f.findUser(w, r.vars["user"])
+	if user == nil {
+		return
+	}
+	updateFakeObject(user, r.params, "password", "admin")
+	if admin, ok := r.params["admin"]; ok {
+		user["is_admin"] = fakeBool(admin)
+	}
+	writeFakeJSON(w, http.StatusOK, user)
+}
+
+func (f *FakeGitLab) deleteUser(w http.ResponseWriter, r *fakeRequest) {
+	index, user := f.findUser(w, r.vars["user"])
+	if user == nil {
+		return
+	}
+	f.deleteFakeObject("users", index)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// groups
+
+func (f *FakeGitLab) findGroup(w http.ResponseWriter, id string) (int, fakeObject) {
+	index, group := f.findFakeObject("groups", byIDOrPath(id, "full_path"))
+	if group == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Group Not Found")
+	}
+	return index, group
+}
+
+func (f *FakeGitLab) listGroups(w http.ResponseWriter, r *fakeRequest) {
+	groups := filterFakeObjects(f.collections["groups"], func(group fakeObject) bool {
+		if fakeBool(r.params["top_level_only"]) && group["parent_id"] != nil {
+			return false
+		}
+		search := strings.ToLower(fakeString(r.params["search"]))
+		return search == "" || strings.Contains(strings.ToLower(fakeString(group["full_path"])), search)
+	})
+	writeFakePage(w, r, sortFakeObjects(r, groups, "asc"), false)
+}
+
+func (f *FakeGitLab) listSubgroups(w http.ResponseWriter, r *fakeRequest) {
+	_, parent := f.findGroup(w, r.vars["group"])
+	if parent == nil {
+		return
+	}
+	subgroups := filterFakeObjects(f.collections["groups"], func(group fakeObject) bool {
+		return fakeInt(group["parent_id"]) == fakeInt(parent["id"])
+	})
+	writeFakePage(w, r, sortFakeObjects(r, subgroups, "asc"), false)
+}
+
+func (f *FakeGitLab) createGroup(w http.ResponseWriter, r *fakeRequest) {
+	if !requireFakeParams(w, r, "name", "path") {
+		return
+	}
+	path := fakeString(r.params["path"])
+	if !fakeNamespacePathFormat.MatchString(path) {
+		writeFakeError(w, http.StatusBadRequest, fakeObject{"path": []string{"can contain only letters, digits, '_', '-' and '.'. Cannot start with '-', end in '.git' or end in '.atom'"}})
+		return
+	}
+
+	fullPath := path
+	var parentID interface{}
+	if id := fakeInt(r.params["parent_id"]); id != 0 {
+		_, parent := f.findFakeObject("groups", byIDOrPath(strconv.Itoa(id), "full_path"))
+		if parent == nil {
+			writeFakeError(w, http.StatusNotFound, "404 Group Not Found")
+			return
+		}
+		fullPath = fakeString(parent["full_path"]) + "/" + path
+		parentID = id
+	}
+	if f.namespaceExists(fullPath) {
+		writeFakeError(w, http.StatusBadRequest, fakeObject{"path": []string{"has already been taken"}})
+		return
+	}
+
+	group := fakeObject{
+		"id":                        f.newID(),
+		"visibility":                "private",
+		"description":               "",
+		"project_creation_level":    "developer",
+		"default_branch_protection": 2,
+		"subgroup_creation_level":   "maintainer",
+		"request_access_enabled":    true,
+		"created_at":                fakeTimestamp(),
+	}
+	updateFakeObject(group, r.params)
+	group["full_path"] = fullPath
+	group["full_name"] = f.fullName(parentID, fakeString(r.params["name"]))
+	group["parent_id"] = parentID
+	group["web_url"] = strings.TrimSuffix(f.URL, "api/v4/") + "groups/" + fullPath
+	f.collections["groups"] = append(f.collections["groups"], group)
+
+	// the creator of a group is its owner
+	f.collections["groups/members"] = append(f.collections["groups/members"], f.newMember(fakeInt(group["id"]), fakeRootUserID, 50))
+	writeFakeJSON(w, http.StatusCreated, group)
+}
+
+func (f *FakeGitLab) getGroup(w http.ResponseWriter, r *fakeRequest) {
+	if _, group := f.findGroup(w, r.vars["group"]); group != nil {
+		writeFakeJSON(w, http.StatusOK, group)
+	}
+}
+
+func (f *FakeGitLab) updateGroup(w http.ResponseWriter, r *fakeRequest) {
+	_, group := f.findGroup(w, r.vars["group"])
+	if group == nil {
+		return
+	}
+	updateFakeObject(group, r.params, "path", "parent_id", "full_path")
+	if path := fakeString(r.params["path"]); path != "" {
+		// the old path isn't redirected and the subgroups and projects keep their paths
+		fullPath := fakeString(group["full_path"])
+		group["full_path"] = strings.TrimSuffix(fullPath, fakeString(group["path"])) + path
+		group["path"] = path
+		group["web_url"] = strings.TrimSuffix(f.URL, "api/v4/") + "groups/" + fakeString(group["full_path"])
+	}
+	writeFakeJSON(w, http.StatusOK, group)
+}
+
+func (f *FakeGitLab) deleteGroup(w http.ResponseWriter, r *fakeRequest) {
+	index, group := f.findGroup(w, r.vars["group"])
+	if group == nil {
+		return
+	}
+	prefix := fakeString(group["full_path"]) + "/"
+	f.deleteFakeObject("groups", index)
+	f.collections["groups"] = filterFakeObjects(f.collections["groups"], func(subgroup fakeObject) bool {
+		return !strings.HasPrefix(fakeString(subgroup["full_path"]), prefix)
+	})
+	f.collections["projects"] = filterFakeObjects(f.collections["projects"], func(project fakeObject) bool {
+		return !strings.HasPrefix(fakeString(project["path_with_namespace"]), prefix)
+	})
+	writeFakeJSON(w, http.StatusAccepted, fakeObject{"message": "202 Accepted"})
+}
+
+func (f *FakeGitLab) namespaceExists(fullPath string) bool {
+	for _, collection := range []string{"groups", "projects"} {
+		field := "full_path"
+		if collection == "projects" {
+			field = "path_with_namespace"
+		}
+		if _, existing := f.findFakeObject(collection, byIDOrPath(fullPath, field)); existing != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FakeGitLab) fullName(groupID interface{}, name string) string {
+	if groupID == nil {
+		return name
+	}
+	_, group := f.findFakeObject("groups", byIDOrPath(fakeString(groupID), "full_path"))
+	if group == nil {
+		return name
+	}
+	return fakeString(group["full_name"]) + " / " + name
+}
+
+// projects
+
+func (f *FakeGitLab) findProject(w http.ResponseWriter, id string) (int, fakeObject) {
+	index, project := f.findFakeObject("projects", byIDOrPath(id, "path_with_namespace"))
+	if project == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Project Not Found")
+	}
+	return index, project
+}
+
+func (f *FakeGitLab) listProjects(w http.ResponseWriter, r *fakeRequest) {
+	projects := filterFakeObjects(f.collections["projects"], func(project fakeObject) bool {
+		search := strings.ToLower(fakeString(r.params["search"]))
+		return search == "" || strings.Contains(strings.ToLower(fakeString(project["path_with_namespace"])), search)
+	})
+	writeFakePage(w, r, sortFakeObjects(r, projects, "desc"), r.params["order_by"] == "id")
+}
+
+func (f *FakeGitLab) listGroupProjects(w http.ResponseWriter, r *fakeRequest) {
+	_, group := f.findGroup(w, r.vars["group"])
+	if group == nil {
+		return
+	}
+	prefix := fakeString(group["full_path"]) + "/"
+	projects := filterFakeObjects(f.collections["projects"], func(project fakeObject) bool {
+		path := strings.TrimPrefix(fakeString(project["path_with_namespace"]), prefix)
+		if path == fakeString(project["path_with_namespace"]) {
+			return false
+		}
+		return fakeBool(r.params["include_subgroups"]) || !strings.Contains(path, "/")
+	})
+	writeFakePage(w, r, sortFakeObjects(r, projects, "desc"), false)
+}
+
+func (f *FakeGitLab) createProject(w http.ResponseWriter, r *fakeRequest) {
+	name, path := fakeString(r.params["name"]), fakeString(r.params["path"])
+	if name == "" && path == "" {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "name, path are missing, at least one parameter must be provided"})
+		return
+	}
+	if path == "" {
+		path = strings.ToLower(regexp.MustCompile(`[^a-zA-Z0-9_.\-]+`).ReplaceAllString(name, "-"))
+	}
+	if name == "" {
+		name = path
+	}
+
+	namespace := fakeObject{"id": fakeRootNamespaceID(f), "full_path": "root", "kind": "user", "name": "Administrator", "path": "root"}
+	if id := fakeString(r.params["namespace_id"]); id != "" {
+		_, group := f.findFakeObject("groups", byIDOrPath(id, "full_path"))
+		if group == nil {
+			writeFakeError(w, http.StatusNotFound, "404 Namespace Not Found")
+			return
+		}
+		namespace = fakeObject{"id": group["id"], "full_path": group["full_path"], "kind": "group", "name": group["name"], "path": group["path"], "parent_id": group["parent_id"]}
+	}
+
+	pathWithNamespace := fakeString(namespace["full_path"]) + "/" + path
+	if f.namespaceExists(pathWithNamespace) {
+		writeFakeError(w, http.StatusBadRequest, fakeObject{
+			"project_namespace.name": []string{"has already been taken"},
+			"name":                   []string{"has already been taken"},
+			"path":                   []string{"has already been taken"},
+		})
+		return
+	}
+
+	webURL := strings.TrimSuffix(f.URL, "api/v4/") + pathWithNamespace
+	project := fakeObject{
+		"id":                     f.newID(),
+		"description":            "",
+		"visibility":             "private",
+		"default_branch":         nil,
+		"archived":               false,
+		"issues_enabled":         true,
+		"merge_requests_enabled": true,
+		"wiki_enabled":           true,
+		"snippets_enabled":       true,
+		"request_access_enabled": true,
+		"merge_method":           "merge",
+		"squash_option":          "default_off",
+		"import_status":          "none",
+		"created_at":             fakeTimestamp(),
+	}
+	updateFakeObject(project, r.params, "namespace_id", "initialize_with_readme")
+	project["name"] = name
+	project["path"] = path
+	project["path_with_namespace"] = pathWithNamespace
+	project["name_with_namespace"] = f.fullName(namespace["id"], name)
+	if namespace["kind"] == "user" {
+		project["name_with_namespace"] = "Administrator / " + name
+	}
+	project["namespace"] = namespace
+	project["web_url"] = webURL
+	project["http_url_to_repo"] = webURL + ".git"
+	project["ssh_url_to_repo"] = "git@" + strings.TrimPrefix(strings.TrimPrefix(f.server.URL, "http://"), "https://") + ":" + pathWithNamespace + ".git"
+	f.collections["projects"] = append(f.collections["projects"], project)
+	f.repositories[fakeInt(project["id"])] = &fakeRepository{branches: make(map[string]*fakeBranch), tags: make(map[string]fakeObject)}
+
+	if fakeBool(r.params["initialize_with_readme"]) {
+		branch := fakeString(r.params["default_branch"])
+		if branch == "" {
+			branch = "main"
+		}
+		f.commit(fakeInt(project["id"]), branch, "", "Initial commit", func(files map[string]fakeObject) {
+			files["README.md"] = f.newFile("README.md", []byte("# "+name+"\n"))
+		})
+		f.initDefaultBranch(project, branch)
+	}
+
+	// the creator of a project is its owner
+	f.collections["projects/members"] = append(f.collections["projects/members"], f.newMember(fakeInt(project["id"]), fakeRootUserID, 50))
+	writeFakeJSON(w, http.StatusCreated, project)
+}
+
+func fakeRootNamespaceID(f *FakeGitLab) interface{} {
+	_, root := f.findFakeObject("users", byIDOrPath(strconv.Itoa(fakeRootUserID), "username"))
+	return root["namespace_id"]
+}
+
+func (f *FakeGitLab) getProject(w http.ResponseWriter, r *fakeRequest) {
+	if _, project := f.findProject(w, r.vars["project"]); project != nil {
+		writeFakeJSON(w, http.StatusOK, project)
+	}
+}
+
+func (f *FakeGitLab) updateProject(w http.ResponseWriter, r *fakeRequest) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return
+	}
+	if branch := fakeString(r.params["default_branch"]); branch != "" {
+		if _, ok := f.repositories[fakeInt(project["id"])].branches[branch]; !ok {
+			writeFakeError(w, http.StatusBadRequest, fakeObject{"base": []string{"Could not change HEAD: branch '" + branch + "' does not exist"}})
+			return
+		}
+	}
+	updateFakeObject(project, r.params, "path_with_namespace", "namespace", "namespace_id")
+	if path := fakeString(r.params["path"]); path != "" {
+		// the old path isn't redirected to the renamed project
+		namespace, _ := project["namespace"].(fakeObject)
+		project["path_with_namespace"] = fakeString(namespace["full_path"]) + "/" + path
+		project["web_url"] = strings.TrimSuffix(f.URL, "api/v4/") + fakeString(project["path_with_namespace"])
+	}
+	writeFakeJSON(w, http.StatusOK, project)
+}
+
+func (f *FakeGitLab) deleteProject(w http.ResponseWriter, r *fakeRequest) {
+	index, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return
+	}
+	f.deleteFakeObject("projects", index)
+	delete(f.repositories, fakeInt(project["id"]))
+	writeFakeJSON(w, http.StatusAccepted, fakeObject{"message": "202 Accepted"})
+}
+
+// findNamespace returns the project or group of the request, by the kind of its route.
+func (f *FakeGitLab) findNamespace(w http.ResponseWriter, r *fakeRequest, kind string) fakeObject {
+	if kind == "projects" {
+		_, project := f.findProject(w, r.vars["project"])
+		return project
+	}
+	_, group := f.findGroup(w, r.vars["group"])
+	return group
+}
+
+// members
+
+func (f *FakeGitLab) newMember(sourceID int, userID int, accessLevel int) fakeObject {
+	_, user := f.findFakeObject("users", byIDOrPath(strconv.Itoa(userID), "username"))
+	return fakeObject{
+		"source_id":    sourceID,
+		"id":           userID,
+		"username":     user["username"],
+		"name":         user["name"],
+		"state":        user["state"],
+		"access_level": accessLevel,
+		"expires_at":   nil,
+		"created_at":   fakeTimestamp(),
+	}
+}
+
+// members returns the members of the project or group, which includes the members inherited from
+// the parent groups if requested, with the highest access level of a user.
+func (f *FakeGitLab) members(kind string, namespace fakeObject, inherited bool) []fakeObject {
+	members := filterFakeObjects(f.collections[kind+"/members"], func(member fakeObject) bool {
+		return fakeInt(member["source_id"]) == fakeInt(namespace["id"])
+	})
+	if !inherited {
+		return members
+	}
+
+	var parentID interface{}
+	if kind == "projects" {
+		if ns, ok := namespace["namespace"].(fakeObject); ok && ns["kind"] == "group" {
+			parentID = ns["id"]
+		}
+	} else {
+		parentID = namespace["parent_id"]
+	}
+	if parentID == nil {
+		return members
+	}
+	_, parent := f.findFakeObject("groups", byIDOrPath(fakeString(parentID), "full_path"))
+	if parent == nil {
+		return members
+	}
+
+	for _, inheritedMember := range f.members("groups", parent, true) {
+		_, existing := f.findMemberIn(members, fakeInt(inheritedMember["id"]))
+		if existing == nil {
+			members = append(members, inheritedMember)
+		} else if fakeInt(inheritedMember["access_level"]) > fakeInt(existing["access_level"]) {
+			existing["access_level"] = inheritedMember["access_level"]
+		}
+	}
+	return members
+}
+
+func (f *FakeGitLab) findMemberIn(members []fakeObject, userID int) (int, fakeObject) {
+	for i, member := range members {
+		if fakeInt(member["id"]) == userID {
+			return i, member
+		}
+	}
+	return -1, nil
+}
+
+func (f *FakeGitLab) listMembers(kind string, inherited bool) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil {
+			return
+		}
+		// inherited members are copies, to not modify the members of the parent groups
+		var members []fakeObject
+		for _, member := range f.members(kind, namespace, inherited) {
+			members = append(members, copyFakeObject(member))
+		}
+		query := strings.ToLower(fakeString(r.params["query"]))
+		members = filterFakeObjects(members, func(member fakeObject) bool {
+			return query == "" || strings.Contains(strings.ToLower(fakeString(member["username"])), query)
+		})
+		writeFakePage(w, r, sortFakeObjects(r, members, "asc"), false)
+	}
+}
+
+func (f *FakeGitLab) getMember(kind string, inherited bool) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil {
+			return
+		}
+		_, member := f.findMemberIn(f.members(kind, namespace, inherited), fakeInt(r.vars["user"]))
+		if member == nil {
+			writeFakeError(w, http.StatusNotFound, "404 Member Not Found")
+			return
+		}
+		writeFakeJSON(w, http.StatusOK, member)
+	}
+}
+
+func (f *FakeGitLab) addMember(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil || !requireFakeParams(w, r, "user_id", "access_level") {
+			return
+		}
+		accessLevel := fakeInt(r.params["access_level"])
+		if !fakeAccessLevels[accessLevel] {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "access_level does not have a valid value"})
+			return
+		}
+		userID := fakeInt(r.params["user_id"])
+		if _, user := f.findFakeObject("users", byIDOrPath(strconv.Itoa(userID), "username")); user == nil {
+			writeFakeError(w, http.StatusNotFound, "404 User Not Found")
+			return
+		}
+		if _, existing := f.findMemberIn(f.members(kind, namespace, false), userID); existing != nil {
+			writeFakeError(w, http.StatusConflict, "Member already exists")
+			return
+		}
+
+		member := f.newMember(fakeInt(namespace["id"]), userID, accessLevel)
+		if expiresAt, ok := r.params["expires_at"]; ok && expiresAt != "" {
+			member["expires_at"] = expiresAt
+		}
+		f.collections[kind+"/members"] = append(f.collections[kind+"/members"], member)
+		writeFakeJSON(w, http.StatusCreated, member)
+	}
+}
+
+func (f *FakeGitLab) updateMember(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil || !requireFakeParams(w, r, "access_level") {
+			return
+		}
+		_, member := f.findMemberIn(f.members(kind, namespace, false), fakeInt(r.vars["user"]))
+		if member == nil {
+			writeFakeError(w, http.StatusNotFound, "404 Member Not Found")
+			return
+		}
+		accessLevel := fakeInt(r.params["access_level"])
+		if !fakeAccessLevels[accessLevel] {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "access_level does not have a valid value"})
+			return
+		}
+		member["access_level"] = accessLevel
+		// like GitLab, an empty expiry date removes the expiry
+		if expiresAt, ok := r.params["expires_at"]; ok {
+			member["expires_at"] = expiresAt
+			if expiresAt == "" {
+				member["expires_at"] = nil
+			}
+		}
+		writeFakeJSON(w, http.StatusOK, member)
+	}
+}
+
+func (f *FakeGitLab) removeMember(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil {
+			return
+		}
+		userID := fakeInt(r.vars["user"])
+		index, member := f.findFakeObject(kind+"/members", func(member fakeObject) bool {
+			return fakeInt(member["source_id"]) == fakeInt(namespace["id"]) && fakeInt(member["id"]) == userID
+		})
+		if member == nil {
+			writeFakeError(w, http.StatusNotFound, "404 Member Not Found")
+			return
+		}
+		f.deleteFakeObject(kind+"/members", index)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// variables
+
+// variablesMatching returns the variables of the project or group with the given key,
+// filtered by the `filter[environment_scope]` parameter, which only exists for project variables.
+func (f *FakeGitLab) variablesMatching(kind string, namespace fakeObject, key string, r *fakeRequest) []fakeObject {
+	scope, filtered := r.params["filter[environment_scope]"]
+	return filterFakeObjects(f.collections[kind+"/variables"], func(variable fakeObject) bool {
+		return fakeInt(variable["source_id"]) == fakeInt(namespace["id"]) &&
+			variable["key"] == key &&
+			(!filtered || variable["environment_scope"] == scope)
+	})
+}
+
+// findVariable returns the single variable of the request or writes the error GitLab returns.
+func (f *FakeGitLab) findVariable(w http.ResponseWriter, r *fakeRequest, kind string) fakeObject {
+	namespace := f.findNamespace(w, r, kind)
+	if namespace == nil {
+		return nil
+	}
+	variables := f.variablesMatching(kind, namespace, r.vars["key"], r)
+	switch len(variables) {
+	case 0:
+		writeFakeError(w, http.StatusNotFound, "404 Variable Not Found")
+		return nil
+	case 1:
+		return variables[0]
+	default:
+		writeFakeError(w, http.StatusConflict, "There are multiple variables with provided parameters. Please use 'filter[environment_scope]'")
+		return nil
+	}
+}
+
+// validateVariable writes the error GitLab returns for an invalid variable.
+func validateVariable(w http.ResponseWriter, variable fakeObject) bool {
+	errors := fakeObject{}
+	if !fakeVariableKeyPattern.MatchString(fakeString(variable["key"])) {
+		errors["key"] = []string{"can contain only letters, digits and '_'."}
+	}
+	if fakeBool(variable["masked"]) && !fakeMaskedValuePattern.MatchString(fakeString(variable["value"])) {
+		errors["value"] = []string{"is invalid"}
+	}
+	if t := fakeString(variable["variable_type"]); t != "env_var" && t != "file" {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "variable_type does not have a valid value"})
+		return false
+	}
+	if len(errors) > 0 {
+		writeFakeError(w, http.StatusBadRequest, errors)
+		return false
+	}
+	return true
+}
+
+func (f *FakeGitLab) listVariables(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil {
+			return
+		}
+		variables := filterFakeObjects(f.collections[kind+"/variables"], func(variable fakeObject) bool {
+			return fakeInt(variable["source_id"]) == fakeInt(namespace["id"])
+		})
+		writeFakePage(w, r, variables, false)
+	}
+}
+
+func (f *FakeGitLab) createVariable(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil || !requireFakeParams(w, r, "key", "value") {
+			return
+		}
+		variable := fakeObject{
+			"source_id":         namespace["id"],
+			"variable_type":     "env_var",
+			"protected":         false,
+			"masked":            false,
+			"raw":               false,
+			"environment_scope": "*",
+		}
+		updateFakeObject(variable, r.params)
+		if !validateVariable(w, variable) {
+			return
+		}
+		for _, existing := range f.variablesMatching(kind, namespace, fakeString(variable["key"]), r) {
+			if existing["environment_scope"] == variable["environment_scope"] {
+				writeFakeError(w, http.StatusBadRequest, fakeObject{"key": []string{fmt.Sprintf("(%s) has already been taken", variable["key"])}})
+				return
+			}
+		}
+		f.collections[kind+"/variables"] = append(f.collections[kind+"/variables"], variable)
+		writeFakeJSON(w, http.StatusCreated, variable)
+	}
+}
+
+func (f *FakeGitLab) getVariable(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		if variable := f.findVariable(w, r, kind); variable != nil {
+			writeFakeJSON(w, http.StatusOK, variable)
+		}
+	}
+}
+
+func (f *FakeGitLab) updateVariable(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		variable := f.findVariable(w, r, kind)
+		if variable == nil {
+			return
+		}
+		updated := make(fakeObject, len(variable))
+		updateFakeObject(updated, variable)
+		updateFakeObject(updated, r.params, "key", "filter[environment_scope]")
+		if !validateVariable(w, updated) {
+			return
+		}
+		updateFakeObject(variable, updated)
+		writeFakeJSON(w, http.StatusOK, variable)
+	}
+}
+
+func (f *FakeGitLab) removeVariable(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		variable := f.findVariable(w, r, kind)
+		if variable == nil {
+			return
+		}
+		index, _ := f.findFakeObject(kind+"/variables", func(v fakeObject) bool {
+			return v["source_id"] == variable["source_id"] && v["key"] == variable["key"] && v["environment_scope"] == variable["environment_scope"]
+		})
+		f.deleteFakeObject(kind+"/variables", index)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// hooks
+
+func (f *FakeGitLab) findHook(w http.ResponseWriter, r *fakeRequest, kind string) (int, fakeObject) {
+	namespace := f.findNamespace(w, r, kind)
+	if namespace == nil {
+		return -1, nil
+	}
+	index, hook := f.findFakeObject(kind+"/hooks", func(hook fakeObject) bool {
+		return fakeInt(hook["source_id"]) == fakeInt(namespace["id"]) && fakeInt(hook["id"]) == fakeInt(r.vars["hook"])
+	})
+	if hook == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Not found")
+	}
+	return index, hook
+}
+
+func (f *FakeGitLab) listHooks(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil {
+			return
+		}
+		hooks := filterFakeObjects(f.collections[kind+"/hooks"], func(hook fakeObject) bool {
+			return fakeInt(hook["source_id"]) == fakeInt(namespace["id"])
+		})
+		writeFakePage(w, r, hooks, false)
+	}
+}
+
+func (f *FakeGitLab) addHook(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil || !requireFakeParams(w, r, "url") {
+			return
+		}
+		if u := fakeString(r.params["url"]); !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "Invalid url given"})
+			return
+		}
+		idField := "project_id"
+		if kind == "groups" {
+			idField = "group_id"
+		}
+		hook := fakeObject{
+			"id":                      f.newID(),
+			"source_id":               namespace["id"],
+			idField:                   namespace["id"],
+			"push_events":             true,
+			"enable_ssl_verification": true,
+			"created_at":              fakeTimestamp(),
+		}
+		updateFakeObject(hook, r.params, "token")
+		f.collections[kind+"/hooks"] = append(f.collections[kind+"/hooks"], hook)
+		writeFakeJSON(w, http.StatusCreated, hook)
+	}
+}
+
+func (f *FakeGitLab) getHook(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		if _, hook := f.findHook(w, r, kind); hook != nil {
+			writeFakeJSON(w, http.StatusOK, hook)
+		}
+	}
+}
+
+func (f *FakeGitLab) updateHook(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		_, hook := f.findHook(w, r, kind)
+		if hook == nil || !requireFakeParams(w, r, "url") {
+			return
+		}
+		// the token is never returned by GitLab
+		updateFakeObject(hook, r.params, "token", "source_id", "project_id", "group_id")
+		writeFakeJSON(w, http.StatusOK, hook)
+	}
+}
+
+func (f *FakeGitLab) deleteHook(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		index, hook := f.findHook(w, r, kind)
+		if hook == nil {
+			return
+		}
+		f.deleteFakeObject(kind+"/hooks", index)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// labels
+
+// findLabel returns the label by the ID or the name in the route, or by the `name` parameter.
+func (f *FakeGitLab) findLabel(w http.ResponseWriter, r *fakeRequest, kind string) (int, fakeObject) {
+	namespace := f.findNamespace(w, r, kind)
+	if namespace == nil {
+		return -1, nil
+	}
+	id, ok := r.vars["label"]
+	if !ok {
+		if r.params["label_id"] != nil {
+			id = fakeString(r.params["label_id"])
+		} else if !requireFakeParams(w, r, "name") {
+			return -1, nil
+		} else {
+			id = fakeString(r.params["name"])
+		}
+	}
+	index, label := f.findFakeObject(kind+"/labels", func(label fakeObject) bool {
+		return fakeInt(label["source_id"]) == fakeInt(namespace["id"]) && byIDOrPath(id, "name")(label)
+	})
+	if label == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Label Not Found")
+	}
+	return index, label
+}
+
+func validateLabelColor(w http.ResponseWriter, color string) bool {
+	if !fakeLabelColorPattern.MatchString(color) {
+		writeFakeError(w, http.StatusBadRequest, fakeObject{"color": []string{"must be a valid color code"}})
+		return false
+	}
+	return true
+}
+
+func (f *FakeGitLab) listLabels(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil {
+			return
+		}
+		labels := filterFakeObjects(f.collections[kind+"/labels"], func(label fakeObject) bool {
+			return fakeInt(label["source_id"]) == fakeInt(namespace["id"])
+		})
+		writeFakePage(w, r, labels, false)
+	}
+}
+
+func (f *FakeGitLab) createLabel(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		namespace := f.findNamespace(w, r, kind)
+		if namespace == nil || !requireFakeParams(w, r, "name", "color") || !validateLabelColor(w, fakeString(r.params["color"])) {
+			return
+		}
+		if _, existing := f.findFakeObject(kind+"/labels", func(label fakeObject) bool {
+			return fakeInt(label["source_id"]) == fakeInt(namespace["id"]) && label["name"] == r.params["name"]
+		}); existing != nil {
+			writeFakeError(w, http.StatusConflict, "Label already exists")
+			return
+		}
+		label := fakeObject{
+			"id":               f.newID(),
+			"source_id":        namespace["id"],
+			"description":      nil,
+			"text_color":       "#FFFFFF",
+			"priority":         nil,
+			"is_project_label": kind == "projects",
+		}
+		updateFakeObject(label, r.params)
+		f.collections[kind+"/labels"] = append(f.collections[kind+"/labels"], label)
+		writeFakeJSON(w, http.StatusCreated, label)
+	}
+}
+
+func (f *FakeGitLab) getLabel(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		if _, label := f.findLabel(w, r, kind); label != nil {
+			writeFakeJSON(w, http.StatusOK, label)
+		}
+	}
+}
+
+func (f *FakeGitLab) updateLabel(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		_, label := f.findLabel(w, r, kind)
+		if label == nil {
+			return
+		}
+		if r.params["new_name"] == nil && r.params["color"] == nil && r.params["description"] == nil && r.params["priority"] == nil {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "new_name, color, description, priority are missing, at least one parameter must be provided"})
+			return
+		}
+		if color, ok := r.params["color"]; ok && !validateLabelColor(w, fakeString(color)) {
+			return
+		}
+		updateFakeObject(label, r.params, "name", "new_name", "label_id")
+		if newName := fakeString(r.params["new_name"]); newName != "" {
+			label["name"] = newName
+		}
+		writeFakeJSON(w, http.StatusOK, label)
+	}
+}
+
+func (f *FakeGitLab) deleteLabel(kind string) func(w http.ResponseWriter, r *fakeRequest) {
+	return func(w http.ResponseWriter, r *fakeRequest) {
+		index, label := f.findLabel(w, r, kind)
+		if label == nil {
+			return
+		}
+		f.deleteFakeObject(kind+"/labels", index)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// branches
+
+// findRepository returns the project and its repository, or writes the error GitLab returns.
+func (f *FakeGitLab) findRepository(w http.ResponseWriter, r *fakeRequest) (fakeObject, *fakeRepository) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return nil, nil
+	}
+	return project, f.repositories[fakeInt(project["id"])]
+}
+
+// commit creates a commit on the branch, which is created from the start branch if it doesn't exist yet,
+// and applies the changes to the files of the branch.
+func (f *FakeGitLab) commit(projectID int, branchName string, startBranch string, message string, change func(files map[string]fakeObject)) fakeObject {
+	repository := f.repositories[projectID]
+	branch, ok := repository.branches[branchName]
+	if !ok {
+		branch = &fakeBranch{files: make(map[string]fakeObject)}
+		if start, ok := repository.branches[startBranch]; ok {
+			for path, file := range start.files {
+				branch.files[path] = file
+			}
+		}
+		repository.branches[branchName] = branch
+	}
+
+	f.commits++
+	hash := sha1.Sum([]byte(fmt.Sprintf("%d/%s/%d", projectID, branchName, f.commits)))
+	id := hex.EncodeToString(hash[:])
+	var parentIDs []string
+	if branch.commit != nil {
+		parentIDs = append(parentIDs, fakeString(branch.commit["id"]))
+	}
+	branch.commit = fakeObject{
+		"id":              id,
+		"short_id":        id[:8],
+		"title":           strings.SplitN(message, "\n", 2)[0],
+		"message":         message,
+		"parent_ids":      parentIDs,
+		"author_name":     "Administrator",
+		"author_email":    "admin@example.com",
+		"committer_name":  "Administrator",
+		"committer_email": "admin@example.com",
+		"created_at":      fakeTimestamp(),
+		"authored_date":   fakeTimestamp(),
+		"committed_date":  fakeTimestamp(),
+	}
+
+	// files are copied on write, to not modify the files of the branches created from this branch
+	files := make(map[string]fakeObject, len(branch.files))
+	for path, file := range branch.files {
+		files[path] = file
+	}
+	change(files)
+	for path, file := range files {
+		if branch.files[path] == nil || fakeString(branch.files[path]["blob_id"]) != fakeString(file["blob_id"]) || file["last_commit_id"] == nil {
+			file = copyFakeObject(file)
+			file["last_commit_id"] = id
+			file["commit_id"] = id
+			files[path] = file
+		}
+	}
+	branch.files = files
+	return branch.commit
+}
+
+func copyFakeObject(object fakeObject) fakeObject {
+	c := make(fakeObject, len(object))
+	for name, value := range object {
+		c[name] = value
+	}
+	return c
+}
+
+func (f *FakeGitLab) branch(project fakeObject, name string, branch *fakeBranch) fakeObject {
+	return fakeObject{
+		"name":      name,
+		"commit":    branch.commit,
+		"default":   project["default_branch"] == name,
+		"protected": f.protectedBranch(project, name) != nil,
+		"merged":    false,
+		"web_url":   fakeString(project["web_url"]) + "/-/tree/" + name,
+	}
+}
+
+func (f *FakeGitLab) listBranches(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	var branches []fakeObject
+	for name, branch := range repository.branches {
+		branches = append(branches, f.branch(project, name, branch))
+	}
+	r.params["order_by"] = "name"
+	writeFakePage(w, r, sortFakeObjects(r, branches, "asc"), false)
+}
+
+func (f *FakeGitLab) getBranch(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	branch, ok := repository.branches[r.vars["branch"]]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "404 Branch Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, f.branch(project, r.vars["branch"], branch))
+}
+
+func (f *FakeGitLab) createBranch(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil || !requireFakeParams(w, r, "branch", "ref") {
+		return
+	}
+	name, ref := fakeString(r.params["branch"]), fakeString(r.params["ref"])
+	if !fakeBranchNamePattern.MatchString(name) {
+		writeFakeError(w, http.StatusBadRequest, "Branch name is invalid")
+		return
+	}
+	if _, ok := repository.branches[name]; ok {
+		writeFakeError(w, http.StatusBadRequest, "Branch already exists")
+		return
+	}
+
+	var start *fakeBranch
+	for _, branch := range repository.branches {
+		if branch.commit != nil && (fakeString(branch.commit["id"]) == ref || fakeString(branch.commit["short_id"]) == ref) {
+			start = branch
+		}
+	}
+	if b, ok := repository.branches[ref]; ok {
+		start = b
+	}
+	if start == nil {
+		writeFakeError(w, http.StatusBadRequest, "Invalid reference name: "+ref)
+		return
+	}
+
+	branch := &fakeBranch{commit: start.commit, files: start.files}
+	repository.branches[name] = branch
+	writeFakeJSON(w, http.StatusCreated, f.branch(project, name, branch))
+}
+
+func (f *FakeGitLab) deleteBranch(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	name := r.vars["branch"]
+	if _, ok := repository.branches[name]; !ok {
+		writeFakeError(w, http.StatusNotFound, "404 Branch Not Found")
+		return
+	}
+	if project["default_branch"] == name {
+		writeFakeError(w, http.StatusBadRequest, "The default branch of a project cannot be deleted.")
+		return
+	}
+	if f.protectedBranch(project, name) != nil {
+		writeFakeError(w, http.StatusForbidden, "403 Forbidden")
+		return
+	}
+	delete(repository.branches, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// protected branches
+
+// initDefaultBranch sets the first branch of the repository of the project as its default branch,
+// which GitLab protects by default.
+func (f *FakeGitLab) initDefaultBranch(project fakeObject, name string) {
+	project["default_branch"] = name
+	protected := fakeObject{"id": f.newID(), "source_id": project["id"], "name": name, "allow_force_push": false, "code_owner_approval_required": false}
+	for _, action := range []string{"push", "merge", "unprotect"} {
+		protected[action+"_access_levels"] = []fakeObject{{"id": f.newID(), "access_level": 40, "access_level_description": fakeAccessLevelDescriptions[40]}}
+	}
+	f.collections["protected_branches"] = append(f.collections["protected_branches"], protected)
+}
+
+func (f *FakeGitLab) protectedBranch(project fakeObject, name string) fakeObject {
+	_, protected := f.findFakeObject("protected_branches", func(protected fakeObject) bool {
+		return fakeInt(protected["source_id"]) == fakeInt(project["id"]) && protected["name"] == name
+	})
+	return protected
+}
+
+// fakeAccessLevelDescriptions are the descriptions of the access levels of protected branches.
+var fakeAccessLevelDescriptions = map[int]string{0: "No one", 30: "Developers + Maintainers", 40: "Maintainers", 60: "Admins"}
+
+// accessLevels returns the access levels of a protected branch action, for the `*_access_level` parameter
+// and the `allowed_to_*` parameter, which are also used to update them with `_destroy`.
+func (f *FakeGitLab) accessLevels(existing interface{}, r *fakeRequest, action string, defaultLevel int) ([]fakeObject, bool) {
+	levels, _ := existing.([]fakeObject)
+	if level, ok := r.params[action+"_access_level"]; ok {
+		if !fakeAccessLevels[fakeInt(level)] {
+			return nil, false
+		}
+		levels = []fakeObject{{"id": f.newID(), "access_level": fakeInt(level), "access_level_description": fakeAccessLevelDescriptions[fakeInt(level)]}}
+	} else if existing == nil {
+		levels = []fakeObject{{"id": f.newID(), "access_level": defaultLevel, "access_level_description": fakeAccessLevelDescriptions[defaultLevel]}}
+	}
+
+	allowed, _ := r.params["allowed_to_"+action].([]interface{})
+	for _, a := range allowed {
+		options, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if fakeBool(options["_destroy"]) {
+			levels = filterFakeObjects(levels, func(level fakeObject) bool { return fakeInt(level["id"]) != fakeInt(options["id"]) })
+			continue
+		}
+		level := fakeObject{"id": f.newID(), "access_level": 40, "user_id": nil, "group_id": nil}
+		for name, value := range options {
+			level[name] = value
+		}
+		if options["user_id"] != nil || options["group_id"] != nil {
+			level["access_level"] = 40
+		}
+		level["access_level_description"] = fakeAccessLevelDescriptions[fakeInt(level["access_level"])]
+		levels = append(levels, level)
+	}
+	return levels, true
+}
+
+func (f *FakeGitLab) listProtectedBranches(w http.ResponseWriter, r *fakeRequest) {
+	project, _ := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	protected := filterFakeObjects(f.collections["protected_branches"], func(protected fakeObject) bool {
+		return fakeInt(protected["source_id"]) == fakeInt(project["id"])
+	})
+	writeFakePage(w, r, protected, false)
+}
+
+func (f *FakeGitLab) getProtectedBranch(w http.ResponseWriter, r *fakeRequest) {
+	project, _ := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	protected := f.protectedBranch(project, r.vars["branch"])
+	if protected == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, protected)
+}
+
+func (f *FakeGitLab) protectBranch(w http.ResponseWriter, r *fakeRequest) {
+	project, _ := f.findRepository(w, r)
+	if project == nil || !requireFakeParams(w, r, "name") {
+		return
+	}
+	name := fakeString(r.params["name"])
+	if f.protectedBranch(project, name) != nil {
+		writeFakeError(w, http.StatusConflict, fmt.Sprintf("Protected branch '%s' already exists", name))
+		return
+	}
+
+	protected := fakeObject{
+		"id":                           f.newID(),
+		"source_id":                    project["id"],
+		"name":                         name,
+		"allow_force_push":             fakeBool(r.params["allow_force_push"]),
+		"code_owner_approval_required": fakeBool(r.params["code_owner_approval_required"]),
+	}
+	for action, defaultLevel := range map[string]int{"push": 40, "merge": 40, "unprotect": 40} {
+		levels, ok := f.accessLevels(nil, r, action, defaultLevel)
+		if !ok {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": action + "_access_level does not have a valid value"})
+			return
+		}
+		protected[action+"_access_levels"] = levels
+	}
+	f.collections["protected_branches"] = append(f.collections["protected_branches"], protected)
+	writeFakeJSON(w, http.StatusCreated, protected)
+}
+
+func (f *FakeGitLab) updateProtectedBranch(w http.ResponseWriter, r *fakeRequest) {
+	project, _ := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	protected := f.protectedBranch(project, r.vars["branch"])
+	if protected == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	for _, option := range []string{"allow_force_push", "code_owner_approval_required"} {
+		if value, ok := r.params[option]; ok {
+			protected[option] = fakeBool(value)
+		}
+	}
+	for _, action := range []string{"push", "merge", "unprotect"} {
+		levels, ok := f.accessLevels(protected[action+"_access_levels"], r, action, 40)
+		if !ok {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": action + "_access_level does not have a valid value"})
+			return
+		}
+		protected[action+"_access_levels"] = levels
+	}
+	writeFakeJSON(w, http.StatusOK, protected)
+}
+
+func (f *FakeGitLab) unprotectBranch(w http.ResponseWriter, r *fakeRequest) {
+	project, _ := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	index, _ := f.findFakeObject("protected_branches", func(protected fakeObject) bool {
+		return fakeInt(protected["source_id"]) == fakeInt(project["id"]) && protected["name"] == r.vars["branch"]
+	})
+	if index < 0 {
+		writeFakeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	f.deleteFakeObject("protected_branches", index)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// repository files
+
+func (f *FakeGitLab) newFile(path string, content []byte) fakeObject {
+	blob := sha1.Sum(append([]byte(fmt.Sprintf("blob %d\x00", len(content))), content...))
+	sha := sha256.Sum256(content)
+	parts := strings.Split(path, "/")
+	return fakeObject{
+		"file_name":        parts[len(parts)-1],
+		"file_path":        path,
+		"size":             len(content),
+		"encoding":         "base64",
+		"content":          base64.StdEncoding.EncodeToString(content),
+		"content_sha256":   hex.EncodeToString(sha[:]),
+		"blob_id":          hex.EncodeToString(blob[:]),
+		"execute_filemode": false,
+	}
+}
+
+// fileContent returns the content of the `content` parameter in the given `encoding`.
+func fileContent(w http.ResponseWriter, params fakeObject) ([]byte, bool) {
+	content := fakeString(params["content"])
+	switch fakeString(params["encoding"]) {
+	case "", "text":
+		return []byte(content), true
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			writeFakeError(w, http.StatusBadRequest, "invalid base64")
+			return nil, false
+		}
+		return decoded, true
+	default:
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "encoding does not have a valid value"})
+		return nil, false
+	}
+}
+
+// findFile returns the file on the branch or commit of the `ref` parameter.
+func (f *FakeGitLab) findFile(w http.ResponseWriter, r *fakeRequest) (fakeObject, string) {
+	project, repository := f.findRepository(w, r)
+	if project == nil || !requireFakeParams(w, r, "ref") {
+		return nil, ""
+	}
+	ref := fakeString(r.params["ref"])
+	branch, ok := repository.branches[ref]
+	if !ok {
+		for _, b := range repository.branches {
+			if b.commit != nil && b.commit["id"] == ref {
+				branch = b
+			}
+		}
+	}
+	if branch == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Commit Not Found")
+		return nil, ""
+	}
+	file, ok := branch.files[r.vars["file"]]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "404 File Not Found")
+		return nil, ""
+	}
+	return file, ref
+}
+
+func (f *FakeGitLab) getFile(w http.ResponseWriter, r *fakeRequest) {
+	file, ref := f.findFile(w, r)
+	if file == nil {
+		return
+	}
+	file = copyFakeObject(file)
+	file["ref"] = ref
+	writeFakeJSON(w, http.StatusOK, file)
+}
+
+func (f *FakeGitLab) getFileMetadata(w http.ResponseWriter, r *fakeRequest) {
+	file, ref := f.findFile(w, r)
+	if file == nil {
+		return
+	}
+	for header, attribute := range map[string]string{
+		"X-Gitlab-Blob-Id":          "blob_id",
+		"X-Gitlab-Commit-Id":        "commit_id",
+		"X-Gitlab-Content-Sha256":   "content_sha256",
+		"X-Gitlab-Encoding":         "encoding",
+		"X-Gitlab-Execute-Filemode": "execute_filemode",
+		"X-Gitlab-File-Name":        "file_name",
+		"X-Gitlab-File-Path":        "file_path",
+		"X-Gitlab-Last-Commit-Id":   "last_commit_id",
+		"X-Gitlab-Size":             "size",
+	} {
+		w.Header().Set(header, fmt.Sprint(file[attribute]))
+	}
+	w.Header().Set("X-Gitlab-Ref", ref)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *FakeGitLab) getRawFile(w http.ResponseWriter, r *fakeRequest) {
+	file, _ := f.findFile(w, r)
+	if file == nil {
+		return
+	}
+	content, _ := base64.StdEncoding.DecodeString(fakeString(file["content"]))
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(content)
+}
+
+// changeFile validates the parameters of a change of a repository file and commits it.
+// It writes the error GitLab returns if the file exists or not, or if it has been changed
+// since the `last_commit_id` of the request.
+func (f *FakeGitLab) changeFile(w http.ResponseWriter, r *fakeRequest, statusCode int, exists bool, change func(files map[string]fakeObject, path string, content []byte)) {
+	project, repository := f.findRepository(w, r)
+	if project == nil || !requireFakeParams(w, r, "branch", "commit_message") {
+		return
+	}
+	path, branchName := r.vars["file"], fakeString(r.params["branch"])
+	var content []byte
+	if r.Method != http.MethodDelete {
+		if !requireFakeParams(w, r, "content") {
+			return
+		}
+		var ok bool
+		if content, ok = fileContent(w, r.params); !ok {
+			return
+		}
+	}
+
+	startBranch := fakeString(r.params["start_branch"])
+	if startBranch == "" {
+		startBranch = branchName
+	}
+	branch, ok := repository.branches[branchName]
+	if !ok {
+		if branch, ok = repository.branches[startBranch]; !ok && len(repository.branches) > 0 {
+			writeFakeError(w, http.StatusBadRequest, "You can only create or edit files when you are on a branch")
+			return
+		}
+	}
+
+	var file fakeObject
+	if branch != nil {
+		file = branch.files[path]
+	}
+	switch {
+	case exists && file == nil:
+		writeFakeError(w, http.StatusBadRequest, "A file with this name doesn't exist")
+		return
+	case !exists && file != nil:
+		writeFakeError(w, http.StatusBadRequest, "A file with this name already exists")
+		return
+	}
+	if lastCommitID := fakeString(r.params["last_commit_id"]); exists && lastCommitID != "" && lastCommitID != fakeString(file["last_commit_id"]) {
+		writeFakeError(w, http.StatusBadRequest, "You are attempting to update a file that has changed since you started editing it.")
+		return
+	}
+
+	f.commit(fakeInt(project["id"]), branchName, startBranch, fakeString(r.params["commit_message"]), func(files map[string]fakeObject) {
+		change(files, path, content)
+	})
+	if project["default_branch"] == nil {
+		f.initDefaultBranch(project, branchName)
+	}
+
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(statusCode)
+		return
+	}
+	writeFakeJSON(w, statusCode, fakeObject{"file_path": path, "branch": branchName})
+}
+
+func (f *FakeGitLab) createFile(w http.ResponseWriter, r *fakeRequest) {
+	f.changeFile(w, r, http.StatusCreated, false, func(files map[string]fakeObject, path string, content []byte) {
+		file := f.newFile(path, content)
+		file["execute_filemode"] = fakeBool(r.params["execute_filemode"])
+		files[path] = file
+	})
+}
+
+func (f *FakeGitLab) updateFile(w http.ResponseWriter, r *fakeRequest) {
+	f.changeFile(w, r, http.StatusOK, true, func(files map[string]fakeObject, path string, content []byte) {
+		file := f.newFile(path, content)
+		file["execute_filemode"] = files[path]["execute_filemode"]
+		if executeFilemode, ok := r.params["execute_filemode"]; ok {
+			file["execute_filemode"] = fakeBool(executeFilemode)
+		}
+		files[path] = file
+	})
+}
+
+func (f *FakeGitLab) deleteFile(w http.ResponseWriter, r *fakeRequest) {
+	f.changeFile(w, r, http.StatusNoContent, true, func(files map[string]fakeObject, path string, content []byte) {
+		delete(files, path)
+	})
+}
+
+// getCommit returns the commit of the given branch name or commit ID, like GitLab resolves refs.
+func (f *FakeGitLab) getCommit(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	ref := r.vars["sha"]
+	if branch, ok := repository.branches[ref]; ok && branch.commit != nil {
+		writeFakeJSON(w, http.StatusOK, branch.commit)
+		return
+	}
+	for _, branch := range repository.branches {
+		if branch.commit != nil && (fakeString(branch.commit["id"]) == ref || fakeString(branch.commit["short_id"]) == ref) {
+			writeFakeJSON(w, http.StatusOK, branch.commit)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "404 Commit Not Found")
+}
+
+// createCommit applies the `actions` to the files of the branch in a single commit. Like GitLab, it either applies
+// all actions or none, and fails if a file to create exists, a file to change doesn't exist or has been changed
+// since the `last_commit_id` of the action.
+func (f *FakeGitLab) createCommit(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil || !requireFakeParams(w, r, "branch", "commit_message", "actions") {
+		return
+	}
+	branchName := fakeString(r.params["branch"])
+	startBranch := fakeString(r.params["start_branch"])
+	if startBranch == "" {
+		startBranch = branchName
+	}
+	files := make(map[string]fakeObject)
+	if branch, ok := repository.branches[branchName]; ok {
+		files = branch.files
+	} else if start, ok := repository.branches[startBranch]; ok {
+		files = start.files
+	} else if len(repository.branches) > 0 {
+		writeFakeError(w, http.StatusBadRequest, "You can only create or edit files when you are on a branch")
+		return
+	}
+
+	rawActions, _ := r.params["actions"].([]interface{})
+	if len(rawActions) == 0 {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "actions is empty"})
+		return
+	}
+	type fakeAction struct {
+		action, path, previousPath string
+		content                    []byte
+		params                     fakeObject
+	}
+	var actions []fakeAction
+	for _, rawAction := range rawActions {
+		params, _ := rawAction.(map[string]interface{})
+		action := fakeAction{
+			action:       fakeString(params["action"]),
+			path:         fakeString(params["file_path"]),
+			previousPath: fakeString(params["previous_path"]),
+			params:       params,
+		}
+		if action.action == "move" && action.previousPath == "" {
+			writeFakeError(w, http.StatusBadRequest, "You must specify the previous path of the file to move")
+			return
+		}
+		existingPath := action.path
+		if action.action == "move" {
+			existingPath = action.previousPath
+		}
+		file := files[existingPath]
+		switch action.action {
+		case "create":
+			if file != nil {
+				writeFakeError(w, http.StatusBadRequest, "A file with this name already exists")
+				return
+			}
+		case "update", "delete", "move", "chmod":
+			if file == nil {
+				writeFakeError(w, http.StatusBadRequest, "A file with this name doesn't exist")
+				return
+			}
+			if lastCommitID := fakeString(params["last_commit_id"]); lastCommitID != "" && lastCommitID != fakeString(file["last_commit_id"]) {
+				writeFakeError(w, http.StatusBadRequest, "You are attempting to update a file that has changed since you started editing it.")
+				return
+			}
+		default:
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "actions[action] does not have a valid value"})
+			return
+		}
+		if _, ok := params["content"]; ok {
+			content, ok := fileContent(w, params)
+			if !ok {
+				return
+			}
+			action.content = content
+		}
+		actions = append(actions, action)
+	}
+
+	commit := f.commit(fakeInt(project["id"]), branchName, startBranch, fakeString(r.params["commit_message"]), func(files map[string]fakeObject) {
+		for _, action := range actions {
+			switch action.action {
+			case "create":
+				file := f.newFile(action.path, action.content)
+				file["execute_filemode"] = fakeBool(action.params["execute_filemode"])
+				files[action.path] = file
+			case "update":
+				file := f.newFile(action.path, action.content)
+				file["execute_filemode"] = files[action.path]["execute_filemode"]
+				if executeFilemode, ok := action.params["execute_filemode"]; ok {
+					file["execute_filemode"] = fakeBool(executeFilemode)
+				}
+				files[action.path] = file
+			case "move":
+				previous := files[action.previousPath]
+				content, _ := base64.StdEncoding.DecodeString(fakeString(previous["content"]))
+				if action.content != nil {
+					content = action.content
+				}
+				file := f.newFile(action.path, content)
+				file["execute_filemode"] = previous["execute_filemode"]
+				delete(files, action.previousPath)
+				files[action.path] = file
+			case "chmod":
+				file := copyFakeObject(files[action.path])
+				file["execute_filemode"] = fakeBool(action.params["execute_filemode"])
+				// the mode isn't part of the blob, so the file needs to be committed explicitly
+				delete(file, "last_commit_id")
+				files[action.path] = file
+			case "delete":
+				delete(files, action.path)
+			}
+		}
+	})
+	if project["default_branch"] == nil {
+		f.initDefaultBranch(project, branchName)
+	}
+	writeFakeJSON(w, http.StatusCreated, commit)
+}
+
+// merge requests
+
+// fakeLabels returns the labels of a parameter, which is either a comma separated string or a list.
+func fakeLabels(v interface{}) []string {
+	labels := []string{}
+	switch v := v.(type) {
+	case string:
+		for _, label := range strings.Split(v, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+	case []interface{}:
+		for _, label := range v {
+			labels = append(labels, fakeString(label))
+		}
+	}
+	return labels
+}
+
+// fakeBasicUsers returns the users with the IDs of a list parameter like GitLab embeds them into other objects.
+func (f *FakeGitLab) fakeBasicUsers(w http.ResponseWriter, value interface{}) ([]fakeObject, bool) {
+	users := []fakeObject{}
+	ids, _ := value.([]interface{})
+	if s, ok := value.(string); ok && s != "" {
+		for _, id := range strings.Split(s, ",") {
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range ids {
+		_, user := f.findUser(w, fakeString(id))
+		if user == nil {
+			return nil, false
+		}
+		users = append(users, fakeObject{"id": user["id"], "username": user["username"], "name": user["name"], "state": user["state"]})
+	}
+	return users, true
+}
+
+// updateMergeRequestParams updates the attributes of the merge request which can be set on create and update.
+func (f *FakeGitLab) updateMergeRequestParams(w http.ResponseWriter, mergeRequest fakeObject, params fakeObject) bool {
+	for _, name := range []string{"title", "description", "target_branch", "squash"} {
+		if value, ok := params[name]; ok {
+			mergeRequest[name] = value
+		}
+	}
+	if value, ok := params["remove_source_branch"]; ok {
+		mergeRequest["force_remove_source_branch"] = fakeBool(value)
+	}
+	if value, ok := params["labels"]; ok {
+		mergeRequest["labels"] = fakeLabels(value)
+	}
+	if value, ok := params["assignee_ids"]; ok {
+		assignees, ok := f.fakeBasicUsers(w, value)
+		if !ok {
+			return false
+		}
+		mergeRequest["assignees"] = assignees
+		mergeRequest["assignee"] = nil
+		if len(assignees) > 0 {
+			mergeRequest["assignee"] = assignees[0]
+		}
+	}
+	if value, ok := params["reviewer_ids"]; ok {
+		reviewers, ok := f.fakeBasicUsers(w, value)
+		if !ok {
+			return false
+		}
+		mergeRequest["reviewers"] = reviewers
+	}
+	if title := fakeString(mergeRequest["title"]); strings.HasPrefix(title, "Draft:") || strings.HasPrefix(title, "WIP:") {
+		mergeRequest["draft"] = true
+	} else {
+		mergeRequest["draft"] = false
+	}
+	mergeRequest["updated_at"] = fakeTimestamp()
+	return true
+}
+
+func (f *FakeGitLab) findMergeRequest(w http.ResponseWriter, r *fakeRequest) (fakeObject, fakeObject) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return nil, nil
+	}
+	_, mergeRequest := f.findFakeObject("merge_requests", func(mergeRequest fakeObject) bool {
+		return fakeInt(mergeRequest["project_id"]) == fakeInt(project["id"]) && fakeString(mergeRequest["iid"]) == r.vars["merge_request"]
+	})
+	if mergeRequest == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Not found")
+		return project, nil
+	}
+	// the merge request follows the commits to its source branch
+	if branch, ok := f.repositories[fakeInt(project["id"])].branches[fakeString(mergeRequest["source_branch"])]; ok && mergeRequest["state"] == "opened" {
+		mergeRequest["sha"] = branch.commit["id"]
+	}
+	return project, mergeRequest
+}
+
+func (f *FakeGitLab) listMergeRequests(w http.ResponseWriter, r *fakeRequest) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return
+	}
+	mergeRequests := filterFakeObjects(f.collections["merge_requests"], func(mergeRequest fakeObject) bool {
+		if fakeInt(mergeRequest["project_id"]) != fakeInt(project["id"]) {
+			return false
+		}
+		for _, name := range []string{"source_branch", "target_branch"} {
+			if value, ok := r.params[name]; ok && value != mergeRequest[name] {
+				return false
+			}
+		}
+		author := mergeRequest["author"].(fakeObject)
+		if value, ok := r.params["author_id"]; ok && fakeInt(value) != fakeInt(author["id"]) {
+			return false
+		}
+		if value, ok := r.params["author_username"]; ok && value != author["username"] {
+			return false
+		}
+		if state := fakeString(r.params["state"]); state != "" && state != "all" && state != mergeRequest["state"] {
+			return false
+		}
+		for _, label := range fakeLabels(r.params["labels"]) {
+			found := false
+			for _, l := range mergeRequest["labels"].([]string) {
+				found = found || l == label
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	})
+	// like GitLab, the newest merge requests are listed first
+	sorted := make([]fakeObject, len(mergeRequests))
+	for i, mergeRequest := range mergeRequests {
+		sorted[len(mergeRequests)-1-i] = mergeRequest
+	}
+	writeFakePage(w, r, sorted, false)
+}
+
+func (f *FakeGitLab) createMergeRequest(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil || !requireFakeParams(w, r, "source_branch", "target_branch", "title") {
+		return
+	}
+	sourceBranch, targetBranch := fakeString(r.params["source_branch"]), fakeString(r.params["target_branch"])
+	for _, name := range []string{sourceBranch, targetBranch} {
+		if _, ok := repository.branches[name]; !ok {
+			writeFakeError(w, http.StatusUnprocessableEntity, fakeObject{"base": []string{fmt.Sprintf("Branch %s does not exist", name)}})
+			return
+		}
+	}
+	if sourceBranch == targetBranch {
+		writeFakeError(w, http.StatusUnprocessableEntity, fakeObject{"base": []string{"You can't use same project/branch for source and target"}})
+		return
+	}
+	if _, existing := f.findFakeObject("merge_requests", func(mergeRequest fakeObject) bool {
+		return fakeInt(mergeRequest["project_id"]) == fakeInt(project["id"]) && mergeRequest["state"] == "opened" &&
+			mergeRequest["source_branch"] == sourceBranch && mergeRequest["target_branch"] == targetBranch
+	}); existing != nil {
+		writeFakeError(w, http.StatusConflict, []string{fmt.Sprintf("Another open merge request already exists for this source branch: !%d", fakeInt(existing["iid"]))})
+		return
+	}
+
+	iid := 1
+	for _, mergeRequest := range f.collections["merge_requests"] {
+		if fakeInt(mergeRequest["project_id"]) == fakeInt(project["id"]) && fakeInt(mergeRequest["iid"]) >= iid {
+			iid = fakeInt(mergeRequest["iid"]) + 1
+		}
+	}
+	_, author := f.findFakeObject("users", byIDOrPath(strconv.Itoa(fakeRootUserID), "username"))
+	mergeRequest := fakeObject{
+		"id":                           f.newID(),
+		"iid":                          iid,
+		"project_id":                   project["id"],
+		"source_project_id":            project["id"],
+		"target_project_id":            project["id"],
+		"description":                  "",
+		"state":                        "opened",
+		"source_branch":                sourceBranch,
+		"target_branch":                targetBranch,
+		"labels":                       []string{},
+		"assignees":                    []fakeObject{},
+		"assignee":                     nil,
+		"reviewers":                    []fakeObject{},
+		"author":                       fakeObject{"id": author["id"], "username": author["username"], "name": author["name"], "state": author["state"]},
+		"merge_status":                 "can_be_merged",
+		"detailed_merge_status":        "mergeable",
+		"merge_when_pipeline_succeeds": false,
+		"should_remove_source_branch":  nil,
+		"force_remove_source_branch":   false,
+		"squash":                       false,
+		"sha":                          repository.branches[sourceBranch].commit["id"],
+		"merge_commit_sha":             nil,
+		"web_url":                      fmt.Sprintf("%s/-/merge_requests/%d", fakeString(project["web_url"]), iid),
+		"references":                   fakeObject{"short": fmt.Sprintf("!%d", iid), "relative": fmt.Sprintf("!%d", iid), "full": fmt.Sprintf("%s!%d", fakeString(project["path_with_namespace"]), iid)},
+		"created_at":                   fakeTimestamp(),
+		"merged_at":                    nil,
+		"merged_by":                    nil,
+		"closed_at":                    nil,
+	}
+	if !f.updateMergeRequestParams(w, mergeRequest, r.params) {
+		return
+	}
+	f.collections["merge_requests"] = append(f.collections["merge_requests"], mergeRequest)
+	writeFakeJSON(w, http.StatusCreated, mergeRequest)
+}
+
+func (f *FakeGitLab) getMergeRequest(w http.ResponseWriter, r *fakeRequest) {
+	if _, mergeRequest := f.findMergeRequest(w, r); mergeRequest != nil {
+		writeFakeJSON(w, http.StatusOK, mergeRequest)
+	}
+}
+
+func (f *FakeGitLab) updateMergeRequest(w http.ResponseWriter, r *fakeRequest) {
+	_, mergeRequest := f.findMergeRequest(w, r)
+	if mergeRequest == nil || !f.updateMergeRequestParams(w, mergeRequest, r.params) {
+		return
+	}
+	switch fakeString(r.params["state_event"]) {
+	case "close":
+		if mergeRequest["state"] == "opened" {
+			mergeRequest["state"] = "closed"
+			mergeRequest["closed_at"] = fakeTimestamp()
+		}
+	case "reopen":
+		if mergeRequest["state"] == "closed" {
+			mergeRequest["state"] = "opened"
+			mergeRequest["closed_at"] = nil
+		}
+	}
+	writeFakeJSON(w, http.StatusOK, mergeRequest)
+}
+
+func (f *FakeGitLab) deleteMergeRequest(w http.ResponseWriter, r *fakeRequest) {
+	_, mergeRequest := f.findMergeRequest(w, r)
+	if mergeRequest == nil {
+		return
+	}
+	index, _ := f.findFakeObject("merge_requests", func(m fakeObject) bool { return fakeInt(m["id"]) == fakeInt(mergeRequest["id"]) })
+	f.deleteFakeObject("merge_requests", index)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// acceptMergeRequest merges the source branch into the target branch, with the files of the source branch
+// like a fast-forward merge. With `merge_when_pipeline_succeeds`, the merge request stays open, as if it waits
+// for the pipeline.
+func (f *FakeGitLab) acceptMergeRequest(w http.ResponseWriter, r *fakeRequest) {
+	project, mergeRequest := f.findMergeRequest(w, r)
+	if mergeRequest == nil {
+		return
+	}
+	if mergeRequest["state"] != "opened" {
+		writeFakeError(w, http.StatusMethodNotAllowed, "405 Method Not Allowed")
+		return
+	}
+	if value, ok := r.params["should_remove_source_branch"]; ok {
+		mergeRequest["should_remove_source_branch"] = fakeBool(value)
+	}
+	if fakeBool(r.params["merge_when_pipeline_succeeds"]) {
+		mergeRequest["merge_when_pipeline_succeeds"] = true
+		writeFakeJSON(w, http.StatusOK, mergeRequest)
+		return
+	}
+
+	repository := f.repositories[fakeInt(project["id"])]
+	source := repository.branches[fakeString(mergeRequest["source_branch"])]
+	if source == nil {
+		writeFakeError(w, http.StatusMethodNotAllowed, "Branch cannot be merged")
+		return
+	}
+	targetBranch := fakeString(mergeRequest["target_branch"])
+	commit := f.commit(fakeInt(project["id"]), targetBranch, targetBranch, fmt.Sprintf("Merge branch '%s' into '%s'", mergeRequest["source_branch"], targetBranch), func(files map[string]fakeObject) {
+		for path := range files {
+			if _, ok := source.files[path]; !ok {
+				delete(files, path)
+			}
+		}
+		for path, file := range source.files {
+			files[path] = file
+		}
+	})
+	if fakeBool(mergeRequest["force_remove_source_branch"]) || fakeBool(mergeRequest["should_remove_source_branch"]) {
+		delete(repository.branches, fakeString(mergeRequest["source_branch"]))
+	}
+	mergeRequest["state"] = "merged"
+	mergeRequest["merged_at"] = fakeTimestamp()
+	mergeRequest["merged_by"] = mergeRequest["author"]
+	mergeRequest["merge_commit_sha"] = commit["id"]
+	writeFakeJSON(w, http.StatusOK, mergeRequest)
+}
+
+func (f *FakeGitLab) cancelMergeWhenPipelineSucceeds(w http.ResponseWriter, r *fakeRequest) {
+	_, mergeRequest := f.findMergeRequest(w, r)
+	if mergeRequest == nil {
+		return
+	}
+	if mergeRequest["state"] != "opened" || !fakeBool(mergeRequest["merge_when_pipeline_succeeds"]) {
+		writeFakeError(w, http.StatusNotAcceptable, "406 Not Acceptable")
+		return
+	}
+	mergeRequest["merge_when_pipeline_succeeds"] = false
+	writeFakeJSON(w, http.StatusCreated, mergeRequest)
+}
+
+// milestones
+
+func (f *FakeGitLab) listMilestones(w http.ResponseWriter, r *fakeRequest) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return
+	}
+	milestones := filterFakeObjects(f.collections["milestones"], func(milestone fakeObject) bool {
+		if fakeInt(milestone["project_id"]) != fakeInt(project["id"]) {
+			return false
+		}
+		title, ok := r.params["title"]
+		return !ok || title == milestone["title"]
+	})
+	writeFakePage(w, r, sortFakeObjects(r, milestones, "asc"), false)
+}
+
+func (f *FakeGitLab) createMilestone(w http.ResponseWriter, r *fakeRequest) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil || !requireFakeParams(w, r, "title") {
+		return
+	}
+	if _, existing := f.findFakeObject("milestones", func(milestone fakeObject) bool {
+		return fakeInt(milestone["project_id"]) == fakeInt(project["id"]) && milestone["title"] == r.params["title"]
+	}); existing != nil {
+		writeFakeError(w, http.StatusBadRequest, fakeObject{"title": []string{"already being used for another group or project milestone."}})
+		return
+	}
+
+	iid := 1
+	for _, milestone := range f.collections["milestones"] {
+		if fakeInt(milestone["project_id"]) == fakeInt(project["id"]) && fakeInt(milestone["iid"]) >= iid {
+			iid = fakeInt(milestone["iid"]) + 1
+		}
+	}
+	milestone := fakeObject{
+		"id":          f.newID(),
+		"iid":         iid,
+		"project_id":  project["id"],
+		"title":       r.params["title"],
+		"description": fakeString(r.params["description"]),
+		"state":       "active",
+		"expired":     false,
+		"created_at":  fakeTimestamp(),
+		"updated_at":  fakeTimestamp(),
+		"web_url":     fmt.Sprintf("%s/-/milestones/%d", fakeString(project["web_url"]), iid),
+	}
+	f.collections["milestones"] = append(f.collections["milestones"], milestone)
+	writeFakeJSON(w, http.StatusCreated, milestone)
+}
+
+// tags
+
+// fakeRef returns the commit a branch, tag or commit SHA refers to, or nil.
+func fakeRef(repository *fakeRepository, ref string) fakeObject {
+	if branch, ok := repository.branches[ref]; ok {
+		return branch.commit
+	}
+	if commit, ok := repository.tags[ref]; ok {
+		return commit
+	}
+	for _, branch := range repository.branches {
+		if branch.commit != nil && (fakeString(branch.commit["id"]) == ref || fakeString(branch.commit["short_id"]) == ref) {
+			return branch.commit
+		}
+	}
+	return nil
+}
+
+func (f *FakeGitLab) getTag(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil {
+		return
+	}
+	commit, ok := repository.tags[r.vars["tag"]]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "404 Tag Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, fakeObject{
+		"name":      r.vars["tag"],
+		"message":   "",
+		"target":    commit["id"],
+		"commit":    commit,
+		"protected": false,
+	})
+}
+
+// releases
+
+func (f *FakeGitLab) findRelease(w http.ResponseWriter, r *fakeRequest) (fakeObject, fakeObject) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return nil, nil
+	}
+	_, release := f.findFakeObject("releases", func(release fakeObject) bool {
+		return fakeInt(release["project_id"]) == fakeInt(project["id"]) && release["tag_name"] == r.vars["tag"]
+	})
+	if release == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Not found")
+		return project, nil
+	}
+	return project, release
+}
+
+// fakeRelease returns the release like GitLab, with its asset links.
+func (f *FakeGitLab) fakeRelease(project fakeObject, release fakeObject) fakeObject {
+	links := []fakeObject{}
+	for _, link := range f.collections["release_links"] {
+		if fakeInt(link["release_id"]) == fakeInt(release["id"]) {
+			links = append(links, link)
+		}
+	}
+	archiveURL := fmt.Sprintf("%s/-/archive/%s/%s-%s", fakeString(project["web_url"]), release["tag_name"], project["path"], release["tag_name"])
+	result := copyFakeObject(release)
+	result["upcoming_release"] = fakeString(release["released_at"]) > fakeTimestamp()
+	result["assets"] = fakeObject{
+		"count": len(links) + 2,
+		"sources": []fakeObject{
+			{"format": "zip", "url": archiveURL + ".zip"},
+			{"format": "tar.gz", "url": archiveURL + ".tar.gz"},
+		},
+		"links": links,
+	}
+	return result
+}
+
+// releaseMilestones returns the milestones of the project with the titles of a list parameter.
+func (f *FakeGitLab) releaseMilestones(w http.ResponseWriter, project fakeObject, value interface{}) ([]fakeObject, bool) {
+	milestones := []fakeObject{}
+	titles, _ := value.([]interface{})
+	for _, title := range titles {
+		_, milestone := f.findFakeObject("milestones", func(milestone fakeObject) bool {
+			return fakeInt(milestone["project_id"]) == fakeInt(project["id"]) && milestone["title"] == title
+		})
+		if milestone == nil {
+			writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Milestone(s) not found: %s", title))
+			return nil, false
+		}
+		milestones = append(milestones, milestone)
+	}
+	return milestones, true
+}
+
+// fakeReleasedAt returns the `released_at` parameter normalized to UTC like GitLab.
+func fakeReleasedAt(w http.ResponseWriter, value interface{}) (string, bool) {
+	releasedAt, err := time.Parse(time.RFC3339, fakeString(value))
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, "released_at is invalid")
+		return "", false
+	}
+	return releasedAt.UTC().Format(time.RFC3339), true
+}
+
+func (f *FakeGitLab) listReleases(w http.ResponseWriter, r *fakeRequest) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return
+	}
+	var releases []fakeObject
+	for _, release := range f.collections["releases"] {
+		if fakeInt(release["project_id"]) == fakeInt(project["id"]) {
+			releases = append(releases, f.fakeRelease(project, release))
+		}
+	}
+	if _, ok := r.params["order_by"]; !ok {
+		r.params["order_by"] = "released_at"
+	}
+	writeFakePage(w, r, sortFakeObjects(r, releases, "desc"), false)
+}
+
+func (f *FakeGitLab) createRelease(w http.ResponseWriter, r *fakeRequest) {
+	project, repository := f.findRepository(w, r)
+	if project == nil || !requireFakeParams(w, r, "tag_name") {
+		return
+	}
+	tagName := fakeString(r.params["tag_name"])
+	if _, existing := f.findFakeObject("releases", func(release fakeObject) bool {
+		return fakeInt(release["project_id"]) == fakeInt(project["id"]) && release["tag_name"] == tagName
+	}); existing != nil {
+		writeFakeError(w, http.StatusConflict, "Release already exists")
+		return
+	}
+	commit, ok := repository.tags[tagName]
+	if !ok {
+		ref := fakeString(r.params["ref"])
+		if ref == "" {
+			writeFakeError(w, http.StatusUnprocessableEntity, "Ref is not specified")
+			return
+		}
+		if commit = fakeRef(repository, ref); commit == nil {
+			writeFakeError(w, http.StatusUnprocessableEntity, "Ref is invalid")
+			return
+		}
+	}
+	milestones, ok := f.releaseMilestones(w, project, r.params["milestones"])
+	if !ok {
+		return
+	}
+	releasedAt := fakeTimestamp()
+	if value, ok := r.params["released_at"]; ok {
+		if releasedAt, ok = fakeReleasedAt(w, value); !ok {
+			return
+		}
+	}
+	var links []fakeObject
+	if assets, ok := r.params["assets"].(map[string]interface{}); ok {
+		values, _ := assets["links"].([]interface{})
+		for _, value := range values {
+			params, _ := value.(map[string]interface{})
+			link, ok := f.newReleaseLink(w, project, tagName, links, fakeObject(params))
+			if !ok {
+				return
+			}
+			links = append(links, link)
+		}
+	}
+
+	repository.tags[tagName] = commit
+	name := fakeString(r.params["name"])
+	if name == "" {
+		name = tagName
+	}
+	_, author := f.findFakeObject("users", byIDOrPath(strconv.Itoa(fakeRootUserID), "username"))
+	id := f.newID()
+	evidence := sha256.Sum256([]byte(fmt.Sprintf("%d/%s", id, tagName)))
+	release := fakeObject{
+		"id":          id,
+		"project_id":  project["id"],
+		"tag_name":    tagName,
+		"name":        name,
+		"description": fakeString(r.params["description"]),
+		"created_at":  fakeTimestamp(),
+		"released_at": releasedAt,
+		"author":      fakeObject{"id": author["id"], "username": author["username"], "name": author["name"], "state": author["state"]},
+		"commit":      commit,
+		"milestones":  milestones,
+		"commit_path": fmt.Sprintf("/%s/-/commit/%s", project["path_with_namespace"], commit["id"]),
+		"tag_path":    fmt.Sprintf("/%s/-/tags/%s", project["path_with_namespace"], tagName),
+		"evidences": []fakeObject{{
+			"sha":          hex.EncodeToString(evidence[:]),
+			"filepath":     fmt.Sprintf("%s/-/releases/%s/evidences/%d.json", fakeString(project["web_url"]), tagName, id),
+			"collected_at": fakeTimestamp(),
+		}},
+	}
+	for _, link := range links {
+		link["release_id"] = id
+	}
+	f.collections["releases"] = append(f.collections["releases"], release)
+	f.collections["release_links"] = append(f.collections["release_links"], links...)
+	writeFakeJSON(w, http.StatusCreated, f.fakeRelease(project, release))
+}
+
+func (f *FakeGitLab) getRelease(w http.ResponseWriter, r *fakeRequest) {
+	if project, release := f.findRelease(w, r); release != nil {
+		writeFakeJSON(w, http.StatusOK, f.fakeRelease(project, release))
+	}
+}
+
+func (f *FakeGitLab) updateRelease(w http.ResponseWriter, r *fakeRequest) {
+	project, release := f.findRelease(w, r)
+	if release == nil {
+		return
+	}
+	if value, ok := r.params["milestones"]; ok {
+		milestones, ok := f.releaseMilestones(w, project, value)
+		if !ok {
+			return
+		}
+		release["milestones"] = milestones
+	}
+	if value, ok := r.params["released_at"]; ok {
+		releasedAt, ok := fakeReleasedAt(w, value)
+		if !ok {
+			return
+		}
+		release["released_at"] = releasedAt
+	}
+	for _, name := range []string{"name", "description"} {
+		if value, ok := r.params[name]; ok && value != nil {
+			release[name] = value
+		}
+	}
+	writeFakeJSON(w, http.StatusOK, f.fakeRelease(project, release))
+}
+
+func (f *FakeGitLab) deleteRelease(w http.ResponseWriter, r *fakeRequest) {
+	project, release := f.findRelease(w, r)
+	if release == nil {
+		return
+	}
+	result := f.fakeRelease(project, release)
+	index, _ := f.findFakeObject("releases", func(r fakeObject) bool { return fakeInt(r["id"]) == fakeInt(release["id"]) })
+	f.deleteFakeObject("releases", index)
+	f.collections["release_links"] = filterFakeObjects(f.collections["release_links"], func(link fakeObject) bool {
+		return fakeInt(link["release_id"]) != fakeInt(release["id"])
+	})
+	writeFakeJSON(w, http.StatusOK, result)
+}
+
+// release links
+
+// newReleaseLink returns a new link of the release, which must have a unique name and URL among the links of the release.
+func (f *FakeGitLab) newReleaseLink(w http.ResponseWriter, project fakeObject, tagName string, links []fakeObject, params fakeObject) (fakeObject, bool) {
+	link := fakeObject{"id": f.newID(), "link_type": "other", "external": true}
+	if !f.updateReleaseLinkParams(w, project, tagName, links, link, params) {
+		return nil, false
+	}
+	return link, true
+}
+
+// updateReleaseLinkParams updates the attributes of the link, which can be set on create and update.
+func (f *FakeGitLab) updateReleaseLinkParams(w http.ResponseWriter, project fakeObject, tagName string, links []fakeObject, link fakeObject, params fakeObject) bool {
+	for _, name := range []string{"name", "url", "link_type"} {
+		if value, ok := params[name]; ok {
+			link[name] = value
+		}
+	}
+	if fakeString(link["name"]) == "" || fakeString(link["url"]) == "" {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "name is missing, url is missing"})
+		return false
+	}
+	for _, other := range links {
+		if fakeInt(other["id"]) == fakeInt(link["id"]) {
+			continue
+		}
+		for _, name := range []string{"name", "url"} {
+			if other[name] == link[name] {
+				writeFakeError(w, http.StatusBadRequest, fakeObject{name: []string{"has already been taken"}})
+				return false
+			}
+		}
+	}
+	link["direct_asset_url"] = link["url"]
+	if filepath, ok := params["filepath"]; ok {
+		link["filepath"] = filepath
+	}
+	if filepath := fakeString(link["filepath"]); filepath != "" {
+		link["direct_asset_url"] = fmt.Sprintf("%s/-/releases/%s/downloads%s", fakeString(project["web_url"]), tagName, filepath)
+	}
+	return true
+}
+
+func (f *FakeGitLab) releaseLinks(release fakeObject) []fakeObject {
+	return filterFakeObjects(f.collections["release_links"], func(link fakeObject) bool {
+		return fakeInt(link["release_id"]) == fakeInt(release["id"])
+	})
+}
+
+func (f *FakeGitLab) findReleaseLink(w http.ResponseWriter, r *fakeRequest) (fakeObject, fakeObject, fakeObject) {
+	project, release := f.findRelease(w, r)
+	if release == nil {
+		return nil, nil, nil
+	}
+	for _, link := range f.releaseLinks(release) {
+		if fakeString(link["id"]) == r.vars["link"] {
+			return project, release, link
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "404 Not found")
+	return project, release, nil
+}
+
+func (f *FakeGitLab) listReleaseLinks(w http.ResponseWriter, r *fakeRequest) {
+	_, release := f.findRelease(w, r)
+	if release == nil {
+		return
+	}
+	writeFakePage(w, r, f.releaseLinks(release), false)
+}
+
+func (f *FakeGitLab) createReleaseLink(w http.ResponseWriter, r *fakeRequest) {
+	project, release := f.findRelease(w, r)
+	if release == nil || !requireFakeParams(w, r, "name", "url") {
+		return
+	}
+	link, ok := f.newReleaseLink(w, project, r.vars["tag"], f.releaseLinks(release), r.params)
+	if !ok {
+		return
+	}
+	link["release_id"] = release["id"]
+	f.collections["release_links"] = append(f.collections["release_links"], link)
+	writeFakeJSON(w, http.StatusCreated, link)
+}
+
+func (f *FakeGitLab) getReleaseLink(w http.ResponseWriter, r *fakeRequest) {
+	if _, _, link := f.findReleaseLink(w, r); link != nil {
+		writeFakeJSON(w, http.StatusOK, link)
+	}
+}
+
+func (f *FakeGitLab) updateReleaseLink(w http.ResponseWriter, r *fakeRequest) {
+	project, release, link := f.findReleaseLink(w, r)
+	if link == nil {
+		return
+	}
+	// validate a copy, to not change the link on errors
+	updated := copyFakeObject(link)
+	if !f.updateReleaseLinkParams(w, project, r.vars["tag"], f.releaseLinks(release), updated, r.params) {
+		return
+	}
+	for name, value := range updated {
+		link[name] = value
+	}
+	writeFakeJSON(w, http.StatusOK, link)
+}
+
+func (f *FakeGitLab) deleteReleaseLink(w http.ResponseWriter, r *fakeRequest) {
+	_, _, link := f.findReleaseLink(w, r)
+	if link == nil {
+		return
+	}
+	index, _ := f.findFakeObject("release_links", func(l fakeObject) bool { return fakeInt(l["id"]) == fakeInt(link["id"]) })
+	f.deleteFakeObject("release_links", index)
+	writeFakeJSON(w, http.StatusOK, link)
+}
+
+// packages
+
+func (f *FakeGitLab) findPackage(w http.ResponseWriter, r *fakeRequest) (fakeObject, fakeObject) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return nil, nil
+	}
+	_, pkg := f.findFakeObject("packages", func(pkg fakeObject) bool {
+		return fakeInt(pkg["project_id"]) == fakeInt(project["id"]) && fakeString(pkg["id"]) == r.vars["package"]
+	})
+	if pkg == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Package Not Found")
+		return project, nil
+	}
+	return project, pkg
+}
+
+// fakePackage returns the package like GitLab, with its links.
+func (f *FakeGitLab) fakePackage(project fakeObject, pkg fakeObject) fakeObject {
+	result := copyFakeObject(pkg)
+	delete(result, "project_id")
+	result["_links"] = fakeObject{
+		"web_path":        fmt.Sprintf("/%s/-/packages/%d", project["path_with_namespace"], fakeInt(pkg["id"])),
+		"delete_api_path": fmt.Sprintf("%sprojects/%d/packages/%d", f.URL, fakeInt(project["id"]), fakeInt(pkg["id"])),
+	}
+	return result
+}
+
+func (f *FakeGitLab) packageFiles(pkg fakeObject) []fakeObject {
+	return filterFakeObjects(f.collections["package_files"], func(file fakeObject) bool {
+		return fakeInt(file["package_id"]) == fakeInt(pkg["id"])
+	})
+}
+
+// uploadGenericPackageFile adds the file to the generic package, which is created on the first upload.
+// GitLab keeps the former files with the same name, it only returns the file if it's selected.
+func (f *FakeGitLab) uploadGenericPackageFile(w http.ResponseWriter, r *fakeRequest) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return
+	}
+	for _, name := range []string{"name", "version", "file"} {
+		if !fakePackagePathPattern.MatchString(r.vars[name]) {
+			writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": fmt.Sprintf("package_%s is invalid", name)})
+			return
+		}
+	}
+	status := fakeString(r.params["status"])
+	if status != "" && status != "default" && status != "hidden" {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "status does not have a valid value"})
+		return
+	}
+	sel := fakeString(r.params["select"])
+	if sel != "" && sel != "package_file" {
+		writeFakeJSON(w, http.StatusBadRequest, fakeObject{"error": "select does not have a valid value"})
+		return
+	}
+
+	_, pkg := f.findFakeObject("packages", func(pkg fakeObject) bool {
+		return fakeInt(pkg["project_id"]) == fakeInt(project["id"]) && pkg["package_type"] == "generic" &&
+			pkg["name"] == r.vars["name"] && pkg["version"] == r.vars["version"]
+	})
+	if pkg == nil {
+		pkg = fakeObject{
+			"id":           f.newID(),
+			"project_id":   project["id"],
+			"name":         r.vars["name"],
+			"version":      r.vars["version"],
+			"package_type": "generic",
+			"status":       "default",
+			"tags":         []string{},
+			"created_at":   fakeTimestamp(),
+		}
+		f.collections["packages"] = append(f.collections["packages"], pkg)
+	}
+	if status != "" {
+		pkg["status"] = status
+	}
+
+	md5Sum := md5.Sum(r.body)
+	sha1Sum := sha1.Sum(r.body)
+	sha256Sum := sha256.Sum256(r.body)
+	file := fakeObject{
+		"id":          f.newID(),
+		"package_id":  pkg["id"],
+		"file_name":   r.vars["file"],
+		"size":        len(r.body),
+		"file_md5":    hex.EncodeToString(md5Sum[:]),
+		"file_sha1":   hex.EncodeToString(sha1Sum[:]),
+		"file_sha256": hex.EncodeToString(sha256Sum[:]),
+		"created_at":  fakeTimestamp(),
+		"updated_at":  fakeTimestamp(),
+	}
+	f.collections["package_files"] = append(f.collections["package_files"], file)
+
+	if sel == "package_file" {
+		writeFakeJSON(w, http.StatusOK, file)
+		return
+	}
+	writeFakeJSON(w, http.StatusCreated, fakeObject{"message": "201 Created"})
+}
+
+func (f *FakeGitLab) listPackages(w http.ResponseWriter, r *fakeRequest) {
+	_, project := f.findProject(w, r.vars["project"])
+	if project == nil {
+		return
+	}
+	var packages []fakeObject
+	for _, pkg := range f.collections["packages"] {
+		if fakeInt(pkg["project_id"]) != fakeInt(project["id"]) {
+			continue
+		}
+		if value, ok := r.params["package_type"]; ok && pkg["package_type"] != value {
+			continue
+		}
+		// GitLab matches the name partially
+		if value, ok := r.params["package_name"]; ok && !strings.Contains(fakeString(pkg["name"]), fakeString(value)) {
+			continue
+		}
+		if value, ok := r.params["package_version"]; ok && pkg["version"] != value {
+			continue
+		}
+		// GitLab lists hidden packages only if they are requested explicitly
+		if value, ok := r.params["status"]; (ok && pkg["status"] != value) || (!ok && pkg["status"] == "hidden") {
+			continue
+		}
+		packages = append(packages, f.fakePackage(project, pkg))
+	}
+	if r.params["order_by"] == "type" {
+		r.params["order_by"] = "package_type"
+	}
+	writeFakePage(w, r, sortFakeObjects(r, packages, "asc"), false)
+}
+
+func (f *FakeGitLab) getPackage(w http.ResponseWriter, r *fakeRequest) {
+	if project, pkg := f.findPackage(w, r); pkg != nil {
+		writeFakeJSON(w, http.StatusOK, f.fakePackage(project, pkg))
+	}
+}
+
+func (f *FakeGitLab) deletePackage(w http.ResponseWriter, r *fakeRequest) {
+	_, pkg := f.findPackage(w, r)
+	if pkg == nil {
+		return
+	}
+	index, _ := f.findFakeObject("packages", func(p fakeObject) bool { return fakeInt(p["id"]) == fakeInt(pkg["id"]) })
+	f.deleteFakeObject("packages", index)
+	f.collections["package_files"] = filterFakeObjects(f.collections["package_files"], func(file fakeObject) bool {
+		return fakeInt(file["package_id"]) != fakeInt(pkg["id"])
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *FakeGitLab) listPackageFiles(w http.ResponseWriter, r *fakeRequest) {
+	_, pkg := f.findPackage(w, r)
+	if pkg == nil {
+		return
+	}
+	writeFakePage(w, r, sortFakeObjects(r, f.packageFiles(pkg), "asc"), false)
+}
+
+// deletePackageFile deletes the file of the package, GitLab keeps the package even if it has no files left.
+func (f *FakeGitLab) deletePackageFile(w http.ResponseWriter, r *fakeRequest) {
+	_, pkg := f.findPackage(w, r)
+	if pkg == nil {
+		return
+	}
+	index, file := f.findFakeObject("package_files", func(file fakeObject) bool {
+		return fakeInt(file["package_id"]) == fakeInt(pkg["id"]) && fakeString(file["id"]) == r.vars["file"]
+	})
+	if file == nil {
+		writeFakeError(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	f.deleteFakeObject("package_files", index)
+	w.WriteHeader(http.StatusNoContent)
+}
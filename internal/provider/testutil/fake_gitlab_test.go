@@ -0,0 +1,461 @@
+package testutil
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/xanzy/go-gitlab"
+)
+
+// requireStatus fails the test if the error isn't a GitLab API error with the given status code.
+func requireStatus(t *testing.T, err error, statusCode int) {
+	t.Helper()
+	var errorResponse *gitlab.ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("expected a GitLab API error with status %d, got %v", statusCode, err)
+	}
+	if errorResponse.Response.StatusCode != statusCode {
+		t.Fatalf("expected status %d, got %v", statusCode, err)
+	}
+}
+
+func TestFakeGitLab_authentication(t *testing.T) {
+	fake := NewFakeGitLab(t)
+
+	client, err := gitlab.NewClient("invalid", gitlab.WithBaseURL(fake.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	_, _, err = client.Users.CurrentUser()
+	requireStatus(t, err, http.StatusUnauthorized)
+
+	user, _, err := fake.Client.Users.CurrentUser()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Username != "root" || !user.IsAdmin {
+		t.Fatalf("expected the admin root, got %+v", user)
+	}
+}
+
+func TestFakeGitLab_projects(t *testing.T) {
+	fake := NewFakeGitLab(t)
+
+	group, _, err := fake.Client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("Foo"), Path: gitlab.String("foo")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+		Name:                 gitlab.String("Bar"),
+		NamespaceID:          gitlab.Int(group.ID),
+		InitializeWithReadme: gitlab.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if project.PathWithNamespace != "foo/bar" || project.DefaultBranch != "main" {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+
+	_, _, err = fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("Bar"), NamespaceID: gitlab.Int(group.ID)})
+	requireStatus(t, err, http.StatusBadRequest)
+
+	byPath, _, err := fake.Client.Projects.GetProject("foo/bar", nil)
+	if err != nil || byPath.ID != project.ID {
+		t.Fatalf("expected project %d by its path, got %v, %v", project.ID, byPath, err)
+	}
+
+	project, _, err = fake.Client.Projects.EditProject(project.ID, &gitlab.EditProjectOptions{Description: gitlab.String("updated")})
+	if err != nil || project.Description != "updated" {
+		t.Fatalf("expected the updated project, got %v, %v", project, err)
+	}
+
+	if _, err := fake.Client.Projects.DeleteProject(project.ID); err != nil {
+		t.Fatalf("failed to delete project: %v", err)
+	}
+	_, _, err = fake.Client.Projects.GetProject(project.ID, nil)
+	requireStatus(t, err, http.StatusNotFound)
+}
+
+func TestFakeGitLab_pagination(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	for i := 0; i < 5; i++ {
+		if _, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Path: gitlab.String("project-" + string(rune('a'+i)))}); err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+	}
+
+	projects, resp, err := fake.Client.Projects.ListProjects(&gitlab.ListProjectsOptions{ListOptions: gitlab.ListOptions{Page: 2, PerPage: 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(projects) != 2 || resp.TotalItems != 5 || resp.TotalPages != 3 || resp.NextPage != 3 || resp.PreviousPage != 1 {
+		t.Fatalf("unexpected page: %d projects, %+v", len(projects), resp)
+	}
+
+	// keyset pagination follows the `Link` header
+	var ids []int
+	opts := &gitlab.ListProjectsOptions{ListOptions: gitlab.ListOptions{PerPage: 2}, OrderBy: gitlab.String("id"), Sort: gitlab.String("asc")}
+	options := []gitlab.RequestOptionFunc{func(req *retryablehttp.Request) error {
+		query := req.URL.Query()
+		query.Set("pagination", "keyset")
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}}
+	for {
+		projects, resp, err := fake.Client.Projects.ListProjects(opts, options...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, project := range projects {
+			ids = append(ids, project.ID)
+		}
+		next := resp.Header.Get("Link")
+		if next == "" {
+			break
+		}
+		opts.IDAfter = gitlab.Int(ids[len(ids)-1])
+	}
+	if len(ids) != 5 {
+		t.Fatalf("expected 5 projects with keyset pagination, got %v", ids)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("expected the projects ordered by ID, got %v", ids)
+		}
+	}
+}
+
+func TestFakeGitLab_variables(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo")})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	for _, scope := range []string{"*", "production"} {
+		if _, _, err := fake.Client.ProjectVariables.CreateVariable(project.ID, &gitlab.CreateProjectVariableOptions{
+			Key: gitlab.String("FOO"), Value: gitlab.String("bar"), EnvironmentScope: gitlab.String(scope),
+		}); err != nil {
+			t.Fatalf("failed to create variable: %v", err)
+		}
+	}
+
+	_, _, err = fake.Client.ProjectVariables.CreateVariable(project.ID, &gitlab.CreateProjectVariableOptions{Key: gitlab.String("FOO"), Value: gitlab.String("bar")})
+	requireStatus(t, err, http.StatusBadRequest)
+
+	_, _, err = fake.Client.ProjectVariables.CreateVariable(project.ID, &gitlab.CreateProjectVariableOptions{Key: gitlab.String("MASKED"), Value: gitlab.String("short"), Masked: gitlab.Bool(true)})
+	requireStatus(t, err, http.StatusBadRequest)
+
+	_, _, err = fake.Client.ProjectVariables.GetVariable(project.ID, "FOO", nil)
+	requireStatus(t, err, http.StatusConflict)
+
+	variable, _, err := fake.Client.ProjectVariables.GetVariable(project.ID, "FOO", &gitlab.GetProjectVariableOptions{
+		Filter: &gitlab.VariableFilter{EnvironmentScope: "production"},
+	})
+	if err != nil || variable.EnvironmentScope != "production" {
+		t.Fatalf("expected the variable of the production scope, got %v, %v", variable, err)
+	}
+}
+
+func TestFakeGitLab_repositoryFiles(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo"), InitializeWithReadme: gitlab.Bool(true)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	if _, _, err := fake.Client.RepositoryFiles.CreateFile(project.ID, "dir/file.txt", &gitlab.CreateFileOptions{
+		Branch: gitlab.String("main"), Content: gitlab.String("hello"), CommitMessage: gitlab.String("add file"),
+	}); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	_, _, err = fake.Client.RepositoryFiles.CreateFile(project.ID, "dir/file.txt", &gitlab.CreateFileOptions{
+		Branch: gitlab.String("main"), Content: gitlab.String("hello"), CommitMessage: gitlab.String("add file"),
+	})
+	requireStatus(t, err, http.StatusBadRequest)
+
+	file, _, err := fake.Client.RepositoryFiles.GetFile(project.ID, "dir/file.txt", &gitlab.GetFileOptions{Ref: gitlab.String("main")})
+	if err != nil || file.Content != "aGVsbG8=" || file.LastCommitID == "" {
+		t.Fatalf("unexpected file: %+v, %v", file, err)
+	}
+
+	// the branch starts with the files of the default branch, but changes to it are independent
+	if _, _, err := fake.Client.Branches.CreateBranch(project.ID, &gitlab.CreateBranchOptions{Branch: gitlab.String("feature"), Ref: gitlab.String("main")}); err != nil {
+		t.Fatalf("failed to create branch: %v", err)
+	}
+	if _, _, err := fake.Client.RepositoryFiles.UpdateFile(project.ID, "dir/file.txt", &gitlab.UpdateFileOptions{
+		Branch: gitlab.String("feature"), Content: gitlab.String("bye"), CommitMessage: gitlab.String("update file"), LastCommitID: gitlab.String(file.LastCommitID),
+	}); err != nil {
+		t.Fatalf("failed to update file: %v", err)
+	}
+	_, _, err = fake.Client.RepositoryFiles.UpdateFile(project.ID, "dir/file.txt", &gitlab.UpdateFileOptions{
+		Branch: gitlab.String("feature"), Content: gitlab.String("again"), CommitMessage: gitlab.String("update file"), LastCommitID: gitlab.String(file.LastCommitID),
+	})
+	requireStatus(t, err, http.StatusBadRequest)
+
+	content, _, err := fake.Client.RepositoryFiles.GetRawFile(project.ID, "dir/file.txt", &gitlab.GetRawFileOptions{Ref: gitlab.String("main")})
+	if err != nil || string(content) != "hello" {
+		t.Fatalf("expected the file on main unchanged, got %q, %v", content, err)
+	}
+
+	if _, err := fake.Client.RepositoryFiles.DeleteFile(project.ID, "dir/file.txt", &gitlab.DeleteFileOptions{Branch: gitlab.String("main"), CommitMessage: gitlab.String("delete file")}); err != nil {
+		t.Fatalf("failed to delete file: %v", err)
+	}
+	_, _, err = fake.Client.RepositoryFiles.GetFile(project.ID, "dir/file.txt", &gitlab.GetFileOptions{Ref: gitlab.String("main")})
+	requireStatus(t, err, http.StatusNotFound)
+}
+
+func TestFakeGitLab_commits(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo"), InitializeWithReadme: gitlab.Bool(true)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	commit, _, err := fake.Client.Commits.CreateCommit(project.ID, &gitlab.CreateCommitOptions{
+		Branch:        gitlab.String("main"),
+		CommitMessage: gitlab.String("add files"),
+		Actions: []*gitlab.CommitActionOptions{
+			{Action: gitlab.FileAction(gitlab.FileCreate), FilePath: gitlab.String("a.txt"), Content: gitlab.String("a")},
+			{Action: gitlab.FileAction(gitlab.FileCreate), FilePath: gitlab.String("run.sh"), Content: gitlab.String("echo"), ExecuteFilemode: gitlab.Bool(true)},
+			{Action: gitlab.FileAction(gitlab.FileMove), FilePath: gitlab.String("README.txt"), PreviousPath: gitlab.String("README.md")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create commit: %v", err)
+	}
+	file, _, err := fake.Client.RepositoryFiles.GetFileMetaData(project.ID, "run.sh", &gitlab.GetFileMetaDataOptions{Ref: gitlab.String("main")})
+	if err != nil || !file.ExecuteFilemode || file.LastCommitID != commit.ID || file.Size != 4 {
+		t.Fatalf("unexpected file: %+v, %v", file, err)
+	}
+	_, _, err = fake.Client.RepositoryFiles.GetFileMetaData(project.ID, "README.md", &gitlab.GetFileMetaDataOptions{Ref: gitlab.String("main")})
+	requireStatus(t, err, http.StatusNotFound)
+
+	// a failing action rejects the whole commit
+	_, _, err = fake.Client.Commits.CreateCommit(project.ID, &gitlab.CreateCommitOptions{
+		Branch:        gitlab.String("main"),
+		CommitMessage: gitlab.String("change files"),
+		Actions: []*gitlab.CommitActionOptions{
+			{Action: gitlab.FileAction(gitlab.FileDelete), FilePath: gitlab.String("a.txt")},
+			{Action: gitlab.FileAction(gitlab.FileCreate), FilePath: gitlab.String("run.sh"), Content: gitlab.String("echo")},
+		},
+	})
+	requireStatus(t, err, http.StatusBadRequest)
+	if _, _, err := fake.Client.RepositoryFiles.GetFile(project.ID, "a.txt", &gitlab.GetFileOptions{Ref: gitlab.String("main")}); err != nil {
+		t.Fatalf("expected the file to be unchanged: %v", err)
+	}
+}
+
+func TestFakeGitLab_mergeRequests(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo"), InitializeWithReadme: gitlab.Bool(true)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if _, _, err := fake.Client.RepositoryFiles.CreateFile(project.ID, "new.txt", &gitlab.CreateFileOptions{
+		Branch: gitlab.String("feature"), StartBranch: gitlab.String("main"), Content: gitlab.String("new"), CommitMessage: gitlab.String("add file"),
+	}); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	options := &gitlab.CreateMergeRequestOptions{
+		Title:              gitlab.String("Add file"),
+		SourceBranch:       gitlab.String("feature"),
+		TargetBranch:       gitlab.String("main"),
+		Labels:             &gitlab.Labels{"foo", "bar"},
+		AssigneeIDs:        &[]int{1},
+		ReviewerIDs:        &[]int{1},
+		RemoveSourceBranch: gitlab.Bool(true),
+	}
+	mergeRequest, _, err := fake.Client.MergeRequests.CreateMergeRequest(project.ID, options)
+	if err != nil {
+		t.Fatalf("failed to create merge request: %v", err)
+	}
+	if mergeRequest.IID != 1 || mergeRequest.State != "opened" || len(mergeRequest.Labels) != 2 || len(mergeRequest.Assignees) != 1 || mergeRequest.Assignees[0].Username != "root" || len(mergeRequest.Reviewers) != 1 {
+		t.Fatalf("unexpected merge request: %+v", mergeRequest)
+	}
+	_, _, err = fake.Client.MergeRequests.CreateMergeRequest(project.ID, options)
+	requireStatus(t, err, http.StatusConflict)
+
+	// auto-merge waits for the pipeline
+	mergeRequest, _, err = fake.Client.MergeRequests.AcceptMergeRequest(project.ID, mergeRequest.IID, &gitlab.AcceptMergeRequestOptions{MergeWhenPipelineSucceeds: gitlab.Bool(true)})
+	if err != nil || mergeRequest.State != "opened" || !mergeRequest.MergeWhenPipelineSucceeds {
+		t.Fatalf("expected the merge request to wait for the pipeline, got %+v, %v", mergeRequest, err)
+	}
+	mergeRequest, _, err = fake.Client.MergeRequests.CancelMergeWhenPipelineSucceeds(project.ID, mergeRequest.IID)
+	if err != nil || mergeRequest.MergeWhenPipelineSucceeds {
+		t.Fatalf("expected auto-merge to be canceled, got %+v, %v", mergeRequest, err)
+	}
+	mergeRequest, _, err = fake.Client.MergeRequests.AcceptMergeRequest(project.ID, mergeRequest.IID, nil)
+	if err != nil || mergeRequest.State != "merged" {
+		t.Fatalf("expected the merge request to be merged, got %+v, %v", mergeRequest, err)
+	}
+	if _, _, err := fake.Client.RepositoryFiles.GetFile(project.ID, "new.txt", &gitlab.GetFileOptions{Ref: gitlab.String("main")}); err != nil {
+		t.Fatalf("expected the file to be merged: %v", err)
+	}
+	_, _, err = fake.Client.Branches.GetBranch(project.ID, "feature")
+	requireStatus(t, err, http.StatusNotFound)
+
+	mergeRequests, _, err := fake.Client.MergeRequests.ListProjectMergeRequests(project.ID, &gitlab.ListProjectMergeRequestsOptions{State: gitlab.String("merged"), AuthorUsername: gitlab.String("root")})
+	if err != nil || len(mergeRequests) != 1 {
+		t.Fatalf("expected the merged merge request, got %v, %v", mergeRequests, err)
+	}
+	mergeRequests, _, err = fake.Client.MergeRequests.ListProjectMergeRequests(project.ID, &gitlab.ListProjectMergeRequestsOptions{AuthorID: gitlab.Int(42)})
+	if err != nil || len(mergeRequests) != 0 {
+		t.Fatalf("expected no merge requests of another author, got %v, %v", mergeRequests, err)
+	}
+}
+
+func TestFakeGitLab_releases(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo"), InitializeWithReadme: gitlab.Bool(true)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if _, _, err := fake.Client.Milestones.CreateMilestone(project.ID, &gitlab.CreateMilestoneOptions{Title: gitlab.String("v1")}); err != nil {
+		t.Fatalf("failed to create milestone: %v", err)
+	}
+
+	_, _, err = fake.Client.Releases.CreateRelease(project.ID, &gitlab.CreateReleaseOptions{TagName: gitlab.String("v1.0.0")})
+	requireStatus(t, err, http.StatusUnprocessableEntity)
+	_, _, err = fake.Client.Releases.CreateRelease(project.ID, &gitlab.CreateReleaseOptions{TagName: gitlab.String("v1.0.0"), Ref: gitlab.String("main"), Milestones: &[]string{"v2"}})
+	requireStatus(t, err, http.StatusBadRequest)
+
+	linkType := gitlab.OtherLinkType
+	release, _, err := fake.Client.Releases.CreateRelease(project.ID, &gitlab.CreateReleaseOptions{
+		TagName:    gitlab.String("v1.0.0"),
+		Ref:        gitlab.String("main"),
+		Milestones: &[]string{"v1"},
+		Assets: &gitlab.ReleaseAssetsOptions{Links: []*gitlab.ReleaseAssetLinkOptions{
+			{Name: gitlab.String("binary"), URL: gitlab.String("https://example.com/binary"), FilePath: gitlab.String("/bin/foo"), LinkType: &linkType},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create release: %v", err)
+	}
+	if release.Name != "v1.0.0" || len(release.Assets.Links) != 1 || !strings.HasSuffix(release.Assets.Links[0].DirectAssetURL, "/-/releases/v1.0.0/downloads/bin/foo") {
+		t.Fatalf("unexpected release: %+v", release)
+	}
+	if _, _, err := fake.Client.Tags.GetTag(project.ID, "v1.0.0"); err != nil {
+		t.Fatalf("expected the tag to be created: %v", err)
+	}
+	_, _, err = fake.Client.Releases.CreateRelease(project.ID, &gitlab.CreateReleaseOptions{TagName: gitlab.String("v1.0.0")})
+	requireStatus(t, err, http.StatusConflict)
+
+	_, _, err = fake.Client.ReleaseLinks.CreateReleaseLink(project.ID, "v1.0.0", &gitlab.CreateReleaseLinkOptions{Name: gitlab.String("binary"), URL: gitlab.String("https://example.com/other")})
+	requireStatus(t, err, http.StatusBadRequest)
+	if _, _, err := fake.Client.ReleaseLinks.CreateReleaseLink(project.ID, "v1.0.0", &gitlab.CreateReleaseLinkOptions{Name: gitlab.String("docs"), URL: gitlab.String("https://example.com/docs")}); err != nil {
+		t.Fatalf("failed to create release link: %v", err)
+	}
+	release, _, err = fake.Client.Releases.UpdateRelease(project.ID, "v1.0.0", &gitlab.UpdateReleaseOptions{Name: gitlab.String("First"), Milestones: &[]string{}})
+	if err != nil || release.Name != "First" || len(release.Assets.Links) != 2 {
+		t.Fatalf("unexpected release: %+v, %v", release, err)
+	}
+
+	if _, _, err := fake.Client.Releases.DeleteRelease(project.ID, "v1.0.0"); err != nil {
+		t.Fatalf("failed to delete release: %v", err)
+	}
+	_, _, err = fake.Client.ReleaseLinks.ListReleaseLinks(project.ID, "v1.0.0", nil)
+	requireStatus(t, err, http.StatusNotFound)
+	if _, _, err := fake.Client.Tags.GetTag(project.ID, "v1.0.0"); err != nil {
+		t.Fatalf("expected the tag to be kept: %v", err)
+	}
+}
+
+func TestFakeGitLab_genericPackages(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("foo")})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	_, _, err = fake.Client.GenericPackages.PublishPackageFile(project.ID, "foo", "1.0.0", "bar baz.txt", strings.NewReader("bar"), nil)
+	requireStatus(t, err, http.StatusBadRequest)
+	hidden := gitlab.PackageHidden
+	_, _, err = fake.Client.GenericPackages.PublishPackageFile(project.ID, "foo", "1.0.0", "bar.txt", strings.NewReader("bar"), &gitlab.PublishPackageFileOptions{Status: &hidden})
+	if err != nil {
+		t.Fatalf("failed to publish package file: %v", err)
+	}
+	file, _, err := fake.Client.GenericPackages.PublishPackageFile(project.ID, "foo", "1.0.0", "bar.txt", strings.NewReader("bar"), &gitlab.PublishPackageFileOptions{
+		Select: gitlab.GenericPackageSelect(gitlab.SelectPackageFile),
+	})
+	if err != nil {
+		t.Fatalf("failed to publish package file: %v", err)
+	}
+	if file.FileName != "bar.txt" || file.Size != 3 || file.FileSHA256 != "fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9" {
+		t.Fatalf("unexpected package file: %+v", file)
+	}
+
+	packages, _, err := fake.Client.Packages.ListProjectPackages(project.ID, &gitlab.ListProjectPackagesOptions{PackageType: gitlab.String("generic")})
+	if err != nil || len(packages) != 0 {
+		t.Fatalf("expected the hidden package to be omitted, got %v, %v", packages, err)
+	}
+	packages, _, err = fake.Client.Packages.ListProjectPackages(project.ID, &gitlab.ListProjectPackagesOptions{PackageType: gitlab.String("generic"), PackageName: gitlab.String("fo"), Status: gitlab.String("hidden")})
+	if err != nil || len(packages) != 1 || packages[0].ID != file.PackageID || packages[0].Status != "hidden" {
+		t.Fatalf("expected the hidden package, got %v, %v", packages, err)
+	}
+	// GitLab keeps the former files with the same name
+	files, _, err := fake.Client.Packages.ListPackageFiles(project.ID, file.PackageID, nil)
+	if err != nil || len(files) != 2 {
+		t.Fatalf("expected both package files, got %v, %v", files, err)
+	}
+
+	if _, err := fake.Client.Packages.DeletePackageFile(project.ID, file.PackageID, file.ID); err != nil {
+		t.Fatalf("failed to delete package file: %v", err)
+	}
+	_, err = fake.Client.Packages.DeletePackageFile(project.ID, file.PackageID, file.ID)
+	requireStatus(t, err, http.StatusNotFound)
+	if _, err := fake.Client.Packages.DeleteProjectPackage(project.ID, file.PackageID); err != nil {
+		t.Fatalf("failed to delete package: %v", err)
+	}
+	_, _, err = fake.Client.Packages.ListPackageFiles(project.ID, file.PackageID, nil)
+	requireStatus(t, err, http.StatusNotFound)
+}
+
+func TestFakeGitLab_membersAndLabels(t *testing.T) {
+	fake := NewFakeGitLab(t)
+	group, _, err := fake.Client.Groups.CreateGroup(&gitlab.CreateGroupOptions{Name: gitlab.String("foo"), Path: gitlab.String("foo")})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	user, _, err := fake.Client.Users.CreateUser(&gitlab.CreateUserOptions{Email: gitlab.String("user@example.com"), Name: gitlab.String("User"), Username: gitlab.String("user")})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	if _, _, err := fake.Client.GroupMembers.AddGroupMember(group.ID, &gitlab.AddGroupMemberOptions{UserID: gitlab.Int(user.ID), AccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions)}); err != nil {
+		t.Fatalf("failed to add member: %v", err)
+	}
+	_, _, err = fake.Client.GroupMembers.AddGroupMember(group.ID, &gitlab.AddGroupMemberOptions{UserID: gitlab.Int(user.ID), AccessLevel: gitlab.AccessLevel(gitlab.DeveloperPermissions)})
+	requireStatus(t, err, http.StatusConflict)
+
+	// members of the group are inherited by its projects
+	project, _, err := fake.Client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.String("bar"), NamespaceID: gitlab.Int(group.ID)})
+	if err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	member, _, err := fake.Client.ProjectMembers.GetInheritedProjectMember(project.ID, user.ID)
+	if err != nil || member.AccessLevel != gitlab.DeveloperPermissions {
+		t.Fatalf("expected the inherited developer, got %v, %v", member, err)
+	}
+	_, _, err = fake.Client.ProjectMembers.GetProjectMember(project.ID, user.ID)
+	requireStatus(t, err, http.StatusNotFound)
+
+	if _, _, err := fake.Client.Labels.CreateLabel(project.ID, &gitlab.CreateLabelOptions{Name: gitlab.String("bug"), Color: gitlab.String("#FF0000")}); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	_, _, err = fake.Client.Labels.CreateLabel(project.ID, &gitlab.CreateLabelOptions{Name: gitlab.String("bug"), Color: gitlab.String("#FF0000")})
+	requireStatus(t, err, http.StatusConflict)
+
+	if _, _, err := fake.Client.Labels.UpdateLabel(project.ID, &gitlab.UpdateLabelOptions{Name: gitlab.String("bug"), NewName: gitlab.String("defect")}); err != nil {
+		t.Fatalf("failed to update label: %v", err)
+	}
+	label, _, err := fake.Client.Labels.GetLabel(project.ID, "defect")
+	if err != nil || label.Color != "#FF0000" {
+		t.Fatalf("expected the renamed label, got %v, %v", label, err)
+	}
+}
@@ -29,6 +29,9 @@ var testGitlabConfig = client.Config{
 	ClientCert:    "",
 	ClientKey:     "",
 	EarlyAuthFail: true,
+	MaxRetries:    client.DefaultMaxRetries,
+	RetryWaitMin:  client.DefaultRetryWaitMin,
+	RetryWaitMax:  client.DefaultRetryWaitMax,
 }
 
 var TestGitlabClient *gitlab.Client
@@ -0,0 +1,34 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// UpdateResource plans the change of a resource from the state of the given resource data to the given configuration
+// and applies it with the update function of the resource, like Terraform does. It returns the resource data
+// after the update. Use it to unit test the update function of a resource against the fake GitLab API:
+//
+//	d := schema.TestResourceDataRaw(t, r.Schema, config)
+//	r.CreateContext(ctx, d, meta)
+//	d = testutil.UpdateResource(t, r, d, updatedConfig, meta)
+func UpdateResource(t *testing.T, r *schema.Resource, d *schema.ResourceData, config map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+	ctx := context.Background()
+	state := d.State()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("failed to diff the resource: %v", err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("failed to apply the diff: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("failed to update the resource: %v", diags)
+	}
+	return d
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
 
 	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider"
+	"gitlab.com/gitlab-org/terraform-provider-gitlab/internal/provider/client"
 )
 
+const providerAddress = "registry.terraform.io/providers/gitlabhq/gitlab"
+
 var (
 	// these will be set by the goreleaser configuration
 	// to appropriate values for the compiled binary
@@ -17,6 +23,13 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	debugFlag := flag.Bool("debug", false, "Start provider in debug mode.")
 	flag.Parse()
 
@@ -31,11 +44,21 @@ func main() {
 	}
 
 	err = tf6server.Serve(
-		"registry.terraform.io/providers/gitlabhq/gitlab",
+		providerAddress,
 		serverFactory,
 		serveOpts...,
 	)
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	// The server has been shut down by Terraform at the end of the operation.
+	// Log the API usage with a logger configured like the ones of the served requests.
+	ctx := tfsdklog.NewRootProviderLogger(
+		context.Background(),
+		tfsdklog.WithStderrFromInit(),
+		tfsdklog.WithLogName("gitlab"),
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER", "gitlab"),
+	)
+	client.LogUsageSummary(ctx)
 }
@@ -25,6 +25,7 @@ cat - | jq -R 'fromjson?' | jq -c '. | select(."@module" == "gitlab.GitLab" and
     if [[ -z "$URI_REGEX" || "$uri" =~ $URI_REGEX ]]; then
       echo "==> $(echo $record | jq -r ".tf_http_req_method") $uri"
       echo "    TRANS-ID: $trans_id at $(echo $record | jq -r '."@timestamp"')"
+      echo "    CORRELATION-ID: $(echo $record | jq -r '.gitlab_correlation_id')"
       echo "    HTTP BODY:"
       echo $record | jq -r '.tf_http_req_body' | jq -C | sed 's/^/    /'
       printed_requests+=($trans_id)